@@ -7,8 +7,10 @@ import (
 	"path"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/olekukonko/tablewriter"
 )
@@ -76,10 +78,73 @@ func generateResourcesDocs(docsPath string, resources map[string]*schema.Resourc
 			table.Append([]string{property, typeString(s.Type), s.Description, boolString(s.Optional), boolString(s.Required), boolString(s.Computed), interfaceString(s.Default)})
 		}
 		table.Render()
+
+		if strings.HasSuffix(name, "_grant") {
+			writePrivilegesTable(f, name)
+		}
+		if resource.Timeouts != nil {
+			writeTimeoutsTable(f, resource.Timeouts)
+		}
 		f.Close()
 	}
 }
 
+// writePrivilegesTable documents which privileges a grant resource accepts,
+// straight from the privilege matrix used to validate them.
+func writePrivilegesTable(f *os.File, name string) {
+	objectType := strings.TrimSuffix(strings.TrimPrefix(name, "snowflake_"), "_grant")
+	objectType = strings.ToUpper(strings.Replace(objectType, "_", " ", -1))
+
+	rules := resources.PrivilegeRules(objectType)
+	if len(rules) == 0 {
+		return
+	}
+
+	_, err := f.WriteString("\n## privileges\n\n")
+	if err != nil {
+		log.Fatalf("unable to write doc file %#v", err)
+	}
+
+	table := tablewriter.NewWriter(f)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"privilege", "grantees", "on_future", "with_grant_option"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	for _, rule := range rules {
+		table.Append([]string{rule.Privilege, strings.Join(rule.Grantees, ", "), boolString(rule.OnFuture), boolString(rule.GrantOption)})
+	}
+	table.Render()
+}
+
+// writeTimeoutsTable documents the operations whose default timeout can be
+// overridden in a timeouts block.
+func writeTimeoutsTable(f *os.File, timeouts *schema.ResourceTimeout) {
+	_, err := f.WriteString("\n## timeouts\n\n")
+	if err != nil {
+		log.Fatalf("unable to write doc file %#v", err)
+	}
+
+	table := tablewriter.NewWriter(f)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"operation", "default"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	for _, t := range []struct {
+		operation string
+		timeout   *time.Duration
+	}{
+		{"create", timeouts.Create},
+		{"read", timeouts.Read},
+		{"update", timeouts.Update},
+		{"delete", timeouts.Delete},
+	} {
+		if t.timeout != nil {
+			table.Append([]string{t.operation, t.timeout.String()})
+		}
+	}
+	table.Render()
+}
+
 func typeString(t schema.ValueType) string {
 	switch t {
 	case schema.TypeBool:
@@ -17,6 +17,8 @@ require (
 	github.com/snowflakedb/gosnowflake v1.3.4
 	github.com/stretchr/testify v1.5.1
 	github.com/vmihailenco/msgpack v4.0.4+incompatible // indirect
+	github.com/youmark/pkcs8 v0.0.0-20201027041543-1326539a0a0a
 	golang.org/x/crypto v0.0.0-20200707235045-ab33eee955e0
+	golang.org/x/oauth2 v0.0.0-20200107190931-bf48bf16ab8d
 	golang.org/x/tools v0.0.0-20200731060945-b5fad4ed8dd6 // indirect
 )
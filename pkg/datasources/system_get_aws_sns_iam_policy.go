@@ -1,8 +1,7 @@
 package datasources
 
 import (
-	"database/sql"
-
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
@@ -30,11 +29,14 @@ func SystemGetAWSSNSIAMPolicy() *schema.Resource {
 
 // ReadSystemGetAWSSNSIAMPolicy implements schema.ReadFunc
 func ReadSystemGetAWSSNSIAMPolicy(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := resources.OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
+
 	awsSNSTopicArn := data.Get("aws_sns_topic_arn").(string)
 
 	sel := snowflake.SystemGetAWSSNSIAMPolicy(awsSNSTopicArn).Select()
-	row := snowflake.QueryRow(db, sel)
+	row := client.QueryRowContext(ctx, sel)
 	policy, err := snowflake.ScanAWSSNSIAMPolicy(row)
 	if err != nil {
 		return err
@@ -3,11 +3,13 @@ package db
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"log"
 	"regexp"
 
 	"github.com/ExpansiveWorlds/instrumentedsql"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	"github.com/snowflakedb/gosnowflake"
 )
 
@@ -16,12 +18,38 @@ func init() {
 
 	logger := instrumentedsql.LoggerFunc(func(ctx context.Context, msg string, keyvals ...interface{}) {
 		s := fmt.Sprintf("[DEBUG] %s %v\n", msg, keyvals)
-		log.Println(re.ReplaceAllString(s, " "))
+		log.Println(snowflake.Redact(re.ReplaceAllString(s, " ")))
 	})
 
-	sql.Register("snowflake-instrumented", instrumentedsql.WrapDriver(&gosnowflake.SnowflakeDriver{}, instrumentedsql.WithLogger(logger)))
+	instrumentedDriver = instrumentedsql.WrapDriver(&gosnowflake.SnowflakeDriver{}, instrumentedsql.WithLogger(logger))
+	sql.Register("snowflake-instrumented", instrumentedDriver)
 }
 
+var instrumentedDriver driver.Driver
+
 func Open(dsn string) (*sql.DB, error) {
 	return sql.Open("snowflake-instrumented", dsn)
 }
+
+// OpenWithDSNFunc opens a database that builds a new DSN with dsn for every
+// connection it opens. This allows credentials that expire, such as OAuth
+// access tokens, to be renewed for the lifetime of the *sql.DB.
+func OpenWithDSNFunc(dsn func() (string, error)) *sql.DB {
+	return sql.OpenDB(&dsnFuncConnector{dsn: dsn})
+}
+
+type dsnFuncConnector struct {
+	dsn func() (string, error)
+}
+
+func (c *dsnFuncConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	dsn, err := c.dsn()
+	if err != nil {
+		return nil, err
+	}
+	return instrumentedDriver.Open(dsn)
+}
+
+func (c *dsnFuncConnector) Driver() driver.Driver {
+	return instrumentedDriver
+}
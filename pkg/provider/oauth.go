@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+// oauthMinValidity is how long the access token a new connection is opened
+// with is at least valid for, if the endpoint hands out tokens that long
+// lived. Connections are not reused for longer, see OAuthConnMaxLifetime.
+const oauthMinValidity = 5 * time.Minute
+
+// OAuthTokenSource returns a TokenSource that exchanges refreshToken for
+// access tokens at tokenEndpoint. Tokens are cached and refreshed once they
+// expire within oauthMinValidity, and a rotated refresh token returned by the
+// endpoint is used for the next refresh.
+func OAuthTokenSource(clientID, clientSecret, refreshToken, tokenEndpoint string) oauth2.TokenSource {
+	config := &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Endpoint: oauth2.Endpoint{
+			TokenURL: tokenEndpoint,
+		},
+	}
+	return &minValidityTokenSource{
+		config:      config,
+		token:       &oauth2.Token{RefreshToken: refreshToken},
+		minValidity: oauthMinValidity,
+	}
+}
+
+// minValidityTokenSource refreshes its token earlier than the TokenSource of
+// an oauth2.Config would, once it expires within minValidity
+type minValidityTokenSource struct {
+	config      *oauth2.Config
+	minValidity time.Duration
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+func (s *minValidityTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.AccessToken != "" && (s.token.Expiry.IsZero() || time.Until(s.token.Expiry) > s.minValidity) {
+		return s.token, nil
+	}
+	// The TokenSource of a token without an access token refreshes it
+	token, err := s.config.TokenSource(context.Background(), &oauth2.Token{RefreshToken: s.token.RefreshToken}).Token()
+	if err != nil {
+		return nil, err
+	}
+	s.token = token
+	return token, nil
+}
+
+// OAuthConnMaxLifetime returns how long a connection opened with an access
+// token from OAuthTokenSource may be reused, given token, the first token it
+// returned. Pooled connections must not outlive their token, as Snowflake
+// rejects it once it expired.
+func OAuthConnMaxLifetime(token *oauth2.Token) time.Duration {
+	if token.Expiry.IsZero() {
+		return oauthMinValidity
+	}
+	if lifetime := time.Until(token.Expiry); lifetime < oauthMinValidity {
+		return lifetime
+	}
+	return oauthMinValidity
+}
+
+// OAuthDSNFunc returns a function that builds the DSN of cfg with a current
+// access token from ts. It is called for every new connection, so connections opened
+// late in a long apply do not use an expired token.
+func OAuthDSNFunc(ts oauth2.TokenSource, cfg DSNConfig) func() (string, error) {
+	return func() (string, error) {
+		token, err := ts.Token()
+		if err != nil {
+			return "", errors.Wrap(err, "could not refresh oauth access token")
+		}
+		cfg.OAuthAccessToken = token.AccessToken
+		return DSN(cfg)
+	}
+}
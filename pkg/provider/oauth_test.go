@@ -0,0 +1,169 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+// fakeTokenEndpoint is a local OAuth token endpoint that hands out numbered
+// access tokens for the refresh_token grant. It runs on the server goroutine,
+// so it records invalid requests for the test to assert on instead of failing
+// the test itself.
+type fakeTokenEndpoint struct {
+	expiresIn int
+
+	mu       sync.Mutex
+	requests int
+	errs     []error
+}
+
+func (f *fakeTokenEndpoint) check(req *http.Request) error {
+	if err := req.ParseForm(); err != nil {
+		return err
+	}
+	if grantType := req.PostForm.Get("grant_type"); grantType != "refresh_token" {
+		return fmt.Errorf("unexpected grant_type %q", grantType)
+	}
+	if refreshToken := req.PostForm.Get("refresh_token"); refreshToken != "refresh-token" {
+		return fmt.Errorf("unexpected refresh_token %q", refreshToken)
+	}
+	clientID, clientSecret, ok := req.BasicAuth()
+	if !ok || clientID != "client-id" || clientSecret != "client-secret" {
+		return fmt.Errorf("unexpected client credentials %q, %q", clientID, clientSecret)
+	}
+	return nil
+}
+
+func (f *fakeTokenEndpoint) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	err := f.check(req)
+
+	f.mu.Lock()
+	if err != nil {
+		f.errs = append(f.errs, err)
+	}
+	f.requests++
+	n := f.requests
+	f.mu.Unlock()
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token":  fmt.Sprintf("access-token-%d", n),
+		"refresh_token": "refresh-token",
+		"token_type":    "Bearer",
+		"expires_in":    f.expiresIn,
+	})
+}
+
+func (f *fakeTokenEndpoint) errors() []error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.errs
+}
+
+func TestOAuthTokenSource(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresIn int
+		tokens    []string
+	}{
+		{"cached until expiry", 600, []string{"access-token-1", "access-token-1"}},
+		// tokens that expire within the refresh margin are refreshed on every use
+		{"refreshed before expiry", 5, []string{"access-token-1", "access-token-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			endpoint := &fakeTokenEndpoint{expiresIn: tt.expiresIn}
+			server := httptest.NewServer(endpoint)
+			defer server.Close()
+
+			ts := provider.OAuthTokenSource("client-id", "client-secret", "refresh-token", server.URL)
+			for _, expected := range tt.tokens {
+				token, err := ts.Token()
+				r.NoError(err)
+				r.Equal(expected, token.AccessToken)
+			}
+			r.Empty(endpoint.errors())
+		})
+	}
+}
+
+func TestOAuthDSNFunc(t *testing.T) {
+	r := require.New(t)
+	endpoint := &fakeTokenEndpoint{expiresIn: 5}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	ts := provider.OAuthTokenSource("client-id", "client-secret", "refresh-token", server.URL)
+	dsnFunc := provider.OAuthDSNFunc(ts, provider.DSNConfig{
+		Account: "acct",
+		User:    "user",
+		Region:  "region",
+		Role:    "role",
+	})
+
+	dsn, err := dsnFunc()
+	r.NoError(err)
+	r.Equal("user:@acct.region.snowflakecomputing.com:443?authenticator=oauth&ocspFailOpen=true&region=region&role=role&token=access-token-1&validateDefaultParameters=true", dsn)
+
+	dsn, err = dsnFunc()
+	r.NoError(err)
+	r.Contains(dsn, "token=access-token-2")
+	r.Empty(endpoint.errors())
+}
+
+func TestOAuthConnMaxLifetime(t *testing.T) {
+	r := require.New(t)
+
+	r.Equal(5*time.Minute, provider.OAuthConnMaxLifetime(&oauth2.Token{}))
+	r.Equal(5*time.Minute, provider.OAuthConnMaxLifetime(&oauth2.Token{Expiry: time.Now().Add(time.Hour)}))
+
+	lifetime := provider.OAuthConnMaxLifetime(&oauth2.Token{Expiry: time.Now().Add(time.Minute)})
+	r.True(lifetime > 0 && lifetime <= time.Minute, "lifetime %v", lifetime)
+}
+
+func TestOpenDBOAuthRefreshToken(t *testing.T) {
+	r := require.New(t)
+	endpoint := &fakeTokenEndpoint{expiresIn: 600}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	p := provider.Provider()
+	d := schema.TestResourceDataRaw(t, p.Schema, map[string]interface{}{
+		"account":             "acct",
+		"username":            "user",
+		"region":              "region",
+		"oauth_refresh_token": "refresh-token",
+		"oauth_client_id":     "client-id",
+		"oauth_client_secret": "client-secret",
+		"oauth_endpoint":      server.URL,
+	})
+	db, err := provider.OpenDB(d)
+	r.NoError(err)
+	r.NotNil(db)
+	r.Equal(1, endpoint.requests)
+	r.Empty(endpoint.errors())
+
+	d = schema.TestResourceDataRaw(t, p.Schema, map[string]interface{}{
+		"account":             "acct",
+		"username":            "user",
+		"region":              "region",
+		"oauth_refresh_token": "refresh-token",
+	})
+	_, err = provider.OpenDB(d)
+	r.EqualError(err, "oauth_client_id, oauth_client_secret and oauth_endpoint are required with oauth_refresh_token")
+}
@@ -0,0 +1,85 @@
+package provider_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
+	"github.com/stretchr/testify/require"
+	"github.com/youmark/pkcs8"
+)
+
+func generateTestKey(t *testing.T) *rsa.PrivateKey {
+	r := require.New(t)
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	r.NoError(err)
+	return key
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key := generateTestKey(t)
+	passphrase := []byte("correct horse battery staple")
+
+	pkcs1 := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	pkcs8Der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	unencryptedPKCS8 := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Der})
+
+	encryptedPKCS8Der, err := pkcs8.ConvertPrivateKeyToPKCS8(key, passphrase)
+	require.NoError(t, err)
+	encryptedPKCS8 := pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: encryptedPKCS8Der})
+
+	encryptedPKCS1Block, err := x509.EncryptPEMBlock(rand.Reader, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key), passphrase, x509.PEMCipherAES256) // nolint: staticcheck
+	require.NoError(t, err)
+	encryptedPKCS1 := pem.EncodeToMemory(encryptedPKCS1Block)
+
+	tests := []struct {
+		name       string
+		key        []byte
+		passphrase []byte
+		err        string
+	}{
+		{"pkcs1", pkcs1, nil, ""},
+		{"pkcs8", unencryptedPKCS8, nil, ""},
+		{"encrypted pkcs8", encryptedPKCS8, passphrase, ""},
+		{"encrypted pkcs1", encryptedPKCS1, passphrase, ""},
+		{"encrypted pkcs8 without passphrase", encryptedPKCS8, nil, "Private key is an encrypted PKCS#8 key but no passphrase was provided"},
+		{"encrypted pkcs1 without passphrase", encryptedPKCS1, nil, "Private key is an encrypted PKCS#1 key but no passphrase was provided"},
+		{"encrypted pkcs1 wrong passphrase", encryptedPKCS1, []byte("wrong"), "Could not decrypt encrypted PKCS#1 private key"},
+		{"not pem", []byte("not a key"), nil, "Could not find a PEM block in the private key"},
+		{"empty", []byte{}, nil, "Private key is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			got, err := provider.ParsePrivateKey(tt.key, tt.passphrase)
+			if tt.err != "" {
+				r.Error(err)
+				r.Contains(err.Error(), tt.err)
+				return
+			}
+			r.NoError(err)
+			r.Equal(key.N, got.N)
+		})
+	}
+}
+
+func TestPrivateKeyDSN(t *testing.T) {
+	r := require.New(t)
+	key := generateTestKey(t)
+	pkcs1 := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	dsn, err := provider.DSN(provider.DSNConfig{
+		Account:    "acct",
+		User:       "user",
+		PrivateKey: string(pkcs1),
+		Region:     "region",
+		Role:       "role",
+	})
+	r.NoError(err)
+	r.Contains(dsn, "authenticator=snowflake_jwt")
+}
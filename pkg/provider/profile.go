@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+)
+
+// DefaultConfigPath is where SnowSQL keeps its config file
+const DefaultConfigPath = "~/.snowsql/config"
+
+// Profile is a named connection from the SnowSQL config file
+type Profile struct {
+	Account        string
+	User           string
+	Password       string
+	Authenticator  string
+	PrivateKeyPath string
+	Role           string
+	Warehouse      string
+	Region         string
+}
+
+// profileKeys maps the keys of a SnowSQL connection section to the profile
+// field they set. SnowSQL's own names are accepted along with the shorter ones.
+var profileKeys = map[string]func(p *Profile) *string{
+	"accountname":      func(p *Profile) *string { return &p.Account },
+	"username":         func(p *Profile) *string { return &p.User },
+	"password":         func(p *Profile) *string { return &p.Password },
+	"authenticator":    func(p *Profile) *string { return &p.Authenticator },
+	"private_key_path": func(p *Profile) *string { return &p.PrivateKeyPath },
+	"rolename":         func(p *Profile) *string { return &p.Role },
+	"role":             func(p *Profile) *string { return &p.Role },
+	"warehousename":    func(p *Profile) *string { return &p.Warehouse },
+	"warehouse":        func(p *Profile) *string { return &p.Warehouse },
+	"region":           func(p *Profile) *string { return &p.Region },
+}
+
+// ReadProfile reads the connection called name from the SnowSQL config file at
+// path. The "default" profile is the unnamed [connections] section, any other
+// profile is read from [connections.<name>].
+func ReadProfile(path, name string) (*Profile, error) {
+	expandedPath, err := homedir.Expand(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid config path %v", path)
+	}
+
+	f, err := os.Open(expandedPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read config file %v", path)
+	}
+	defer f.Close()
+
+	sections, err := ParseSnowSQLConfig(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse config file %v", path)
+	}
+
+	section := "connections." + name
+	if name == "default" {
+		section = "connections"
+	}
+	values, ok := sections[section]
+	if !ok {
+		return nil, errors.Errorf("profile %v not found in config file %v", name, path)
+	}
+
+	p := &Profile{}
+	for k, v := range values {
+		if field, ok := profileKeys[k]; ok {
+			*field(p) = v
+		}
+	}
+	return p, nil
+}
+
+// ParseSnowSQLConfig parses the INI format of the SnowSQL config file into its
+// sections. Keys are lower cased and values are unquoted.
+func ParseSnowSQLConfig(r io.Reader) (map[string]map[string]string, error) {
+	sections := map[string]map[string]string{}
+	var current map[string]string
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, errors.Errorf("line %d: unterminated section header", lineNumber)
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if _, ok := sections[name]; !ok {
+				sections[name] = map[string]string{}
+			}
+			current = sections[name]
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("line %d: expected key = value", lineNumber)
+		}
+		if current == nil {
+			return nil, errors.Errorf("line %d: key outside of a section", lineNumber)
+		}
+		current[strings.ToLower(strings.TrimSpace(parts[0]))] = unquote(strings.TrimSpace(parts[1]))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
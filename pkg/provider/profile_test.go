@@ -0,0 +1,141 @@
+package provider_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+const testSnowSQLConfig = `
+[connections]
+accountname = default_account
+username = default_user
+password = "default password"
+
+# a named connection
+[connections.dev]
+accountname = dev_account
+username = dev_user
+authenticator = SNOWFLAKE_JWT
+private_key_path = ~/.ssh/snowflake_key
+rolename = DEV_ROLE
+warehousename = 'DEV_WH'
+region = us-east-1
+
+[connections.sso]
+accountname = sso_account
+username = sso_user
+authenticator = externalbrowser
+
+[connections.okta]
+accountname = okta_account
+username = okta_user
+authenticator = https://example.okta.com
+
+[options]
+log_level = DEBUG
+`
+
+func writeTestConfig(t *testing.T) (string, func()) {
+	r := require.New(t)
+	dir, err := ioutil.TempDir("", "snowsql")
+	r.NoError(err)
+
+	path := filepath.Join(dir, "config")
+	r.NoError(ioutil.WriteFile(path, []byte(testSnowSQLConfig), 0600))
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func TestReadProfile(t *testing.T) {
+	path, cleanup := writeTestConfig(t)
+	defer cleanup()
+
+	tests := []struct {
+		name    string
+		profile string
+		want    *provider.Profile
+		err     string
+	}{
+		{"default", "default", &provider.Profile{Account: "default_account", User: "default_user", Password: "default password"}, ""},
+		{"named", "dev", &provider.Profile{
+			Account:        "dev_account",
+			User:           "dev_user",
+			Authenticator:  "SNOWFLAKE_JWT",
+			PrivateKeyPath: "~/.ssh/snowflake_key",
+			Role:           "DEV_ROLE",
+			Warehouse:      "DEV_WH",
+			Region:         "us-east-1",
+		}, ""},
+		{"missing", "prod", nil, "profile prod not found in config file " + path},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			got, err := provider.ReadProfile(path, tt.profile)
+			if tt.err != "" {
+				r.EqualError(err, tt.err)
+				return
+			}
+			r.NoError(err)
+			r.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestParseSnowSQLConfigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{"unterminated section", "[connections\n", "line 1: unterminated section header"},
+		{"no value", "[connections]\naccountname\n", "line 2: expected key = value"},
+		{"no section", "accountname = a\n", "line 1: key outside of a section"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			_, err := provider.ParseSnowSQLConfig(strings.NewReader(tt.config))
+			r.EqualError(err, tt.err)
+		})
+	}
+}
+
+func TestOpenDBProfile(t *testing.T) {
+	path, cleanup := writeTestConfig(t)
+	defer cleanup()
+
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+		err    string
+	}{
+		{"default profile", map[string]interface{}{"profile": "default"}, ""},
+		{"browser auth profile", map[string]interface{}{"profile": "sso"}, ""},
+		{"unsupported authenticator", map[string]interface{}{"profile": "okta"}, "authenticator https://example.okta.com of profile okta is not supported"},
+		// the profile's authenticator is not used when the provider configures its own
+		{"provider authentication wins", map[string]interface{}{"profile": "okta", "password": "pass"}, ""},
+		{"missing profile", map[string]interface{}{"profile": "prod"}, "profile prod not found in config file " + path},
+		{"no profile", map[string]interface{}{"password": "pass"}, "account must be set in the provider, SNOWFLAKE_ACCOUNT or a profile"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			tt.config["config_path"] = path
+			d := schema.TestResourceDataRaw(t, provider.Provider().Schema, tt.config)
+			db, err := provider.OpenDB(d)
+			if tt.err != "" {
+				r.EqualError(err, tt.err)
+				return
+			}
+			r.NoError(err)
+			r.NotNil(db)
+		})
+	}
+}
@@ -1,31 +1,42 @@
 package provider
 
 import (
+	"context"
 	"crypto/rsa"
+	"crypto/x509"
+	"database/sql"
+	"encoding/pem"
 	"io/ioutil"
+	"log"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/datasources"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/db"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/pkg/errors"
 	"github.com/snowflakedb/gosnowflake"
+	"github.com/youmark/pkcs8"
 	"golang.org/x/crypto/ssh"
 )
 
 // Provider is a provider
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"account": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("SNOWFLAKE_ACCOUNT", nil),
 			},
 			"username": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("SNOWFLAKE_USER", nil),
 			},
 			"password": {
@@ -33,28 +44,72 @@ func Provider() *schema.Provider {
 				Optional:      true,
 				DefaultFunc:   schema.EnvDefaultFunc("SNOWFLAKE_PASSWORD", nil),
 				Sensitive:     true,
-				ConflictsWith: []string{"browser_auth", "private_key_path", "oauth_access_token"},
+				ConflictsWith: []string{"browser_auth", "private_key_path", "private_key", "private_key_passphrase", "oauth_access_token", "oauth_refresh_token"},
 			},
 			"oauth_access_token": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				DefaultFunc:   schema.EnvDefaultFunc("SNOWFLAKE_OAUTH_ACCESS_TOKEN", nil),
 				Sensitive:     true,
-				ConflictsWith: []string{"browser_auth", "private_key_path", "password"},
+				ConflictsWith: []string{"browser_auth", "private_key_path", "private_key", "private_key_passphrase", "password", "oauth_refresh_token"},
 			},
 			"browser_auth": {
 				Type:          schema.TypeBool,
 				Optional:      true,
 				DefaultFunc:   schema.EnvDefaultFunc("SNOWFLAKE_USE_BROWSER_AUTH", nil),
 				Sensitive:     false,
-				ConflictsWith: []string{"password", "private_key_path", "oauth_access_token"},
+				ConflictsWith: []string{"password", "private_key_path", "private_key", "private_key_passphrase", "oauth_access_token", "oauth_refresh_token"},
 			},
 			"private_key_path": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				DefaultFunc:   schema.EnvDefaultFunc("SNOWFLAKE_PRIVATE_KEY_PATH", nil),
 				Sensitive:     true,
-				ConflictsWith: []string{"browser_auth", "password", "oauth_access_token"},
+				ConflictsWith: []string{"browser_auth", "password", "oauth_access_token", "private_key", "oauth_refresh_token"},
+			},
+			"private_key": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("SNOWFLAKE_PRIVATE_KEY", nil),
+				Sensitive:     true,
+				Description:   "Private Key in PEM format for username+private-key auth, as an alternative to private_key_path.",
+				ConflictsWith: []string{"browser_auth", "password", "oauth_access_token", "private_key_path", "oauth_refresh_token"},
+			},
+			"private_key_passphrase": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("SNOWFLAKE_PRIVATE_KEY_PASSPHRASE", nil),
+				Sensitive:     true,
+				Description:   "Passphrase used to decrypt an encrypted private key (PKCS#8 or PKCS#1).",
+				ConflictsWith: []string{"browser_auth", "password", "oauth_access_token", "oauth_refresh_token"},
+			},
+			"oauth_refresh_token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("SNOWFLAKE_OAUTH_REFRESH_TOKEN", nil),
+				Sensitive:     true,
+				Description:   "Refresh token that is exchanged for OAuth access tokens, which are refreshed before they expire.",
+				ConflictsWith: []string{"browser_auth", "private_key_path", "private_key", "private_key_passphrase", "password", "oauth_access_token"},
+			},
+			"oauth_client_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SNOWFLAKE_OAUTH_CLIENT_ID", nil),
+				Sensitive:   true,
+				Description: "Client ID of the OAuth integration, required with oauth_refresh_token.",
+			},
+			"oauth_client_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SNOWFLAKE_OAUTH_CLIENT_SECRET", nil),
+				Sensitive:   true,
+				Description: "Client secret of the OAuth integration, required with oauth_refresh_token.",
+			},
+			"oauth_endpoint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SNOWFLAKE_OAUTH_ENDPOINT", nil),
+				Description: "Token endpoint of the OAuth authorization server, required with oauth_refresh_token.",
 			},
 			"role": {
 				Type:        schema.TypeString,
@@ -63,22 +118,115 @@ func Provider() *schema.Provider {
 			},
 			"region": {
 				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("SNOWFLAKE_REGION", "us-west-2"),
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SNOWFLAKE_REGION", nil),
+			},
+			"warehouse": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SNOWFLAKE_WAREHOUSE", nil),
+				Description: "Warehouse of the session, needed by some DDL.",
+			},
+			"query_tag": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SNOWFLAKE_QUERY_TAG", nil),
+				Description: "QUERY_TAG of the session, to attribute the provider's queries in QUERY_HISTORY.",
+			},
+			"login_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SNOWFLAKE_LOGIN_TIMEOUT", nil),
+				Description:  "Login retry timeout in seconds, excluding network roundtrips.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SNOWFLAKE_REQUEST_TIMEOUT", nil),
+				Description:  "Request retry timeout in seconds, excluding network roundtrips.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SNOWFLAKE_HOST", nil),
+				Description: "Host to connect to instead of the one derived from account and region, e.g. for private link.",
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SNOWFLAKE_PORT", nil),
+				Description:  "Port to connect to.",
+				ValidateFunc: validation.IntBetween(0, 65535),
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SNOWFLAKE_PROTOCOL", nil),
+				Description:  "Protocol to connect with, either https or http.",
+				ValidateFunc: validation.StringInSlice([]string{"https", "http"}, true),
+			},
+			"session_params": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Session parameters, e.g. TIMEZONE or STATEMENT_TIMEOUT_IN_SECONDS, that are passed to the driver.",
+			},
+			"retry_max_attempts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SNOWFLAKE_RETRY_MAX_ATTEMPTS", snowflake.DefaultRetryPolicy.MaxAttempts),
+				Description:  "Number of attempts of a statement that fails with a transient error, such as a refused connection or an expired token. Network resets are only retried for read-only statements. Set to 1 to disable retries.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SNOWFLAKE_PROFILE", nil),
+				Description: "Name of a connection in the SnowSQL config file to read settings from. Use \"default\" for the unnamed [connections] section.",
+			},
+			"config_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SNOWFLAKE_CONFIG_PATH", DefaultConfigPath),
+				Description: "Path to the SnowSQL config file that profile is read from.",
+			},
+			"identifier_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SNOWFLAKE_IDENTIFIER_POLICY", string(snowflake.IdentifierPolicyQuoted)),
+				Description:  "How names map to Snowflake objects: \"quoted\" preserves their case by always quoting them, \"uppercase\" folds names that are valid unquoted identifiers to uppercase, as Snowflake does for unquoted identifiers.",
+				ValidateFunc: validation.StringInSlice(snowflake.IdentifierPolicies, true),
+			},
+			"require_sysadmin_rollup": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SNOWFLAKE_REQUIRE_SYSADMIN_ROLLUP", true),
+				Description: "Fail plans that would leave a role granted by snowflake_role_grants or snowflake_role_membership not rolling up to SYSADMIN. When false, this is only logged as a warning.",
+			},
+			"audit_log_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SNOWFLAKE_AUDIT_LOG_PATH", nil),
+				Description: "Path of a file to append a JSON line to for every statement the provider runs, with the resource, time, query ID and outcome. Secrets such as passwords and credentials are redacted. Once a line cannot be written, further statements fail.",
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"snowflake_account_grant":          resources.AccountGrant(),
+			"snowflake_account_parameter":      resources.AccountParameter(),
 			"snowflake_database":               resources.Database(),
 			"snowflake_database_grant":         resources.DatabaseGrant(),
 			"snowflake_file_format":            resources.FileFormat(),
 			"snowflake_integration_grant":      resources.IntegrationGrant(),
 			"snowflake_managed_account":        resources.ManagedAccount(),
+			"snowflake_ownership":              resources.Ownership(),
 			"snowflake_pipe":                   resources.Pipe(),
 			"snowflake_resource_monitor":       resources.ResourceMonitor(),
 			"snowflake_resource_monitor_grant": resources.ResourceMonitorGrant(),
 			"snowflake_role":                   resources.Role(),
 			"snowflake_role_grants":            resources.RoleGrants(),
+			"snowflake_role_membership":        resources.RoleMembership(),
 			"snowflake_schema":                 resources.Schema(),
 			"snowflake_schema_grant":           resources.SchemaGrant(),
 			"snowflake_share":                  resources.Share(),
@@ -96,21 +244,175 @@ func Provider() *schema.Provider {
 		DataSourcesMap: map[string]*schema.Resource{
 			"snowflake_system_get_aws_sns_iam_policy": datasources.SystemGetAWSSNSIAMPolicy(),
 		},
-		ConfigureFunc: ConfigureProvider,
 	}
+
+	// Diff suppression and set hashing apply the identifier policy but are
+	// not passed the client, so they read it here once it is configured.
+	var policy atomic.Value
+	policy.Store(snowflake.IdentifierPolicyQuoted)
+	currentPolicy := func() snowflake.IdentifierPolicy {
+		return policy.Load().(snowflake.IdentifierPolicy)
+	}
+	for name, r := range p.ResourcesMap {
+		withResourceName(name, r)
+		resources.BindIdentifierPolicy(r, currentPolicy)
+	}
+	for name, r := range p.DataSourcesMap {
+		withResourceName(name, r)
+	}
+
+	p.ConfigureFunc = func(s *schema.ResourceData) (interface{}, error) {
+		meta, err := ConfigureProvider(s)
+		if err != nil {
+			return nil, err
+		}
+
+		// Cancel running statements when Terraform stops the provider, then
+		// flush and close the audit log along with the connections.
+		client := meta.(*snowflake.Client)
+		client.StopContext = p.StopContext()
+		policy.Store(client.IdentifierPolicy)
+		go func() {
+			<-p.StopContext().Done()
+			if err := client.Close(); err != nil {
+				log.Printf("[ERROR] could not close the client: %v", err)
+			}
+		}()
+		return client, nil
+	}
+	return p
 }
 
+// ConfigureProvider returns the client the resources are passed as meta.
+// Reading its session also fails the configuration early on bad credentials.
 func ConfigureProvider(s *schema.ResourceData) (interface{}, error) {
-	account := s.Get("account").(string)
-	user := s.Get("username").(string)
-	password := s.Get("password").(string)
-	browserAuth := s.Get("browser_auth").(bool)
-	privateKeyPath := s.Get("private_key_path").(string)
-	oauthAccessToken := s.Get("oauth_access_token").(string)
-	region := s.Get("region").(string)
-	role := s.Get("role").(string)
+	policy, err := snowflake.ParseIdentifierPolicy(s.Get("identifier_policy").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := OpenDB(s)
+	if err != nil {
+		return nil, err
+	}
+
+	client := snowflake.NewClient(db)
+	client.RetryPolicy.MaxAttempts = s.Get("retry_max_attempts").(int)
+	client.IdentifierPolicy = policy
+	client.RequireSysadminRollup = s.Get("require_sysadmin_rollup").(bool)
+
+	if path := s.Get("audit_log_path").(string); path != "" {
+		expandedPath, err := homedir.Expand(path)
+		if err != nil {
+			db.Close()
+			return nil, errors.Wrap(err, "Invalid path to audit log")
+		}
+		client.Audit, err = snowflake.OpenAuditLog(expandedPath)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+
+	err = client.LoadSession(context.Background())
+	if err != nil {
+		db.Close()
+		return nil, errors.Wrap(err, "Could not connect to snowflake.")
+	}
+	log.Printf("[DEBUG] connected to account %v in %v as role %v", client.Session.Account, client.Session.Region, client.Session.Role)
+	return client, nil
+}
+
+// OpenDB opens the database configured by s. Like sql.Open, it does not
+// connect to Snowflake yet.
+func OpenDB(s *schema.ResourceData) (*sql.DB, error) {
+	cfg := DSNConfig{
+		Account:              s.Get("account").(string),
+		User:                 s.Get("username").(string),
+		Password:             s.Get("password").(string),
+		BrowserAuth:          s.Get("browser_auth").(bool),
+		PrivateKeyPath:       s.Get("private_key_path").(string),
+		PrivateKey:           s.Get("private_key").(string),
+		PrivateKeyPassphrase: s.Get("private_key_passphrase").(string),
+		OAuthAccessToken:     s.Get("oauth_access_token").(string),
+		Region:               s.Get("region").(string),
+		Role:                 s.Get("role").(string),
+		Session: SessionOptions{
+			Warehouse:      s.Get("warehouse").(string),
+			QueryTag:       s.Get("query_tag").(string),
+			LoginTimeout:   time.Duration(s.Get("login_timeout").(int)) * time.Second,
+			RequestTimeout: time.Duration(s.Get("request_timeout").(int)) * time.Second,
+			Host:           s.Get("host").(string),
+			Port:           s.Get("port").(int),
+			Protocol:       strings.ToLower(s.Get("protocol").(string)),
+			Params:         map[string]string{},
+		},
+	}
+	for k, v := range s.Get("session_params").(map[string]interface{}) {
+		cfg.Session.Params[k] = v.(string)
+	}
+	oauthRefreshToken := s.Get("oauth_refresh_token").(string)
+	oauthClientID := s.Get("oauth_client_id").(string)
+	oauthClientSecret := s.Get("oauth_client_secret").(string)
+	oauthEndpoint := s.Get("oauth_endpoint").(string)
+
+	// Settings from a profile have the lowest precedence: provider arguments
+	// and SNOWFLAKE_* environment variables override them field by field.
+	if profileName := s.Get("profile").(string); profileName != "" {
+		profile, err := ReadProfile(s.Get("config_path").(string), profileName)
+		if err != nil {
+			return nil, err
+		}
 
-	dsn, err := DSN(account, user, password, browserAuth, privateKeyPath, oauthAccessToken, region, role)
+		cfg.Account = firstNonEmpty(cfg.Account, profile.Account)
+		cfg.User = firstNonEmpty(cfg.User, profile.User)
+		cfg.Region = firstNonEmpty(cfg.Region, profile.Region)
+		cfg.Role = firstNonEmpty(cfg.Role, profile.Role)
+		cfg.Session.Warehouse = firstNonEmpty(cfg.Session.Warehouse, profile.Warehouse)
+
+		// The profile's authentication is only used if none is configured on
+		// the provider, so that methods from both are never mixed.
+		if cfg.Password == "" && !cfg.BrowserAuth && cfg.PrivateKeyPath == "" && cfg.PrivateKey == "" && cfg.OAuthAccessToken == "" && oauthRefreshToken == "" {
+			switch strings.ToLower(profile.Authenticator) {
+			case "", "snowflake", "snowflake_jwt":
+				cfg.Password = profile.Password
+				cfg.PrivateKeyPath = profile.PrivateKeyPath
+			case "externalbrowser":
+				cfg.BrowserAuth = true
+			default:
+				return nil, errors.Errorf("authenticator %v of profile %v is not supported", profile.Authenticator, profileName)
+			}
+		}
+	}
+
+	if cfg.Account == "" {
+		return nil, errors.New("account must be set in the provider, SNOWFLAKE_ACCOUNT or a profile")
+	}
+	if cfg.User == "" {
+		return nil, errors.New("username must be set in the provider, SNOWFLAKE_USER or a profile")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-west-2"
+	}
+
+	if oauthRefreshToken != "" {
+		if oauthClientID == "" || oauthClientSecret == "" || oauthEndpoint == "" {
+			return nil, errors.New("oauth_client_id, oauth_client_secret and oauth_endpoint are required with oauth_refresh_token")
+		}
+
+		ts := OAuthTokenSource(oauthClientID, oauthClientSecret, oauthRefreshToken, oauthEndpoint)
+		// Fail early if the refresh token cannot be exchanged.
+		token, err := ts.Token()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not refresh oauth access token")
+		}
+
+		oauthDB := db.OpenWithDSNFunc(OAuthDSNFunc(ts, cfg))
+		oauthDB.SetConnMaxLifetime(OAuthConnMaxLifetime(token))
+		return oauthDB, nil
+	}
+
+	dsn, err := DSN(cfg)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "could not build dsn for snowflake connection")
@@ -124,45 +426,144 @@ func ConfigureProvider(s *schema.ResourceData) (interface{}, error) {
 	return db, nil
 }
 
-func DSN(
-	account,
-	user,
-	password string,
-	browserAuth bool,
-	privateKeyPath,
-	oauthAccessToken,
-	region,
-	role string) (string, error) {
+// withResourceName makes the functions of r pass on a client that knows the
+// type name of r, so that the audit log can attribute statements to it.
+func withResourceName(name string, r *schema.Resource) {
+	wrap := func(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+		if f == nil {
+			return nil
+		}
+		return func(data *schema.ResourceData, meta interface{}) error {
+			return f(data, forResource(name, meta))
+		}
+	}
+	r.Create = wrap(r.Create)
+	r.Read = wrap(r.Read)
+	r.Update = wrap(r.Update)
+	r.Delete = wrap(r.Delete)
+
+	if exists := r.Exists; exists != nil {
+		r.Exists = func(data *schema.ResourceData, meta interface{}) (bool, error) {
+			return exists(data, forResource(name, meta))
+		}
+	}
+}
+
+func forResource(name string, meta interface{}) interface{} {
+	client, ok := meta.(*snowflake.Client)
+	if !ok {
+		return meta
+	}
+	return client.ForResource(name)
+}
+
+// SessionOptions are the settings of the connection that do not depend on
+// how the provider authenticates.
+type SessionOptions struct {
+	Warehouse      string
+	QueryTag       string
+	LoginTimeout   time.Duration
+	RequestTimeout time.Duration
+	Host           string
+	Port           int
+	Protocol       string
+	// Params are session parameters, such as TIMEZONE, passed to the driver
+	Params map[string]string
+}
+
+func (o SessionOptions) apply(config *gosnowflake.Config) {
+	config.Warehouse = o.Warehouse
+	config.LoginTimeout = o.LoginTimeout
+	config.RequestTimeout = o.RequestTimeout
+	config.Host = o.Host
+	if o.Host != "" {
+		// The region only serves to derive the host, and the driver would
+		// otherwise splice it into a host that does not contain it.
+		config.Region = ""
+	}
+	config.Port = o.Port
+	config.Protocol = o.Protocol
+
+	params := map[string]*string{}
+	for k, v := range o.Params {
+		v := v
+		params[strings.ToLower(k)] = &v
+	}
+	if o.QueryTag != "" {
+		params["query_tag"] = &o.QueryTag
+	}
+	if len(params) > 0 {
+		config.Params = params
+	}
+}
 
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// DSNConfig is what a DSN is built from: whom to connect as, how to
+// authenticate and the settings of the session. Of the authentication
+// methods, the first one set of a private key, BrowserAuth, OAuthAccessToken
+// and Password is used.
+type DSNConfig struct {
+	Account              string
+	User                 string
+	Password             string
+	BrowserAuth          bool
+	PrivateKeyPath       string
+	PrivateKey           string
+	PrivateKeyPassphrase string
+	OAuthAccessToken     string
+	Region               string
+	Role                 string
+	Session              SessionOptions
+}
+
+// DSN returns the gosnowflake data source name for c
+func DSN(c DSNConfig) (string, error) {
 	// us-west-2 is their default region, but if you actually specify that it won't trigger their default code
 	//  https://github.com/snowflakedb/gosnowflake/blob/52137ce8c32eaf93b0bd22fc5c7297beff339812/dsn.go#L61
-	if region == "us-west-2" {
-		region = ""
+	if c.Region == "us-west-2" {
+		c.Region = ""
 	}
 
 	config := gosnowflake.Config{
-		Account: account,
-		User:    user,
-		Region:  region,
-		Role:    role,
+		Account: c.Account,
+		User:    c.User,
+		Region:  c.Region,
+		Role:    c.Role,
 	}
+	c.Session.apply(&config)
 
-	if privateKeyPath != "" {
+	if c.PrivateKeyPath != "" || c.PrivateKey != "" {
+		privateKeyBytes := []byte(c.PrivateKey)
+		if c.PrivateKeyPath != "" {
+			var err error
+			privateKeyBytes, err = ReadPrivateKeyFile(c.PrivateKeyPath)
+			if err != nil {
+				return "", errors.Wrap(err, "Private Key file could not be read")
+			}
+		}
 
-		rsaPrivateKey, err := ParsePrivateKey(privateKeyPath)
+		rsaPrivateKey, err := ParsePrivateKey(privateKeyBytes, []byte(c.PrivateKeyPassphrase))
 		if err != nil {
 			return "", errors.Wrap(err, "Private Key could not be parsed")
 		}
 		config.PrivateKey = rsaPrivateKey
 		config.Authenticator = gosnowflake.AuthTypeJwt
 
-	} else if browserAuth {
+	} else if c.BrowserAuth {
 		config.Authenticator = gosnowflake.AuthTypeExternalBrowser
-	} else if oauthAccessToken != "" {
+	} else if c.OAuthAccessToken != "" {
 		config.Authenticator = gosnowflake.AuthTypeOAuth
-		config.Token = oauthAccessToken
-	} else if password != "" {
-		config.Password = password
+		config.Token = c.OAuthAccessToken
+	} else if c.Password != "" {
+		config.Password = c.Password
 	} else {
 		return "", errors.New("no authentication method provided")
 	}
@@ -170,7 +571,7 @@ func DSN(
 	return gosnowflake.DSN(&config)
 }
 
-func ParsePrivateKey(privateKeyPath string) (*rsa.PrivateKey, error) {
+func ReadPrivateKeyFile(privateKeyPath string) ([]byte, error) {
 	expandedPrivateKeyPath, err := homedir.Expand(privateKeyPath)
 	if err != nil {
 		return nil, errors.Wrap(err, "Invalid Path to private key")
@@ -183,15 +584,67 @@ func ParsePrivateKey(privateKeyPath string) (*rsa.PrivateKey, error) {
 	if len(privateKeyBytes) == 0 {
 		return nil, errors.New("Private key is empty")
 	}
+	return privateKeyBytes, nil
+}
 
-	privateKey, err := ssh.ParseRawPrivateKey(privateKeyBytes)
-	if err != nil {
-		return nil, errors.Wrap(err, "Could not parse private key")
+// ParsePrivateKey parses a PEM encoded RSA private key. Encrypted keys, either
+// PKCS#8 (ENCRYPTED PRIVATE KEY) or legacy PKCS#1 (RSA PRIVATE KEY with a
+// Proc-Type header), are decrypted with passphrase.
+func ParsePrivateKey(privateKeyBytes []byte, passphrase []byte) (*rsa.PrivateKey, error) {
+	if len(privateKeyBytes) == 0 {
+		return nil, errors.New("Private key is empty")
+	}
+
+	block, _ := pem.Decode(privateKeyBytes)
+	if block == nil {
+		return nil, errors.New("Could not find a PEM block in the private key")
+	}
+
+	var privateKey interface{}
+	var err error
+	switch {
+	case block.Type == "ENCRYPTED PRIVATE KEY":
+		if len(passphrase) == 0 {
+			return nil, errors.New("Private key is an encrypted PKCS#8 key but no passphrase was provided")
+		}
+		privateKey, err = pkcs8.ParsePKCS8PrivateKey(block.Bytes, passphrase)
+		if err != nil {
+			return nil, errors.Wrap(err, "Could not parse encrypted PKCS#8 private key")
+		}
+	case block.Type == "PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "Could not parse PKCS#8 private key")
+		}
+	case block.Type == "RSA PRIVATE KEY" && x509.IsEncryptedPEMBlock(block): // nolint: staticcheck
+		if len(passphrase) == 0 {
+			return nil, errors.New("Private key is an encrypted PKCS#1 key but no passphrase was provided")
+		}
+		der, err := x509.DecryptPEMBlock(block, passphrase) // nolint: staticcheck
+		if err != nil {
+			return nil, errors.Wrap(err, "Could not decrypt encrypted PKCS#1 private key")
+		}
+		// The padding check of DecryptPEMBlock does not catch every wrong
+		// passphrase, which then decrypts to garbage.
+		privateKey, err = x509.ParsePKCS1PrivateKey(der)
+		if err != nil {
+			return nil, errors.Wrap(err, "Could not decrypt encrypted PKCS#1 private key, the passphrase may be wrong")
+		}
+	case block.Type == "RSA PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "Could not parse PKCS#1 private key")
+		}
+	default:
+		privateKey, err = ssh.ParseRawPrivateKey(privateKeyBytes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Could not parse private key of type %v", block.Type)
+		}
 	}
 
 	rsaPrivateKey, ok := privateKey.(*rsa.PrivateKey)
 	if !ok {
-		return nil, errors.New("privateKey not of type RSA")
+		return nil, errors.Errorf("privateKey of type %v is not an RSA key", block.Type)
 	}
 	return rsaPrivateKey, nil
 }
@@ -2,6 +2,7 @@ package provider_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -41,7 +42,14 @@ func TestDSN(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := provider.DSN(tt.args.account, tt.args.user, tt.args.password, tt.args.browserAuth, "", "", tt.args.region, tt.args.role)
+			got, err := provider.DSN(provider.DSNConfig{
+				Account:     tt.args.account,
+				User:        tt.args.user,
+				Password:    tt.args.password,
+				BrowserAuth: tt.args.browserAuth,
+				Region:      tt.args.region,
+				Role:        tt.args.role,
+			})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("DSN() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -93,7 +101,13 @@ func TestOAuthDSN(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := provider.DSN(tt.args.account, tt.args.user, "", false, "", tt.args.oauthAccessToken, tt.args.region, tt.args.role)
+			got, err := provider.DSN(provider.DSNConfig{
+				Account:          tt.args.account,
+				User:             tt.args.user,
+				OAuthAccessToken: tt.args.oauthAccessToken,
+				Region:           tt.args.region,
+				Role:             tt.args.role,
+			})
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("DSN() error = %v, dsn = %v, wantErr %v", err, got, tt.wantErr)
@@ -105,3 +119,35 @@ func TestOAuthDSN(t *testing.T) {
 		})
 	}
 }
+
+func TestDSNSessionOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts provider.SessionOptions
+		want string
+	}{
+		{"warehouse and query tag", provider.SessionOptions{Warehouse: "wh", QueryTag: "terraform"},
+			"user:pass@acct.region.snowflakecomputing.com:443?ocspFailOpen=true&query_tag=terraform&region=region&role=role&validateDefaultParameters=true&warehouse=wh"},
+		{"timeouts", provider.SessionOptions{LoginTimeout: 30 * time.Second, RequestTimeout: 60 * time.Second},
+			"user:pass@acct.region.snowflakecomputing.com:443?loginTimeout=30&ocspFailOpen=true&region=region&requestTimeout=60&role=role&validateDefaultParameters=true"},
+		{"private link", provider.SessionOptions{Host: "acct.region.privatelink.snowflakecomputing.com", Port: 8443, Protocol: "https"},
+			"user:pass@acct.region.privatelink.snowflakecomputing.com:8443?account=acct&ocspFailOpen=true&role=role&validateDefaultParameters=true"},
+		{"session params", provider.SessionOptions{Params: map[string]string{"TIMEZONE": "UTC"}, QueryTag: "terraform"},
+			"user:pass@acct.region.snowflakecomputing.com:443?ocspFailOpen=true&query_tag=terraform&region=region&role=role&timezone=UTC&validateDefaultParameters=true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			got, err := provider.DSN(provider.DSNConfig{
+				Account:  "acct",
+				User:     "user",
+				Password: "pass",
+				Region:   "region",
+				Role:     "role",
+				Session:  tt.opts,
+			})
+			r.NoError(err)
+			r.Equal(tt.want, got)
+		})
+	}
+}
@@ -7,17 +7,7 @@ import (
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 )
 
-var validAccountPrivileges = newPrivilegeSet(
-	privilegeCreateRole,
-	privilegeCreateUser,
-	privilegeCreateWarehouse,
-	privilegeCreateDatabase,
-	privilegeCreateIntegration,
-	privilegeManageGrants,
-	privilegeMonitorUsage,
-	privilegeMonitorExecution,
-	privilegeExecuteTask,
-)
+var validAccountPrivileges = grantObjectAccount.privileges()
 
 var accountGrantSchema = map[string]*schema.Schema{
 	"privilege": {
@@ -34,6 +24,7 @@ var accountGrantSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Grants privilege to these roles.",
 		ForceNew:    true,
+		Set:         hashIdentifier,
 	},
 	"with_grant_option": {
 		Type:        schema.TypeBool,
@@ -51,7 +42,8 @@ func AccountGrant() *schema.Resource {
 		Read:   ReadAccountGrant,
 		Delete: DeleteAccountGrant,
 
-		Schema: accountGrantSchema,
+		Schema:        accountGrantSchema,
+		CustomizeDiff: customizeDiffGrant(grantObjectAccount, accountGrantSchema),
 	}
 }
 
@@ -1,7 +1,6 @@
 package resources_test
 
 import (
-	"database/sql"
 	"testing"
 	"time"
 
@@ -12,6 +11,7 @@ import (
 
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
 )
 
@@ -32,11 +32,11 @@ func TestAccountGrantCreate(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.AccountGrant().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`^GRANT CREATE DATABASE ON ACCOUNT TO ROLE "test-role-1" WITH GRANT OPTION$`).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectExec(`^GRANT CREATE DATABASE ON ACCOUNT TO ROLE "test-role-2" WITH GRANT OPTION$`).WillReturnResult(sqlmock.NewResult(1, 1))
 		expectReadAccountGrant(mock)
-		err := resources.CreateAccountGrant(d, db)
+		err := resources.CreateAccountGrant(d, client)
 		r.NoError(err)
 	})
 }
@@ -52,9 +52,9 @@ func TestAccountGrantRead(t *testing.T) {
 
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		expectReadAccountGrant(mock)
-		err := resources.ReadAccountGrant(d, db)
+		err := resources.ReadAccountGrant(d, client)
 		r.NoError(err)
 	})
 }
@@ -70,9 +70,9 @@ func TestMonitorExecution(t *testing.T) {
 
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		expectReadAccountGrant(mock)
-		err := resources.ReadAccountGrant(d, db)
+		err := resources.ReadAccountGrant(d, client)
 		r.NoError(err)
 	})
 }
@@ -88,9 +88,9 @@ func TestExecuteTask(t *testing.T) {
 
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		expectReadAccountGrant(mock)
-		err := resources.ReadAccountGrant(d, db)
+		err := resources.ReadAccountGrant(d, client)
 		r.NoError(err)
 	})
 }
@@ -0,0 +1,164 @@
+package resources
+
+import (
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pkg/errors"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+)
+
+var accountParameterSchema = map[string]*schema.Schema{
+	"key": {
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		Description:      "Name of the account parameter, e.g. TIMEZONE. Only the parameters in the provider's catalog can be set.",
+		ValidateFunc:     validation.StringInSlice(snowflake.ParameterKeys(snowflake.AccountType), true),
+		DiffSuppressFunc: diffCaseInsensitive,
+	},
+	"value": {
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "Value of the account parameter, as a string, e.g. \"true\" or \"3600\". It is checked against the type of the parameter.",
+		DiffSuppressFunc: diffParameterValue,
+	},
+}
+
+// AccountParameter returns a pointer to the resource representing a parameter
+// set on the account. Destroying it restores the parameter's default.
+func AccountParameter() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateAccountParameter,
+		Read:   ReadAccountParameter,
+		Update: UpdateAccountParameter,
+		Delete: DeleteAccountParameter,
+
+		CustomizeDiff: customizeDiffAccountParameter,
+
+		Schema: accountParameterSchema,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+// diffParameterValue suppresses the diff between two spellings of the same
+// value of the parameter named by key, e.g. true and TRUE
+func diffParameterValue(k, old, new string, d *schema.ResourceData) bool {
+	return quotedIdentifiers.diffParameterValue(k, old, new, d)
+}
+
+func (f identifierFuncs) diffParameterValue(k, old, new string, d *schema.ResourceData) bool {
+	pt, err := snowflake.LookupParameter(d.Get("key").(string), snowflake.AccountType)
+	if err != nil {
+		return false
+	}
+	return snowflake.EqualParameterValues(f.policy(), pt, old, new)
+}
+
+// customizeDiffAccountParameter checks the value against the type of the
+// parameter, so that invalid values fail at plan time.
+func customizeDiffAccountParameter(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("key") || !d.NewValueKnown("value") {
+		return nil
+	}
+	_, err := snowflake.AccountParameter(d.Get("key").(string)).Set(d.Get("value").(string))
+	return err
+}
+
+// CreateAccountParameter implements schema.CreateFunc
+func CreateAccountParameter(data *schema.ResourceData, meta interface{}) error {
+	key := strings.ToUpper(data.Get("key").(string))
+
+	err := setAccountParameter(data, meta, schema.TimeoutCreate)
+	if err != nil {
+		return err
+	}
+
+	data.SetId(key)
+	return ReadAccountParameter(data, meta)
+}
+
+func setAccountParameter(data *schema.ResourceData, meta interface{}, timeout string) error {
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, timeout)
+	defer cancel()
+
+	key := data.Get("key").(string)
+	value := data.Get("value").(string)
+	if pt, err := snowflake.LookupParameter(key, snowflake.AccountType); err == nil && pt == snowflake.ParameterTypeIdentifier {
+		value = identifierPolicy(meta).Normalize(value)
+	}
+	stmt, err := snowflake.AccountParameter(key).Set(value)
+	if err != nil {
+		return err
+	}
+
+	err = client.ExecContext(ctx, stmt)
+	if err != nil {
+		return errors.Wrapf(err, "error setting account parameter %v", key)
+	}
+	return nil
+}
+
+// ReadAccountParameter implements schema.ReadFunc. A parameter that is no
+// longer set on the account is removed from the state.
+func ReadAccountParameter(data *schema.ResourceData, meta interface{}) error {
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
+
+	key := strings.ToUpper(data.Id())
+
+	rows, err := client.QueryContext(ctx, snowflake.AccountParameter(key).Show())
+	if err != nil {
+		return err
+	}
+	params, err := snowflake.ScanParameters(rows)
+	if err != nil {
+		return err
+	}
+
+	value, ok := snowflake.ParametersSetOn(params, snowflake.AccountType)[key]
+	if !ok {
+		log.Printf("[WARN] account parameter %v is not set, removing from state file", key)
+		data.SetId("")
+		return nil
+	}
+
+	err = data.Set("key", key)
+	if err != nil {
+		return err
+	}
+	return data.Set("value", value)
+}
+
+// UpdateAccountParameter implements schema.UpdateFunc
+func UpdateAccountParameter(data *schema.ResourceData, meta interface{}) error {
+	err := setAccountParameter(data, meta, schema.TimeoutUpdate)
+	if err != nil {
+		return err
+	}
+	return ReadAccountParameter(data, meta)
+}
+
+// DeleteAccountParameter implements schema.DeleteFunc
+func DeleteAccountParameter(data *schema.ResourceData, meta interface{}) error {
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutDelete)
+	defer cancel()
+
+	key := data.Id()
+
+	err := client.ExecContext(ctx, snowflake.AccountParameter(key).Unset())
+	if err != nil {
+		return errors.Wrapf(err, "error unsetting account parameter %v", key)
+	}
+
+	data.SetId("")
+	return nil
+}
@@ -0,0 +1,108 @@
+package resources_test
+
+import (
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountParameter(t *testing.T) {
+	r := require.New(t)
+	err := resources.AccountParameter().InternalValidate(provider.Provider().Schema, true)
+	r.NoError(err)
+}
+
+func expectReadAccountParameter(mock sqlmock.Sqlmock, level string) {
+	rows := sqlmock.NewRows([]string{"key", "value", "default", "level", "description", "type"}).
+		AddRow("PERIODIC_DATA_REKEYING", "true", "false", level, "desc", "BOOLEAN")
+	mock.ExpectQuery(`^SHOW PARAMETERS LIKE 'PERIODIC\\\\_DATA\\\\_REKEYING' IN ACCOUNT$`).WillReturnRows(rows)
+}
+
+func TestAccountParameterCreate(t *testing.T) {
+	r := require.New(t)
+
+	d := accountParameter(t, "", map[string]interface{}{
+		"key":   "periodic_data_rekeying",
+		"value": "True",
+	})
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`^ALTER ACCOUNT SET PERIODIC_DATA_REKEYING = TRUE$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectReadAccountParameter(mock, "ACCOUNT")
+		err := resources.CreateAccountParameter(d, client)
+		r.NoError(err)
+		r.Equal("PERIODIC_DATA_REKEYING", d.Id())
+		r.Equal("true", d.Get("value").(string))
+	})
+}
+
+func TestAccountParameterCreateInvalidValue(t *testing.T) {
+	r := require.New(t)
+
+	d := accountParameter(t, "", map[string]interface{}{
+		"key":   "PERIODIC_DATA_REKEYING",
+		"value": "yes please",
+	})
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		err := resources.CreateAccountParameter(d, client)
+		r.EqualError(err, "invalid value for PERIODIC_DATA_REKEYING: expected true or false, got yes please")
+	})
+}
+
+func TestAccountParameterPlan(t *testing.T) {
+	r := require.New(t)
+
+	res := resources.AccountParameter()
+	state := &terraform.InstanceState{
+		ID:         "PERIODIC_DATA_REKEYING",
+		Attributes: map[string]string{"id": "PERIODIC_DATA_REKEYING", "key": "PERIODIC_DATA_REKEYING", "value": "true"},
+	}
+
+	diff, err := res.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{"key": "PERIODIC_DATA_REKEYING", "value": "TRUE"}), nil)
+	r.NoError(err)
+	r.Nil(diff)
+
+	_, err = res.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{"key": "PERIODIC_DATA_REKEYING", "value": "3600"}), nil)
+	r.Error(err)
+
+	_, err = res.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{"key": "NOT_A_PARAMETER", "value": "1"}), nil)
+	r.Error(err)
+}
+
+func TestAccountParameterReadUnset(t *testing.T) {
+	r := require.New(t)
+
+	d := accountParameter(t, "PERIODIC_DATA_REKEYING", map[string]interface{}{
+		"key":   "PERIODIC_DATA_REKEYING",
+		"value": "true",
+	})
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		expectReadAccountParameter(mock, "")
+		err := resources.ReadAccountParameter(d, client)
+		r.NoError(err)
+		r.Equal("", d.Id())
+	})
+}
+
+func TestAccountParameterDelete(t *testing.T) {
+	r := require.New(t)
+
+	d := accountParameter(t, "PERIODIC_DATA_REKEYING", map[string]interface{}{
+		"key":   "PERIODIC_DATA_REKEYING",
+		"value": "true",
+	})
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`^ALTER ACCOUNT UNSET PERIODIC_DATA_REKEYING$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		err := resources.DeleteAccountParameter(d, client)
+		r.NoError(err)
+	})
+}
@@ -0,0 +1,29 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// stopContext returns the context that is cancelled when Terraform asks the
+// provider the client of meta belongs to to stop
+func stopContext(meta interface{}) context.Context {
+	if client, ok := meta.(*snowflake.Client); ok && client != nil && client.StopContext != nil {
+		return client.StopContext
+	}
+	return context.Background()
+}
+
+// OperationContext returns the context of a CRUD operation. It is cancelled
+// when the provider is stopped or when the operation's timeout, as configured
+// in the resource's timeouts block, is exceeded. The statements run with it
+// are attributed to the resource in the audit log.
+func OperationContext(data *schema.ResourceData, meta interface{}, timeout string) (context.Context, context.CancelFunc) {
+	ctx := stopContext(meta)
+	if client, ok := meta.(*snowflake.Client); ok && client != nil {
+		ctx = snowflake.WithAuditResource(ctx, client.Resource, data.Id())
+	}
+	return context.WithTimeout(ctx, data.Timeout(timeout))
+}
@@ -0,0 +1,32 @@
+package resources
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationContext(t *testing.T) {
+	r := require.New(t)
+
+	stop, cancelStop := context.WithCancel(context.Background())
+	client := snowflake.NewClient(nil)
+	client.StopContext = stop
+
+	d := Database().TestResourceData()
+	ctx, cancel := OperationContext(d, client, schema.TimeoutCreate)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	r.True(ok)
+	r.WithinDuration(time.Now().Add(d.Timeout(schema.TimeoutCreate)), deadline, time.Minute)
+
+	r.NoError(ctx.Err())
+	cancelStop()
+	<-ctx.Done()
+	r.Equal(context.Canceled, ctx.Err())
+}
@@ -1,21 +1,21 @@
 package resources
 
 import (
-	"database/sql"
 	"fmt"
-	"log"
-	"strconv"
+	"time"
 
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/pkg/errors"
 )
 
 var databaseSchema = map[string]*schema.Schema{
 	"name": {
-		Type:     schema.TypeString,
-		Required: true,
-		ForceNew: false,
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         false,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"comment": {
 		Type:     schema.TypeString,
@@ -23,9 +23,23 @@ var databaseSchema = map[string]*schema.Schema{
 		Default:  "",
 	},
 	"data_retention_time_in_days": {
-		Type:     schema.TypeInt,
-		Optional: true,
-		Computed: true,
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      intUnset,
+		Description:  "Specifies the number of days for which Time Travel actions can be performed on the database. Inherited from the account if not set.",
+		ValidateFunc: validation.IntBetween(0, 90),
+	},
+	"max_data_extension_time_in_days": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      intUnset,
+		Description:  "Specifies the maximum number of days for which Snowflake can extend the data retention period of the tables in the database to prevent streams on them from becoming stale. Inherited from the account if not set.",
+		ValidateFunc: validation.IntBetween(0, 90),
+	},
+	"default_ddl_collation": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Specifies the default collation specification of the tables created in the database. Inherited from the account if not set.",
 	},
 	"from_share": {
 		Type:          schema.TypeMap,
@@ -35,15 +49,21 @@ var databaseSchema = map[string]*schema.Schema{
 		ConflictsWith: []string{"from_database"},
 	},
 	"from_database": {
-		Type:          schema.TypeString,
-		Description:   "Specify a database to create a clone from.",
-		Optional:      true,
-		ForceNew:      true,
-		ConflictsWith: []string{"from_share"},
+		Type:             schema.TypeString,
+		Description:      "Specify a database to create a clone from.",
+		Optional:         true,
+		ForceNew:         true,
+		ConflictsWith:    []string{"from_share"},
+		DiffSuppressFunc: diffIdentifier,
 	},
 }
 
-var databaseProperties = []string{"comment", "data_retention_time_in_days"}
+var databaseProperties = Properties{
+	{Attribute: "comment", Type: StringProperty, Column: "comment"},
+	{Attribute: "data_retention_time_in_days", Type: IntProperty, ObjectParameter: true, Unset: UnsetAlways},
+	{Attribute: "max_data_extension_time_in_days", Type: IntProperty, ObjectParameter: true, Unset: UnsetAlways},
+	{Attribute: "default_ddl_collation", Type: StringProperty, ObjectParameter: true, Unset: UnsetAlways},
+}
 
 // Database returns a pointer to the resource representing a database
 func Database() *schema.Resource {
@@ -57,6 +77,11 @@ func Database() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+
+		// Cloning a large database can take a long time
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
 	}
 }
 
@@ -70,7 +95,7 @@ func CreateDatabase(data *schema.ResourceData, meta interface{}) error {
 		return createDatabaseFromDatabase(data, meta)
 	}
 
-	return CreateResource("database", databaseProperties, databaseSchema, snowflake.Database, ReadDatabase)(data, meta)
+	return CreateResource("database", databaseProperties, snowflake.Database, ReadDatabase)(data, meta)
 }
 
 func createDatabaseFromShare(data *schema.ResourceData, meta interface{}) error {
@@ -82,11 +107,14 @@ func createDatabaseFromShare(data *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("from_share must contain the keys provider and share, but it had %+v", in)
 	}
 
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	name := data.Get("name").(string)
 	builder := snowflake.DatabaseFromShare(name, prov.(string), share.(string))
 
-	err := snowflake.Exec(db, builder.Create())
+	err := client.ExecContext(ctx, builder.Create())
 	if err != nil {
 		return errors.Wrapf(err, "error creating database %v from share %v.%v", name, prov, share)
 	}
@@ -99,11 +127,14 @@ func createDatabaseFromShare(data *schema.ResourceData, meta interface{}) error
 func createDatabaseFromDatabase(data *schema.ResourceData, meta interface{}) error {
 	sourceDb := data.Get("from_database").(string)
 
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	name := data.Get("name").(string)
 	builder := snowflake.DatabaseFromDatabase(name, sourceDb)
 
-	err := snowflake.Exec(db, builder.Create())
+	err := client.ExecContext(ctx, builder.Create())
 	if err != nil {
 		return errors.Wrapf(err, "error creating a clone database %v from database %v", name, sourceDb)
 	}
@@ -114,43 +145,11 @@ func createDatabaseFromDatabase(data *schema.ResourceData, meta interface{}) err
 }
 
 func ReadDatabase(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
-	name := data.Id()
-
-	stmt := snowflake.Database(name).Show()
-	row := snowflake.QueryRow(db, stmt)
-
-	database, err := snowflake.ScanDatabase(row)
-
-	if err != nil {
-		if err == sql.ErrNoRows {
-			log.Printf("[WARN] database %v not found, removing from state file", name)
-			data.SetId("")
-			return nil
-		}
-		return errors.Wrap(err, "unable to scan row for SHOW DATABASES")
-	}
-
-	err = data.Set("name", database.DBName.String)
-	if err != nil {
-		return err
-	}
-	err = data.Set("comment", database.Comment.String)
-	if err != nil {
-		return err
-	}
-
-	i, err := strconv.ParseInt(database.RetentionTime.String, 10, 64)
-	if err != nil {
-		return err
-	}
-
-	err = data.Set("data_retention_time_in_days", i)
-	return err
+	return ReadResource("database", databaseProperties, snowflake.Database)(data, meta)
 }
 
 func UpdateDatabase(data *schema.ResourceData, meta interface{}) error {
-	return UpdateResource("database", databaseProperties, databaseSchema, snowflake.Database, ReadDatabase)(data, meta)
+	return UpdateResource("database", databaseProperties, snowflake.Database, ReadDatabase)(data, meta)
 }
 
 func DeleteDatabase(data *schema.ResourceData, meta interface{}) error {
@@ -6,23 +6,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
-var ValidDatabasePrivileges = newPrivilegeSet(
-	privilegeAll,
-	privilegeCreateSchema,
-	privilegeImportedPrivileges,
-	privilegeModify,
-	privilegeMonitor,
-	privilegeOwnership,
-	privilegeReferenceUsage,
-	privilegeUsage,
-)
+var ValidDatabasePrivileges = grantObjectDatabase.privileges()
 
 var databaseGrantSchema = map[string]*schema.Schema{
 	"database_name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "The name of the database on which to grant privileges.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The name of the database on which to grant privileges.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"privilege": {
 		Type:         schema.TypeString,
@@ -38,6 +30,15 @@ var databaseGrantSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Grants privilege to these roles.",
 		ForceNew:    true,
+		Set:         hashIdentifier,
+	},
+	"database_roles": {
+		Type:        schema.TypeSet,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Optional:    true,
+		Description: "Grants privilege to these database roles, each in the form <database>.<role>.",
+		ForceNew:    true,
+		Set:         hashQualifiedIdentifier,
 	},
 	"shares": {
 		Type:        schema.TypeSet,
@@ -45,6 +46,7 @@ var databaseGrantSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Grants privilege to these shares.",
 		ForceNew:    true,
+		Set:         hashIdentifier,
 	},
 	"with_grant_option": {
 		Type:        schema.TypeBool,
@@ -62,7 +64,8 @@ func DatabaseGrant() *schema.Resource {
 		Read:   ReadDatabaseGrant,
 		Delete: DeleteDatabaseGrant,
 
-		Schema: databaseGrantSchema,
+		Schema:        databaseGrantSchema,
+		CustomizeDiff: customizeDiffGrant(grantObjectDatabase, databaseGrantSchema),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
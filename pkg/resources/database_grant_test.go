@@ -1,7 +1,6 @@
 package resources_test
 
 import (
-	"database/sql"
 	"testing"
 	"time"
 
@@ -12,6 +11,7 @@ import (
 
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
 )
 
@@ -34,13 +34,13 @@ func TestDatabaseGrantCreate(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.DatabaseGrant().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`^GRANT USAGE ON DATABASE "test-database" TO ROLE "test-role-1" WITH GRANT OPTION$`).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectExec(`^GRANT USAGE ON DATABASE "test-database" TO ROLE "test-role-2" WITH GRANT OPTION$`).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectExec(`^GRANT USAGE ON DATABASE "test-database" TO SHARE "test-share-1" WITH GRANT OPTION$`).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectExec(`^GRANT USAGE ON DATABASE "test-database" TO SHARE "test-share-2" WITH GRANT OPTION$`).WillReturnResult(sqlmock.NewResult(1, 1))
 		expectReadDatabaseGrant(mock)
-		err := resources.CreateDatabaseGrant(d, db)
+		err := resources.CreateDatabaseGrant(d, client)
 		r.NoError(err)
 	})
 }
@@ -58,9 +58,9 @@ func TestDatabaseGrantRead(t *testing.T) {
 
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		expectReadDatabaseGrant(mock)
-		err := resources.ReadDatabaseGrant(d, db)
+		err := resources.ReadDatabaseGrant(d, client)
 		r.NoError(err)
 	})
 }
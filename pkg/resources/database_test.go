@@ -1,12 +1,12 @@
 package resources_test
 
 import (
-	"database/sql"
 	"testing"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/stretchr/testify/require"
@@ -28,17 +28,23 @@ func TestDatabaseCreate(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.Database().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`CREATE DATABASE "good_name" COMMENT='great comment`).WillReturnResult(sqlmock.NewResult(1, 1))
 		expectRead(mock)
-		err := resources.CreateDatabase(d, db)
+		err := resources.CreateDatabase(d, client)
 		r.NoError(err)
 	})
 }
 
 func expectRead(mock sqlmock.Sqlmock) {
 	rows := sqlmock.NewRows([]string{"created_on", "name", "is_default", "is_current", "origin", "owner", "comment", "options", "retention_time"}).AddRow("created_on", "good_name", "is_default", "is_current", "origin", "owner", "mock comment", "options", "1")
-	mock.ExpectQuery("SHOW DATABASES LIKE 'good_name'").WillReturnRows(rows)
+	mock.ExpectQuery(`^SHOW DATABASES$`).WillReturnRows(rows)
+
+	params := sqlmock.NewRows([]string{"key", "value", "default", "level", "description", "type"}).
+		AddRow("DATA_RETENTION_TIME_IN_DAYS", "7", "1", "DATABASE", "desc", "NUMBER").
+		AddRow("MAX_DATA_EXTENSION_TIME_IN_DAYS", "14", "14", "ACCOUNT", "desc", "NUMBER").
+		AddRow("DEFAULT_DDL_COLLATION", "", "", "", "desc", "STRING")
+	mock.ExpectQuery(`^SHOW PARAMETERS IN DATABASE "good_name"$`).WillReturnRows(params)
 }
 
 func TestDatabaseRead(t *testing.T) {
@@ -46,13 +52,17 @@ func TestDatabaseRead(t *testing.T) {
 
 	d := database(t, "good_name", map[string]interface{}{"name": "good_name"})
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		expectRead(mock)
-		err := resources.ReadDatabase(d, db)
+		err := resources.ReadDatabase(d, client)
 		r.NoError(err)
 		r.Equal("good_name", d.Get("name").(string))
 		r.Equal("mock comment", d.Get("comment").(string))
-		r.Equal(1, d.Get("data_retention_time_in_days").(int))
+		// read from SHOW PARAMETERS, not the retention_time column
+		r.Equal(7, d.Get("data_retention_time_in_days").(int))
+		// inherited from the account
+		r.Equal(-1, d.Get("max_data_extension_time_in_days").(int))
+		r.Equal("", d.Get("default_ddl_collation").(string))
 	})
 }
 
@@ -61,9 +71,9 @@ func TestDatabaseDelete(t *testing.T) {
 
 	d := database(t, "drop_it", map[string]interface{}{"name": "drop_it"})
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`DROP DATABASE "drop_it"`).WillReturnResult(sqlmock.NewResult(1, 1))
-		err := resources.DeleteDatabase(d, db)
+		err := resources.DeleteDatabase(d, client)
 		r.NoError(err)
 	})
 }
@@ -81,10 +91,10 @@ func TestDatabaseCreateFromShare(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.Database().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`CREATE DATABASE "good_name" FROM SHARE "abc123"."my_share"`).WillReturnResult(sqlmock.NewResult(1, 1))
 		expectRead(mock)
-		err := resources.CreateDatabase(d, db)
+		err := resources.CreateDatabase(d, client)
 		r.NoError(err)
 	})
 }
@@ -99,10 +109,10 @@ func TestDatabaseCreateFromDatabase(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.Database().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`CREATE DATABASE "good_name" CLONE "abc123"`).WillReturnResult(sqlmock.NewResult(1, 1))
 		expectRead(mock)
-		err := resources.CreateDatabase(d, db)
+		err := resources.CreateDatabase(d, client)
 		r.NoError(err)
 	})
 }
@@ -2,7 +2,6 @@ package resources
 
 import (
 	"bytes"
-	"database/sql"
 	"encoding/csv"
 	"fmt"
 	"strings"
@@ -19,22 +18,25 @@ const (
 
 var fileFormatSchema = map[string]*schema.Schema{
 	"name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "Specifies the identifier for the file format; must be unique for the schema in which the file format is created.",
-		ForceNew:    true, // TODO: Support RENAME TO
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "Specifies the identifier for the file format; must be unique for the schema in which the file format is created.",
+		ForceNew:         true, // TODO: Support RENAME TO
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"database": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "The database in which to create the file format.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The database in which to create the file format.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"schema": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "The schema in which to create the file format.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The schema in which to create the file format.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"type": {
 		Type:        schema.TypeString,
@@ -155,7 +157,10 @@ func FileFormat() *schema.Resource {
 
 // CreateFileFormat implements schema.CreateFunc
 func CreateFileFormat(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	name := data.Get("name").(string)
 	database := data.Get("database").(string)
 	schema := data.Get("schema").(string)
@@ -195,7 +200,7 @@ func CreateFileFormat(data *schema.ResourceData, meta interface{}) error {
 		builder.WithNullIf(nulls)
 	}
 
-	if err := snowflake.Exec(db, builder.Create()); err != nil {
+	if err := client.ExecContext(ctx, builder.Create()); err != nil {
 		return errors.Wrapf(err, "error creating file format %v", name)
 	}
 
@@ -215,7 +220,10 @@ func CreateFileFormat(data *schema.ResourceData, meta interface{}) error {
 
 // ReadStage implements schema.ReadFunc
 func ReadFileFormat(data *schema.ResourceData, metadata interface{}) error {
-	db := metadata.(*sql.DB)
+	client := metadata.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, metadata, schema.TimeoutRead)
+	defer cancel()
+
 	fileFormatID, err := fileFormatIDFromString(data.Id())
 	if err != nil {
 		return err
@@ -223,12 +231,12 @@ func ReadFileFormat(data *schema.ResourceData, metadata interface{}) error {
 
 	builder := snowflake.FileFormat(fileFormatID.FileFormatName, fileFormatID.DatabaseName, fileFormatID.SchemaName)
 
-	ffData, err := snowflake.DescFileFormat(db, builder.Describe())
+	ffData, err := snowflake.DescFileFormat(ctx, client, builder.Describe())
 	if err != nil {
 		return err
 	}
 
-	row := snowflake.QueryRow(db, builder.Show())
+	row := client.QueryRowContext(ctx, builder.Show())
 	ffMeta, err := snowflake.ScanFileFormatShow(row)
 	if err != nil {
 		return err
@@ -285,11 +293,13 @@ func UpdateFileFormat(data *schema.ResourceData, meta interface{}) error {
 
 	builder := snowflake.FileFormat(fileFormatID.FileFormatName, fileFormatID.DatabaseName, fileFormatID.SchemaName)
 
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutUpdate)
+	defer cancel()
 
 	if data.HasChange("comment") {
 		_, comment := data.GetChange("comment")
-		if err := snowflake.Exec(db, builder.ChangeComment(comment.(string))); err != nil {
+		if err := client.ExecContext(ctx, builder.ChangeComment(comment.(string))); err != nil {
 			return errors.Wrapf(err, "error updating file format comment on %v", data.Id())
 		}
 
@@ -298,7 +308,7 @@ func UpdateFileFormat(data *schema.ResourceData, meta interface{}) error {
 
 	if data.HasChange("compression") {
 		_, compression := data.GetChange("compression")
-		if err := snowflake.Exec(db, builder.ChangeCompression(compression.(string))); err != nil {
+		if err := client.ExecContext(ctx, builder.ChangeCompression(compression.(string))); err != nil {
 			return errors.Wrapf(err, "error updating file format compression on %v", data.Id())
 		}
 
@@ -307,7 +317,7 @@ func UpdateFileFormat(data *schema.ResourceData, meta interface{}) error {
 
 	if data.HasChange("binary_as_text") {
 		_, binaryAsText := data.GetChange("binary_as_text")
-		if err := snowflake.Exec(db, builder.ChangeBinaryAsText(binaryAsText.(bool))); err != nil {
+		if err := client.ExecContext(ctx, builder.ChangeBinaryAsText(binaryAsText.(bool))); err != nil {
 			return errors.Wrapf(err, "error updating file format binary as text on %v", data.Id())
 		}
 
@@ -316,7 +326,7 @@ func UpdateFileFormat(data *schema.ResourceData, meta interface{}) error {
 
 	if data.HasChange("trim_space") {
 		_, trimSpace := data.GetChange("trim_space")
-		if err := snowflake.Exec(db, builder.ChangeTrimSpace(trimSpace.(bool))); err != nil {
+		if err := client.ExecContext(ctx, builder.ChangeTrimSpace(trimSpace.(bool))); err != nil {
 			return errors.Wrapf(err, "error updating file format trim space on %v", data.Id())
 		}
 
@@ -336,7 +346,7 @@ func UpdateFileFormat(data *schema.ResourceData, meta interface{}) error {
 			}
 		}
 
-		if err := snowflake.Exec(db, builder.ChangeNullIf(nulls)); err != nil {
+		if err := client.ExecContext(ctx, builder.ChangeNullIf(nulls)); err != nil {
 			return errors.Wrapf(err, "error updating file format null if on %v", data.Id())
 		}
 
@@ -348,7 +358,10 @@ func UpdateFileFormat(data *schema.ResourceData, meta interface{}) error {
 
 // DeleteFileFormat implements schema.DeleteFunc
 func DeleteFileFormat(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutDelete)
+	defer cancel()
+
 	fileFormatID, err := fileFormatIDFromString(data.Id())
 	if err != nil {
 		return err
@@ -356,7 +369,7 @@ func DeleteFileFormat(data *schema.ResourceData, meta interface{}) error {
 
 	builder := snowflake.FileFormat(fileFormatID.FileFormatName, fileFormatID.DatabaseName, fileFormatID.SchemaName)
 
-	if err := snowflake.Exec(db, builder.Drop()); err != nil {
+	if err := client.ExecContext(ctx, builder.Drop()); err != nil {
 		return errors.Wrapf(err, "error deleting file format %v", data.Id())
 	}
 
@@ -1,7 +1,6 @@
 package resources_test
 
 import (
-	"database/sql"
 	"fmt"
 	"strings"
 	"testing"
@@ -12,6 +11,7 @@ import (
 
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
 )
 
@@ -42,10 +42,10 @@ func TestFileFormatRead(t *testing.T) {
 	data.SetId(strings.Join([]string{databaseName, schemaName, fileFormatName}, "|"))
 	r.NotNil(data)
 
-	testhelpers.WithMockDb(t, func(db *sql.DB, sqlmock sqlmock.Sqlmock) {
+	testhelpers.WithMockClient(t, func(client *snowflake.Client, sqlmock sqlmock.Sqlmock) {
 		expectReadFileFormat(sqlmock)
 
-		err := resources.ReadFileFormat(data, db)
+		err := resources.ReadFileFormat(data, client)
 		r.NoError(err)
 	})
 }
@@ -62,17 +62,17 @@ func TestFileFormatCreate(t *testing.T) {
 	})
 	r.NotNil(data)
 
-	testhelpers.WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	testhelpers.WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(
 			fmt.Sprintf(
-				`^CREATE FILE FORMAT "%v"."%v"."%v" TYPE = "%v" COMMENT = "%v" BINARY_AS_TEXT = true TRIM_SPACE = false$`,
+				`^CREATE FILE FORMAT "%v"."%v"."%v" BINARY_AS_TEXT = true TRIM_SPACE = false TYPE = '%v' COMMENT = '%v' COMPRESSION = 'AUTO'$`,
 				databaseName, schemaName, fileFormatName, fileFormatType, comment,
 			),
 		).WillReturnResult(sqlmock.NewResult(1, 1))
 
 		expectReadFileFormat(mock)
 
-		err := resources.CreateFileFormat(data, db)
+		err := resources.CreateFileFormat(data, client)
 		r.NoError(err)
 	})
 }
@@ -81,7 +81,7 @@ func expectReadFileFormat(sqlmock sqlmock.Sqlmock) {
 	showRows := sqlmock.NewRows([]string{
 		"format_options", "created_on", "name", "database_name", "schema_name", "type", "owner", "comment",
 	}).AddRow("{}", "2000-01-01 00:00:00.000 +0000", fileFormatName, databaseName, schemaName, fileFormatType, "SYSADMIN", comment)
-	sqlmock.ExpectQuery(fmt.Sprintf(`^SHOW FILE FORMATS LIKE '%v' IN DATABASE "%v"$`, fileFormatName, databaseName)).
+	sqlmock.ExpectQuery(fmt.Sprintf(`^SHOW FILE FORMATS LIKE 'test\\\\_file\\\\_format' IN DATABASE "%v"$`, databaseName)).
 		WillReturnRows(showRows)
 
 	descRows := sqlmock.NewRows([]string{
@@ -2,7 +2,7 @@ package resources
 
 import (
 	"bytes"
-	"database/sql"
+	"context"
 	"encoding/csv"
 	"fmt"
 	"strings"
@@ -10,7 +10,6 @@ import (
 
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/jmoiron/sqlx"
 )
 
 const (
@@ -169,27 +168,44 @@ func grantIDFromString(stringID string) (*grantID, error) {
 	return grantResult, nil
 }
 
+// grantee is a principal of any kind that privileges can be granted to
+type grantee struct {
+	Type snowflake.GranteeType
+	Name string
+}
+
+// granteeAttributes maps each kind of grantee to the set attribute that lists
+// it. Grant resources only declare the attributes for the grantee types that
+// their object type supports.
+var granteeAttributes = []struct {
+	Type      snowflake.GranteeType
+	Attribute string
+}{
+	{snowflake.RoleGrantee, "roles"},
+	{snowflake.ShareGrantee, "shares"},
+	{snowflake.DatabaseRoleGrantee, "database_roles"},
+}
+
 func createGenericGrant(data *schema.ResourceData, meta interface{}, builder snowflake.GrantBuilder) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutCreate)
+	defer cancel()
 
 	priv := data.Get("privilege").(string)
 	grantOption := data.Get("with_grant_option").(bool)
 
-	roles, shares := expandRolesAndShares(data)
+	grantees := expandGrantees(data)
 
-	if len(roles)+len(shares) == 0 {
-		return fmt.Errorf("no roles or shares specified for this grant")
+	if len(grantees) == 0 {
+		return fmt.Errorf("no grantees specified for this grant")
 	}
 
-	for _, role := range roles {
-		err := snowflake.Exec(db, builder.Role(role).Grant(priv, grantOption))
+	for _, g := range grantees {
+		ge, err := builder.Grantee(g.Type, g.Name)
 		if err != nil {
 			return err
 		}
-	}
-
-	for _, share := range shares {
-		err := snowflake.Exec(db, builder.Share(share).Grant(priv, grantOption))
+		err = client.ExecContext(ctx, ge.Grant(priv, grantOption))
 		if err != nil {
 			return err
 		}
@@ -199,13 +215,16 @@ func createGenericGrant(data *schema.ResourceData, meta interface{}, builder sno
 }
 
 func readGenericGrant(data *schema.ResourceData, meta interface{}, builder snowflake.GrantBuilder, futureObjects bool, validPrivileges privilegeSet) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
+
 	var grants []*grant
 	var err error
 	if futureObjects {
-		grants, err = readGenericFutureGrants(db, builder)
+		grants, err = readGenericFutureGrants(ctx, client, builder)
 	} else {
-		grants, err = readGenericCurrentGrants(db, builder)
+		grants, err = readGenericCurrentGrants(ctx, client, builder)
 	}
 	if err != nil {
 		return err
@@ -216,48 +235,36 @@ func readGenericGrant(data *schema.ResourceData, meta interface{}, builder snowf
 	// We re-aggregate grants that would be equivalent to the "ALL" grant
 	grants = filterALLGrants(grants, validPrivileges)
 
-	// Map of roles to privileges
-	rolePrivileges := map[string]privilegeSet{}
-	sharePrivileges := map[string]privilegeSet{}
+	// Map of grantees to privileges
+	granteePrivileges := map[grantee]privilegeSet{}
 
 	// List of all grants for each schema_database
 	for _, grant := range grants {
-		switch grant.GranteeType {
-		case "ROLE":
-			roleName := grant.GranteeName
-			// Find set of privileges
-			privileges, ok := rolePrivileges[roleName]
-			if !ok {
-				// If not there, create an empty set
-				privileges = privilegeSet{}
-			}
-			// Add privilege to the set
-			privileges.addString(grant.Privilege)
-			// Reassign set back
-			rolePrivileges[roleName] = privileges
-		case "SHARE":
-			granteeNameStrippedAccount := StripAccountFromName(grant.GranteeName)
-			// Find set of privileges
-			privileges, ok := sharePrivileges[granteeNameStrippedAccount]
-			if !ok {
-				// If not there, create an empty set
-				privileges = privilegeSet{}
-			}
-			// Add privilege to the set
-			privileges.addString(grant.Privilege)
-			// Reassign set back
-			sharePrivileges[granteeNameStrippedAccount] = privileges
-		default:
-			return fmt.Errorf("unknown grantee type %s", grant.GranteeType)
+		g := grantee{
+			Type: snowflake.GranteeTypeFromShow(grant.GranteeType),
+			Name: grant.GranteeName,
 		}
+		if g.Type == snowflake.ShareGrantee {
+			g.Name = StripAccountFromName(g.Name)
+		}
+		// Find set of privileges
+		privileges, ok := granteePrivileges[g]
+		if !ok {
+			// If not there, create an empty set
+			privileges = privilegeSet{}
+		}
+		// Add privilege to the set
+		privileges.addString(grant.Privilege)
+		// Reassign set back
+		granteePrivileges[g] = privileges
 	}
 
-	var roles, shares []string
-	// Now see which roles have our privilege
-	for roleName, privileges := range rolePrivileges {
+	// Now see which grantees have our privilege
+	granteeNames := map[snowflake.GranteeType][]string{}
+	for g, privileges := range granteePrivileges {
 		// Where priv is not all so it should match exactly
 		if privileges.hasString(priv) || privileges.ALLPrivsPresent(validPrivileges) {
-			roles = append(roles, roleName)
+			granteeNames[g.Type] = append(granteeNames[g.Type], g.Name)
 		}
 	}
 
@@ -265,15 +272,13 @@ func readGenericGrant(data *schema.ResourceData, meta interface{}, builder snowf
 	if err != nil {
 		return err
 	}
-	err = data.Set("roles", roles)
-	if err != nil {
-		return err
-	}
-	err = data.Set("shares", shares)
-	if err != nil {
-		// warehouses and future grants don't use shares - check for this error
-		if !strings.HasPrefix(err.Error(), "Invalid address to set") {
-			return err
+	for _, ga := range granteeAttributes {
+		err = data.Set(ga.Attribute, granteeNames[ga.Type])
+		if err != nil {
+			// not every grant resource supports every grantee type - check for this error
+			if !strings.HasPrefix(err.Error(), "Invalid address to set") {
+				return err
+			}
 		}
 	}
 	err = data.Set("with_grant_option", grantOption)
@@ -283,9 +288,29 @@ func readGenericGrant(data *schema.ResourceData, meta interface{}, builder snowf
 	return nil
 }
 
-func readGenericCurrentGrants(db *sql.DB, builder snowflake.GrantBuilder) ([]*grant, error) {
+// cachedGrants memoizes the grants read by stmt in the ShowCache of client, so
+// that the grant resources for different privileges on one object share a
+// single SHOW GRANTS. Snowflake cannot show the grants on all objects of a
+// scope at once, so current grants still take a query per object.
+func cachedGrants(client *snowflake.Client, stmt string, load func() ([]*grant, error)) ([]*grant, error) {
+	grants, err := client.Cache().Get(stmt, func() (interface{}, error) {
+		return load()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return grants.([]*grant), nil
+}
+
+func readGenericCurrentGrants(ctx context.Context, client *snowflake.Client, builder snowflake.GrantBuilder) ([]*grant, error) {
 	stmt := builder.Show()
-	rows, err := snowflake.Query(db, stmt)
+	return cachedGrants(client, stmt, func() ([]*grant, error) {
+		return queryGenericCurrentGrants(ctx, client, stmt)
+	})
+}
+
+func queryGenericCurrentGrants(ctx context.Context, client *snowflake.Client, stmt string) ([]*grant, error) {
+	rows, err := client.QueryContext(ctx, stmt)
 	if err != nil {
 		return nil, err
 	}
@@ -320,11 +345,31 @@ func readGenericCurrentGrants(db *sql.DB, builder snowflake.GrantBuilder) ([]*gr
 	return grants, nil
 }
 
-func readGenericFutureGrants(db *sql.DB, builder snowflake.GrantBuilder) ([]*grant, error) {
-	conn := sqlx.NewDb(db, "snowflake")
-
+// readGenericFutureGrants returns the future grants of builder. SHOW FUTURE
+// GRANTS covers every type of object in a schema or database, so all future
+// grant resources of the scope share a single query, whose grants are then
+// filtered by type.
+func readGenericFutureGrants(ctx context.Context, client *snowflake.Client, builder snowflake.GrantBuilder) ([]*grant, error) {
 	stmt := builder.Show()
-	rows, err := conn.Queryx(stmt)
+	all, err := cachedGrants(client, stmt, func() ([]*grant, error) {
+		return queryGenericFutureGrants(ctx, client, stmt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	objectType := builder.(*snowflake.FutureGrantBuilder).ObjectType()
+	grants := []*grant{}
+	for _, g := range all {
+		if strings.EqualFold(g.GrantType, objectType) {
+			grants = append(grants, g)
+		}
+	}
+	return grants, nil
+}
+
+func queryGenericFutureGrants(ctx context.Context, client *snowflake.Client, stmt string) ([]*grant, error) {
+	rows, err := client.QueryContext(ctx, stmt)
 	if err != nil {
 		return nil, err
 	}
@@ -353,28 +398,18 @@ func readGenericFutureGrants(db *sql.DB, builder snowflake.GrantBuilder) ([]*gra
 }
 
 func deleteGenericGrant(data *schema.ResourceData, meta interface{}, builder snowflake.GrantBuilder) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutDelete)
+	defer cancel()
 
 	priv := data.Get("privilege").(string)
 
-	var roles, shares []string
-	if _, ok := data.GetOk("roles"); ok {
-		roles = expandStringList(data.Get("roles").(*schema.Set).List())
-	}
-
-	if _, ok := data.GetOk("shares"); ok {
-		shares = expandStringList(data.Get("shares").(*schema.Set).List())
-	}
-
-	for _, role := range roles {
-		err := snowflake.Exec(db, builder.Role(role).Revoke(priv))
+	for _, g := range expandGrantees(data) {
+		ge, err := builder.Grantee(g.Type, g.Name)
 		if err != nil {
 			return err
 		}
-	}
-
-	for _, share := range shares {
-		err := snowflake.Exec(db, builder.Share(share).Revoke(priv))
+		err = client.ExecContext(ctx, ge.Revoke(priv))
 		if err != nil {
 			return err
 		}
@@ -384,14 +419,16 @@ func deleteGenericGrant(data *schema.ResourceData, meta interface{}, builder sno
 	return nil
 }
 
-func expandRolesAndShares(data *schema.ResourceData) ([]string, []string) {
-	var roles, shares []string
-	if _, ok := data.GetOk("roles"); ok {
-		roles = expandStringList(data.Get("roles").(*schema.Set).List())
-	}
-
-	if _, ok := data.GetOk("shares"); ok {
-		shares = expandStringList(data.Get("shares").(*schema.Set).List())
+// expandGrantees returns the typed list of grantees from every grantee
+// attribute that is set on the resource.
+func expandGrantees(data *schema.ResourceData) []grantee {
+	grantees := []grantee{}
+	for _, ga := range granteeAttributes {
+		if v, ok := data.GetOk(ga.Attribute); ok {
+			for _, name := range expandStringList(v.(*schema.Set).List()) {
+				grantees = append(grantees, grantee{Type: ga.Type, Name: name})
+			}
+		}
 	}
-	return roles, shares
+	return grantees
 }
@@ -0,0 +1,294 @@
+package resources
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// identifierFuncs are the schema functions that depend on the identifier
+// policy. Diff suppression and set hashing are not passed the provider's meta,
+// so BindIdentifierPolicy binds them to the policy of each provider instead.
+type identifierFuncs struct {
+	policy func() snowflake.IdentifierPolicy
+}
+
+// quotedIdentifiers are the functions of resources that are not bound to a
+// provider, e.g. in tests
+var quotedIdentifiers = identifierFuncs{
+	policy: func() snowflake.IdentifierPolicy { return snowflake.IdentifierPolicyQuoted },
+}
+
+// diffIdentifier suppresses the diff between two names of the same object
+// under the provider's identifier policy, e.g. analytics and ANALYTICS when
+// names are folded to uppercase
+func diffIdentifier(k, old, new string, d *schema.ResourceData) bool {
+	return quotedIdentifiers.diffIdentifier(k, old, new, d)
+}
+
+// hashIdentifier is the schema.SchemaSetFunc of sets of names, which hashes
+// the names of the same object alike
+func hashIdentifier(v interface{}) int {
+	return quotedIdentifiers.hashIdentifier(v)
+}
+
+// hashQualifiedIdentifier is the schema.SchemaSetFunc of sets of qualified
+// names, e.g. <database>.<role>, which hashes the names of the same object
+// alike
+func hashQualifiedIdentifier(v interface{}) int {
+	return quotedIdentifiers.hashQualifiedIdentifier(v)
+}
+
+func (f identifierFuncs) diffIdentifier(k, old, new string, d *schema.ResourceData) bool {
+	return f.policy().Equal(old, new)
+}
+
+func (f identifierFuncs) hashIdentifier(v interface{}) int {
+	return hashcode.String(f.policy().Normalize(v.(string)))
+}
+
+func (f identifierFuncs) hashQualifiedIdentifier(v interface{}) int {
+	name, _ := mapQualified(v.(string), func(part string) (string, error) {
+		return f.policy().Normalize(part), nil
+	})
+	return hashcode.String(name)
+}
+
+// mapQualified applies f to each part of the qualified name, split as
+// snowflake.GranteeType renders database roles
+func mapQualified(name string, f func(string) (string, error)) (string, error) {
+	parts := strings.SplitN(name, ".", 2)
+	for i, p := range parts {
+		mapped, err := f(p)
+		if err != nil {
+			return name, err
+		}
+		parts[i] = mapped
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// identifierPolicy returns the identifier policy of the provider whose client
+// is meta
+func identifierPolicy(meta interface{}) snowflake.IdentifierPolicy {
+	if client, ok := meta.(*snowflake.Client); ok && client != nil {
+		return client.IdentifierPolicy
+	}
+	return snowflake.IdentifierPolicyQuoted
+}
+
+// BindIdentifierPolicy applies the identifier policy of a provider to r, whose
+// name attributes are those that diff with diffIdentifier or hash with
+// hashIdentifier or hashQualifiedIdentifier. Their diff suppression and set
+// hashing use policy, which returns the policy the provider is configured
+// with. Plans fail on names the client's policy cannot be applied to, and
+// Create and Update normalize the names with it before the resource's
+// functions pass them to builders, so the state and the IDs hold the names of
+// the objects. r's schema is copied rather than changed, as resources share
+// it.
+func BindIdentifierPolicy(r *schema.Resource, policy func() snowflake.IdentifierPolicy) {
+	names := r.Schema
+	if !hasIdentifiers(names) {
+		return
+	}
+	r.Schema = bindIdentifierFuncs(names, identifierFuncs{policy: policy})
+
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(d *schema.ResourceDiff, meta interface{}) error {
+		if err := validateIdentifiers(d, meta, names); err != nil {
+			return err
+		}
+		if customizeDiff == nil {
+			return nil
+		}
+		return customizeDiff(d, meta)
+	}
+
+	wrap := func(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+		if f == nil {
+			return nil
+		}
+		return func(data *schema.ResourceData, meta interface{}) error {
+			if err := normalizeIdentifiers(data, identifierPolicy(meta), names); err != nil {
+				return err
+			}
+			return f(data, meta)
+		}
+	}
+	r.Create = wrap(r.Create)
+	r.Update = wrap(r.Update)
+}
+
+func sameFunc(a, b interface{}) bool {
+	return reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+}
+
+func isIdentifier(s *schema.Schema) bool {
+	return s.Type == schema.TypeString && s.DiffSuppressFunc != nil && sameFunc(s.DiffSuppressFunc, diffIdentifier)
+}
+
+func isIdentifierSet(s *schema.Schema) bool {
+	return s.Type == schema.TypeSet && s.Set != nil && sameFunc(s.Set, hashIdentifier)
+}
+
+func isQualifiedIdentifierSet(s *schema.Schema) bool {
+	return s.Type == schema.TypeSet && s.Set != nil && sameFunc(s.Set, hashQualifiedIdentifier)
+}
+
+func hasIdentifiers(m map[string]*schema.Schema) bool {
+	for _, s := range m {
+		if isIdentifier(s) || isIdentifierSet(s) || isQualifiedIdentifierSet(s) {
+			return true
+		}
+		if elem, ok := s.Elem.(*schema.Resource); ok && hasIdentifiers(elem.Schema) {
+			return true
+		}
+	}
+	return false
+}
+
+func bindIdentifierFuncs(m map[string]*schema.Schema, f identifierFuncs) map[string]*schema.Schema {
+	bound := make(map[string]*schema.Schema, len(m))
+	for k, s := range m {
+		b := *s
+		switch {
+		case isIdentifier(s):
+			b.DiffSuppressFunc = f.diffIdentifier
+		case isIdentifierSet(s):
+			b.Set = f.hashIdentifier
+		case isQualifiedIdentifierSet(s):
+			b.Set = f.hashQualifiedIdentifier
+		case s.DiffSuppressFunc != nil && sameFunc(s.DiffSuppressFunc, diffParameterValue):
+			b.DiffSuppressFunc = f.diffParameterValue
+		}
+		if elem, ok := s.Elem.(*schema.Resource); ok {
+			e := *elem
+			e.Schema = bindIdentifierFuncs(elem.Schema, f)
+			b.Elem = &e
+		}
+		bound[k] = &b
+	}
+	return bound
+}
+
+// mapIdentifiers applies f to the names in v, the value of an attribute of
+// schema s at path k, and returns the value with the names f returns
+func mapIdentifiers(s *schema.Schema, k string, v interface{}, f func(k, name string) (string, error)) (interface{}, bool, error) {
+	switch {
+	case isIdentifier(s):
+		name, _ := v.(string)
+		if name == "" {
+			return v, false, nil
+		}
+		mapped, err := f(k, name)
+		return mapped, mapped != name, err
+	case isIdentifierSet(s), isQualifiedIdentifierSet(s):
+		names := v.(*schema.Set).List()
+		changed := false
+		for i, name := range names {
+			var mapped string
+			var err error
+			if isQualifiedIdentifierSet(s) {
+				mapped, err = mapQualified(name.(string), func(part string) (string, error) { return f(k, part) })
+			} else {
+				mapped, err = f(k, name.(string))
+			}
+			if err != nil {
+				return v, false, err
+			}
+			changed = changed || mapped != name
+			names[i] = mapped
+		}
+		return names, changed, nil
+	}
+
+	elem, ok := s.Elem.(*schema.Resource)
+	if !ok || !hasIdentifiers(elem.Schema) {
+		return v, false, nil
+	}
+	// the blocks of a set are not addressed by their index, so the names in
+	// them are reported at the path of the set
+	var blocks []interface{}
+	path := func(i int, field string) string { return fmt.Sprintf("%v.%d.%v", k, i, field) }
+	switch v := v.(type) {
+	case []interface{}:
+		blocks = v
+	case *schema.Set:
+		blocks = v.List()
+		path = func(int, string) string { return k }
+	}
+	changed := false
+	for i, block := range blocks {
+		fields, ok := block.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		mappedFields := make(map[string]interface{}, len(fields))
+		for field, fv := range fields {
+			mapped, c, err := mapIdentifiers(elem.Schema[field], path(i, field), fv, f)
+			if err != nil {
+				return v, false, err
+			}
+			changed = changed || c
+			mappedFields[field] = mapped
+		}
+		blocks[i] = mappedFields
+	}
+	return blocks, changed, nil
+}
+
+// validateIdentifiers checks that the names in the plan can be used under the
+// client's identifier policy. It is part of CustomizeDiff rather than of the
+// attributes' ValidateFunc, which runs before the provider is configured.
+func validateIdentifiers(d *schema.ResourceDiff, meta interface{}, m map[string]*schema.Schema) error {
+	policy := identifierPolicy(meta)
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		s := m[k]
+		if !d.NewValueKnown(k) {
+			continue
+		}
+		_, _, err := mapIdentifiers(s, k, d.Get(k), func(path, name string) (string, error) {
+			if !d.NewValueKnown(path) {
+				return name, nil
+			}
+			if err := policy.Validate(name); err != nil {
+				return name, fmt.Errorf("%v: %v", path, err)
+			}
+			return name, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeIdentifiers replaces the names in data with those of the objects
+// they refer to under policy
+func normalizeIdentifiers(data *schema.ResourceData, policy snowflake.IdentifierPolicy, m map[string]*schema.Schema) error {
+	for k, s := range m {
+		v, changed, err := mapIdentifiers(s, k, data.Get(k), func(_, name string) (string, error) {
+			return policy.Normalize(name), nil
+		})
+		if err != nil {
+			return err
+		}
+		if !changed {
+			continue
+		}
+		if err := data.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
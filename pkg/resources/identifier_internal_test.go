@@ -0,0 +1,179 @@
+package resources
+
+import (
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func policyOf(p snowflake.IdentifierPolicy) func() snowflake.IdentifierPolicy {
+	return func() snowflake.IdentifierPolicy { return p }
+}
+
+func TestIdentifierDiffs(t *testing.T) {
+	r := require.New(t)
+
+	r.False(diffIdentifier("name", "ANALYTICS", "analytics", nil))
+	r.NotEqual(hashIdentifier("ANALYST"), hashIdentifier("analyst"))
+
+	uppercase := identifierFuncs{policy: policyOf(snowflake.IdentifierPolicyUppercase)}
+	r.True(uppercase.diffIdentifier("name", "ANALYTICS", "analytics", nil))
+	r.False(uppercase.diffIdentifier("name", "HAS SPACE", "has space", nil))
+	r.Equal(uppercase.hashIdentifier("ANALYST"), uppercase.hashIdentifier("analyst"))
+}
+
+// TestBindIdentifierPolicy checks that providers with different policies do
+// not share them through the schemas of their resources
+func TestBindIdentifierPolicy(t *testing.T) {
+	r := require.New(t)
+
+	uppercase := Database()
+	BindIdentifierPolicy(uppercase, policyOf(snowflake.IdentifierPolicyUppercase))
+	quoted := Database()
+	BindIdentifierPolicy(quoted, policyOf(snowflake.IdentifierPolicyQuoted))
+
+	r.True(uppercase.Schema["name"].DiffSuppressFunc("name", "ANALYTICS", "analytics", nil))
+	r.False(quoted.Schema["name"].DiffSuppressFunc("name", "ANALYTICS", "analytics", nil))
+	r.False(Database().Schema["name"].DiffSuppressFunc("name", "ANALYTICS", "analytics", nil))
+
+	grants := RoleGrants()
+	BindIdentifierPolicy(grants, policyOf(snowflake.IdentifierPolicyUppercase))
+	roles := grants.Schema["roles"].Set
+	r.Equal(roles("reader"), roles("READER"))
+	r.NotEqual(RoleGrants().Schema["roles"].Set("reader"), RoleGrants().Schema["roles"].Set("READER"))
+
+	dbGrant := DatabaseGrant()
+	BindIdentifierPolicy(dbGrant, policyOf(snowflake.IdentifierPolicyUppercase))
+	databaseRoles := dbGrant.Schema["database_roles"].Set
+	r.Equal(databaseRoles("analytics.reader"), databaseRoles("ANALYTICS.READER"))
+	r.NotEqual(databaseRoles("analytics.reader"), databaseRoles("analytics.writer"))
+}
+
+// TestIdentifierPolicyBeforeConfigure checks that the policy is applied to
+// names at plan time, when the provider is configured, rather than by schema
+// validation, which Terraform runs before the provider is configured
+func TestIdentifierPolicyBeforeConfigure(t *testing.T) {
+	r := require.New(t)
+
+	res := RoleMembership()
+	BindIdentifierPolicy(res, policyOf(snowflake.IdentifierPolicyUppercase))
+	cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"role_name":    "1analyst",
+		"grantee_type": "USER",
+		"grantee_name": "bob",
+	})
+
+	_, errs := res.Validate(cfg)
+	r.Empty(errs)
+
+	client := snowflake.NewClient(nil)
+	client.IdentifierPolicy = snowflake.IdentifierPolicyUppercase
+	_, err := res.Diff(nil, cfg, client)
+	r.EqualError(err, "role_name: '1analyst' is not a valid unquoted identifier, as the uppercase identifier policy requires")
+
+	client.IdentifierPolicy = snowflake.IdentifierPolicyQuoted
+	_, err = res.Diff(nil, cfg, client)
+	r.NoError(err)
+}
+
+// TestIdentifierPolicyValidatesEveryName checks that the uppercase policy
+// rejects every name it would not fold, not only role names
+func TestIdentifierPolicyValidatesEveryName(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource func() *schema.Resource
+		config   map[string]interface{}
+		err      string
+	}{
+		{
+			"database",
+			Database,
+			map[string]interface{}{"name": "has space"},
+			"name: 'has space' is not a valid unquoted identifier, as the uppercase identifier policy requires",
+		},
+		{
+			"set of roles",
+			DatabaseGrant,
+			map[string]interface{}{"database_name": "db", "roles": []interface{}{"reader", "has-dash"}},
+			"roles: 'has-dash' is not a valid unquoted identifier, as the uppercase identifier policy requires",
+		},
+		{
+			"set of database roles",
+			DatabaseGrant,
+			map[string]interface{}{"database_name": "db", "database_roles": []interface{}{"db.has space"}},
+			"database_roles: 'has space' is not a valid unquoted identifier, as the uppercase identifier policy requires",
+		},
+		{
+			"nested block",
+			User,
+			map[string]interface{}{"name": "bob", "parameters": []interface{}{map[string]interface{}{"network_policy": "my policy"}}},
+			"parameters.0.network_policy: 'my policy' is not a valid unquoted identifier, as the uppercase identifier policy requires",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			res := tt.resource()
+			BindIdentifierPolicy(res, policyOf(snowflake.IdentifierPolicyUppercase))
+			cfg := terraform.NewResourceConfigRaw(tt.config)
+
+			client := snowflake.NewClient(nil)
+			client.IdentifierPolicy = snowflake.IdentifierPolicyUppercase
+			_, err := res.Diff(nil, cfg, client)
+			r.EqualError(err, tt.err)
+
+			client.IdentifierPolicy = snowflake.IdentifierPolicyQuoted
+			_, err = res.Diff(nil, cfg, client)
+			r.NoError(err)
+		})
+	}
+}
+
+// TestIdentifierPolicyUnknownName checks that names that are not known yet are
+// validated once they are
+func TestIdentifierPolicyUnknownName(t *testing.T) {
+	r := require.New(t)
+
+	res := Database()
+	BindIdentifierPolicy(res, policyOf(snowflake.IdentifierPolicyUppercase))
+	cfg := terraform.NewResourceConfigRaw(map[string]interface{}{"name": "74D93920-ED26-11E3-AC10-0800200C9A66"})
+
+	client := snowflake.NewClient(nil)
+	client.IdentifierPolicy = snowflake.IdentifierPolicyUppercase
+	_, err := res.Diff(nil, cfg, client)
+	r.NoError(err)
+}
+
+// TestIdentifierPolicyNormalizesNames checks that builders and IDs are given
+// the names of the objects under the client's policy
+func TestIdentifierPolicyNormalizesNames(t *testing.T) {
+	r := require.New(t)
+
+	res := RoleGrants()
+	BindIdentifierPolicy(res, policyOf(snowflake.IdentifierPolicyUppercase))
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"role_name": "analyst",
+		"roles":     []interface{}{"reader"},
+		"users":     []interface{}{"bob"},
+	})
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		client.IdentifierPolicy = snowflake.IdentifierPolicyUppercase
+		mock.ExpectExec(`^GRANT ROLE "ANALYST" TO ROLE "READER"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`^GRANT ROLE "ANALYST" TO USER "BOB"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		rows := sqlmock.NewRows([]string{"created_on", "role", "granted_to", "grantee_name", "granted_by"}).
+			AddRow("_", "ANALYST", "ROLE", "READER", "").
+			AddRow("_", "ANALYST", "USER", "BOB", "")
+		mock.ExpectQuery(`^SHOW GRANTS OF ROLE "ANALYST"$`).WillReturnRows(rows)
+
+		r.NoError(res.Create(d, client))
+		r.Equal("ANALYST", d.Id())
+		r.Equal("ANALYST", d.Get("role_name"))
+		r.Equal([]interface{}{"READER"}, d.Get("roles").(*schema.Set).List())
+	})
+}
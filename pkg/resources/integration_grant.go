@@ -6,17 +6,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
-var validIntegrationPrivileges = newPrivilegeSet(
-	privilegeAll,
-	privilegeUsage,
-	privilegeOwnership,
-)
+var validIntegrationPrivileges = grantObjectIntegration.privileges()
 var integrationGrantSchema = map[string]*schema.Schema{
 	"integration_name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "Identifier for the integration; must be unique for your account.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "Identifier for the integration; must be unique for your account.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"privilege": {
 		Type:         schema.TypeString,
@@ -32,6 +29,7 @@ var integrationGrantSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Grants privilege to these roles.",
 		ForceNew:    true,
+		Set:         hashIdentifier,
 	},
 	"with_grant_option": {
 		Type:        schema.TypeBool,
@@ -49,7 +47,8 @@ func IntegrationGrant() *schema.Resource {
 		Read:   ReadIntegrationGrant,
 		Delete: DeleteIntegrationGrant,
 
-		Schema: integrationGrantSchema,
+		Schema:        integrationGrantSchema,
+		CustomizeDiff: customizeDiffGrant(grantObjectIntegration, integrationGrantSchema),
 	}
 }
 
@@ -1,7 +1,6 @@
 package resources_test
 
 import (
-	"database/sql"
 	"testing"
 	"time"
 
@@ -12,6 +11,7 @@ import (
 
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
 )
 
@@ -33,11 +33,11 @@ func TestIntegrationGrantCreate(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.IntegrationGrant().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`^GRANT USAGE ON INTEGRATION "test-integration" TO ROLE "test-role-1" WITH GRANT OPTION$`).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectExec(`^GRANT USAGE ON INTEGRATION "test-integration" TO ROLE "test-role-2" WITH GRANT OPTION$`).WillReturnResult(sqlmock.NewResult(1, 1))
 		expectReadIntegrationGrant(mock)
-		err := resources.CreateIntegrationGrant(d, db)
+		err := resources.CreateIntegrationGrant(d, client)
 		r.NoError(err)
 	})
 }
@@ -54,9 +54,9 @@ func TestIntegrationGrantRead(t *testing.T) {
 
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		expectReadIntegrationGrant(mock)
-		err := resources.ReadIntegrationGrant(d, db)
+		err := resources.ReadIntegrationGrant(d, client)
 		r.NoError(err)
 	})
 }
@@ -1,7 +1,6 @@
 package resources
 
 import (
-	"database/sql"
 	"fmt"
 	"log"
 	"time"
@@ -17,19 +16,20 @@ const (
 	SnowflakeReaderAccountType = "READER"
 )
 
-var managedAccountProperties = []string{
-	"admin_name",
-	"admin_password",
-	"type",
-	"comment",
+var managedAccountProperties = Properties{
+	{Attribute: "admin_name", Type: StringProperty, CreateOnly: true},
+	{Attribute: "admin_password", Type: StringProperty, CreateOnly: true},
+	{Attribute: "type", Type: EnumProperty, CreateOnly: true},
+	{Attribute: "comment", Type: StringProperty, CreateOnly: true},
 }
 
 var managedAccountSchema = map[string]*schema.Schema{
 	"name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "Identifier for the managed account; must be unique for your account.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "Identifier for the managed account; must be unique for your account.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"admin_name": {
 		Type:        schema.TypeString,
@@ -106,7 +106,6 @@ func CreateManagedAccount(data *schema.ResourceData, meta interface{}) error {
 	return CreateResource(
 		"this does not seem to be used",
 		managedAccountProperties,
-		managedAccountSchema,
 		snowflake.ManagedAccount,
 		initialReadManagedAccount,
 	)(data, meta)
@@ -123,11 +122,14 @@ func initialReadManagedAccount(data *schema.ResourceData, meta interface{}) erro
 
 // ReadManagedAccount implements schema.ReadFunc
 func ReadManagedAccount(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
+
 	id := data.Id()
 
 	stmt := snowflake.ManagedAccount(id).Show()
-	row := snowflake.QueryRow(db, stmt)
+	row := client.QueryRowContext(ctx, stmt)
 	a, err := snowflake.ScanManagedAccount(row)
 	if err != nil {
 		return err
@@ -183,11 +185,13 @@ func DeleteManagedAccount(data *schema.ResourceData, meta interface{}) error {
 
 // ManagedAccountExists implements schema.ExistsFunc
 func ManagedAccountExists(data *schema.ResourceData, meta interface{}) (bool, error) {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
 	id := data.Id()
 
 	stmt := snowflake.ManagedAccount(id).Show()
-	rows, err := db.Query(stmt)
+	rows, err := client.QueryContext(ctx, stmt)
 	if err != nil {
 		return false, err
 	}
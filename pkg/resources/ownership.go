@@ -0,0 +1,355 @@
+package resources
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pkg/errors"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+)
+
+const (
+	ownershipIDDelimiter = '|'
+)
+
+// Objects that live directly in the account only need an object_name.
+var ownershipAccountObjectTypes = []string{
+	"DATABASE",
+	"INTEGRATION",
+	"RESOURCE MONITOR",
+	"ROLE",
+	"USER",
+	"WAREHOUSE",
+}
+
+// Objects that live in a database need a database_name and an object_name.
+var ownershipDatabaseObjectTypes = []string{
+	"SCHEMA",
+}
+
+// Objects that live in a schema need a database_name, schema_name and object_name.
+var ownershipSchemaObjectTypes = []string{
+	"EXTERNAL TABLE",
+	"FILE FORMAT",
+	"MATERIALIZED VIEW",
+	"PIPE",
+	"SEQUENCE",
+	"STAGE",
+	"STREAM",
+	"TABLE",
+	"TASK",
+	"VIEW",
+}
+
+var ownershipSchema = map[string]*schema.Schema{
+	"object_type": {
+		Type:         schema.TypeString,
+		Required:     true,
+		Description:  "The type of the object whose ownership is transferred.",
+		ForceNew:     true,
+		ValidateFunc: validation.StringInSlice(ownershipObjectTypes(), true),
+		StateFunc: func(val interface{}) string {
+			return strings.ToUpper(val.(string))
+		},
+	},
+	"object_name": {
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The name of the object whose ownership is transferred.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
+	},
+	"database_name": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		Description:      "The name of the database containing the object (required for schemas and schema objects).",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
+	},
+	"schema_name": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		Description:      "The name of the schema containing the object (required for schema objects).",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
+	},
+	"role_name": {
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The role that will own the object.",
+		DiffSuppressFunc: diffIdentifier,
+	},
+	"current_grants": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "Whether to COPY or REVOKE the existing outbound privileges on the object when ownership is transferred.",
+		Default:      string(snowflake.CopyCurrentGrants),
+		ValidateFunc: validation.StringInSlice([]string{string(snowflake.CopyCurrentGrants), string(snowflake.RevokeCurrentGrants)}, false),
+	},
+	"revert_ownership_to_role_name": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		Description:      "The role that ownership is transferred to when this resource is destroyed. Ownership cannot be revoked, only transferred.",
+		Default:          "SYSADMIN",
+		DiffSuppressFunc: diffIdentifier,
+	},
+}
+
+// Ownership returns a pointer to the resource representing the ownership of an object
+func Ownership() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateOwnership,
+		Read:   ReadOwnership,
+		Update: UpdateOwnership,
+		Delete: DeleteOwnership,
+
+		Schema: ownershipSchema,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func ownershipObjectTypes() []string {
+	types := []string{}
+	types = append(types, ownershipAccountObjectTypes...)
+	types = append(types, ownershipDatabaseObjectTypes...)
+	types = append(types, ownershipSchemaObjectTypes...)
+	return types
+}
+
+// ownershipID contains the elements that identify the object being owned
+type ownershipID struct {
+	ObjectType   string
+	DatabaseName string
+	SchemaName   string
+	ObjectName   string
+}
+
+// String() takes in an ownershipID object and returns a pipe-delimited string:
+// ObjectType|DatabaseName|SchemaName|ObjectName
+func (oi *ownershipID) String() (string, error) {
+	var buf bytes.Buffer
+	csvWriter := csv.NewWriter(&buf)
+	csvWriter.Comma = ownershipIDDelimiter
+	dataIdentifiers := [][]string{{oi.ObjectType, oi.DatabaseName, oi.SchemaName, oi.ObjectName}}
+	err := csvWriter.WriteAll(dataIdentifiers)
+	if err != nil {
+		return "", err
+	}
+	strOwnershipID := strings.TrimSpace(buf.String())
+	return strOwnershipID, nil
+}
+
+// ownershipIDFromString() takes in a pipe-delimited string: ObjectType|DatabaseName|SchemaName|ObjectName
+// and returns an ownershipID object
+func ownershipIDFromString(stringID string) (*ownershipID, error) {
+	reader := csv.NewReader(strings.NewReader(stringID))
+	reader.Comma = ownershipIDDelimiter
+	lines, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("Not CSV compatible")
+	}
+
+	if len(lines) != 1 {
+		return nil, fmt.Errorf("1 line per ownership")
+	}
+	if len(lines[0]) != 4 {
+		return nil, fmt.Errorf("4 fields allowed")
+	}
+
+	return &ownershipID{
+		ObjectType:   lines[0][0],
+		DatabaseName: lines[0][1],
+		SchemaName:   lines[0][2],
+		ObjectName:   lines[0][3],
+	}, nil
+}
+
+// validate checks that the name parts required by the object type are set
+// and that no superfluous ones are.
+func (oi *ownershipID) validate() error {
+	switch {
+	case stringInSlice(oi.ObjectType, ownershipAccountObjectTypes):
+		if oi.DatabaseName != "" || oi.SchemaName != "" {
+			return fmt.Errorf("database_name and schema_name must be unset for object_type %v", oi.ObjectType)
+		}
+	case stringInSlice(oi.ObjectType, ownershipDatabaseObjectTypes):
+		if oi.DatabaseName == "" {
+			return fmt.Errorf("database_name must be set for object_type %v", oi.ObjectType)
+		}
+		if oi.SchemaName != "" {
+			return fmt.Errorf("schema_name must be unset for object_type %v", oi.ObjectType)
+		}
+	case stringInSlice(oi.ObjectType, ownershipSchemaObjectTypes):
+		if oi.DatabaseName == "" || oi.SchemaName == "" {
+			return fmt.Errorf("database_name and schema_name must be set for object_type %v", oi.ObjectType)
+		}
+	default:
+		return fmt.Errorf("unsupported object_type %v", oi.ObjectType)
+	}
+	return nil
+}
+
+func (oi *ownershipID) builder() *snowflake.OwnershipBuilder {
+	return snowflake.Ownership(oi.ObjectType, oi.DatabaseName, oi.SchemaName, oi.ObjectName)
+}
+
+func stringInSlice(s string, list []string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateOwnership implements schema.CreateFunc
+func CreateOwnership(data *schema.ResourceData, meta interface{}) error {
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutCreate)
+	defer cancel()
+
+	id := &ownershipID{
+		ObjectType:   strings.ToUpper(data.Get("object_type").(string)),
+		DatabaseName: data.Get("database_name").(string),
+		SchemaName:   data.Get("schema_name").(string),
+		ObjectName:   data.Get("object_name").(string),
+	}
+	err := id.validate()
+	if err != nil {
+		return err
+	}
+
+	role := data.Get("role_name").(string)
+	action := snowflake.CurrentGrantsAction(data.Get("current_grants").(string))
+
+	b := id.builder()
+	err = client.ExecContext(ctx, b.Transfer(role, action))
+	if err != nil {
+		return errors.Wrapf(err, "error transferring ownership of %v %v to %v", id.ObjectType, b.QualifiedName(), role)
+	}
+
+	dataIDInput, err := id.String()
+	if err != nil {
+		return err
+	}
+	data.SetId(dataIDInput)
+
+	return ReadOwnership(data, meta)
+}
+
+// ReadOwnership implements schema.ReadFunc
+func ReadOwnership(data *schema.ResourceData, meta interface{}) error {
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
+
+	id, err := ownershipIDFromString(data.Id())
+	if err != nil {
+		return err
+	}
+
+	rows, err := client.QueryContext(ctx, id.builder().Show())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	owner := ""
+	for rows.Next() {
+		g := &currentGrant{}
+		err = rows.StructScan(g)
+		if err != nil {
+			return err
+		}
+		if g.Privilege == privilegeOwnership.string() {
+			owner = g.GranteeName
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	if owner == "" {
+		log.Printf("[WARN] no owner found for %v %v, removing from state file", id.ObjectType, id.ObjectName)
+		data.SetId("")
+		return nil
+	}
+
+	err = data.Set("object_type", id.ObjectType)
+	if err != nil {
+		return err
+	}
+	err = data.Set("database_name", id.DatabaseName)
+	if err != nil {
+		return err
+	}
+	err = data.Set("schema_name", id.SchemaName)
+	if err != nil {
+		return err
+	}
+	err = data.Set("object_name", id.ObjectName)
+	if err != nil {
+		return err
+	}
+	return data.Set("role_name", owner)
+}
+
+// UpdateOwnership implements schema.UpdateFunc
+func UpdateOwnership(data *schema.ResourceData, meta interface{}) error {
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutUpdate)
+	defer cancel()
+
+	id, err := ownershipIDFromString(data.Id())
+	if err != nil {
+		return err
+	}
+
+	// current_grants and revert_ownership_to_role_name only take effect on
+	// the next transfer, so a change in role_name is the only thing to apply.
+	if data.HasChange("role_name") {
+		role := data.Get("role_name").(string)
+		action := snowflake.CurrentGrantsAction(data.Get("current_grants").(string))
+
+		b := id.builder()
+		err = client.ExecContext(ctx, b.Transfer(role, action))
+		if err != nil {
+			return errors.Wrapf(err, "error transferring ownership of %v %v to %v", id.ObjectType, b.QualifiedName(), role)
+		}
+	}
+
+	return ReadOwnership(data, meta)
+}
+
+// DeleteOwnership implements schema.DeleteFunc. Ownership cannot be revoked,
+// so it is handed over to revert_ownership_to_role_name instead.
+func DeleteOwnership(data *schema.ResourceData, meta interface{}) error {
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutDelete)
+	defer cancel()
+
+	id, err := ownershipIDFromString(data.Id())
+	if err != nil {
+		return err
+	}
+
+	role := data.Get("revert_ownership_to_role_name").(string)
+	action := snowflake.CurrentGrantsAction(data.Get("current_grants").(string))
+
+	b := id.builder()
+	err = client.ExecContext(ctx, b.Transfer(role, action))
+	if err != nil {
+		return errors.Wrapf(err, "error reverting ownership of %v %v to %v", id.ObjectType, b.QualifiedName(), role)
+	}
+
+	data.SetId("")
+	return nil
+}
@@ -0,0 +1,66 @@
+package resources_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccOwnership(t *testing.T) {
+	dbName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	roleName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	roleName2 := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		Providers: providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: ownershipConfig(dbName, roleName, roleName2, "a"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_ownership.test", "object_type", "DATABASE"),
+					resource.TestCheckResourceAttr("snowflake_ownership.test", "object_name", dbName),
+					resource.TestCheckResourceAttr("snowflake_ownership.test", "role_name", roleName),
+					resource.TestCheckResourceAttr("snowflake_ownership.test", "current_grants", "COPY"),
+				),
+			},
+			// TRANSFER
+			{
+				Config: ownershipConfig(dbName, roleName, roleName2, "b"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_ownership.test", "role_name", roleName2),
+				),
+			},
+			// IMPORT
+			{
+				ResourceName:            "snowflake_ownership.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"current_grants", "revert_ownership_to_role_name"},
+			},
+		},
+	})
+}
+
+func ownershipConfig(dbName, roleName, roleName2, owner string) string {
+	return fmt.Sprintf(`
+resource "snowflake_database" "test" {
+  name = "%v"
+}
+
+resource "snowflake_role" "a" {
+  name = "%v"
+}
+
+resource "snowflake_role" "b" {
+  name = "%v"
+}
+
+resource "snowflake_ownership" "test" {
+  object_type = "DATABASE"
+  object_name = snowflake_database.test.name
+  role_name   = snowflake_role.%v.name
+}
+`, dbName, roleName, roleName2, owner)
+}
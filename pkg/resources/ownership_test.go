@@ -0,0 +1,105 @@
+package resources_test
+
+import (
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
+)
+
+func TestOwnership(t *testing.T) {
+	r := require.New(t)
+	err := resources.Ownership().InternalValidate(provider.Provider().Schema, true)
+	r.NoError(err)
+}
+
+func TestOwnershipCreate(t *testing.T) {
+	r := require.New(t)
+
+	d := ownership(t, "", map[string]interface{}{
+		"object_type":    "table",
+		"database_name":  "test-db",
+		"schema_name":    "PUBLIC",
+		"object_name":    "test-table",
+		"role_name":      "test-role",
+		"current_grants": "REVOKE",
+	})
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`^GRANT OWNERSHIP ON TABLE "test-db"."PUBLIC"."test-table" TO ROLE "test-role" REVOKE CURRENT GRANTS$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectReadOwnership(mock)
+		err := resources.CreateOwnership(d, client)
+		r.NoError(err)
+		r.Equal("TABLE|test-db|PUBLIC|test-table", d.Id())
+		r.Equal("test-role", d.Get("role_name").(string))
+	})
+}
+
+func TestOwnershipCreateMissingSchema(t *testing.T) {
+	r := require.New(t)
+
+	d := ownership(t, "", map[string]interface{}{
+		"object_type":   "VIEW",
+		"database_name": "test-db",
+		"object_name":   "test-view",
+		"role_name":     "test-role",
+	})
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		err := resources.CreateOwnership(d, client)
+		r.EqualError(err, "database_name and schema_name must be set for object_type VIEW")
+	})
+}
+
+func expectReadOwnership(mock sqlmock.Sqlmock) {
+	rows := sqlmock.NewRows([]string{
+		"created_on", "privilege", "granted_on", "name", "granted_to", "grantee_name", "grant_option", "granted_by",
+	}).AddRow(
+		time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), "SELECT", "TABLE", "test-table", "ROLE", "other-role", false, "test-role",
+	).AddRow(
+		time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), "OWNERSHIP", "TABLE", "test-table", "ROLE", "test-role", true, "SYSADMIN",
+	)
+	mock.ExpectQuery(`^SHOW GRANTS ON TABLE "test-db"."PUBLIC"."test-table"$`).WillReturnRows(rows)
+}
+
+func TestOwnershipRead(t *testing.T) {
+	r := require.New(t)
+
+	d := ownership(t, "TABLE|test-db|PUBLIC|test-table", map[string]interface{}{
+		"object_type":   "TABLE",
+		"database_name": "test-db",
+		"schema_name":   "PUBLIC",
+		"object_name":   "test-table",
+		"role_name":     "old-role",
+	})
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		expectReadOwnership(mock)
+		err := resources.ReadOwnership(d, client)
+		r.NoError(err)
+		r.Equal("test-role", d.Get("role_name").(string))
+	})
+}
+
+func TestOwnershipDelete(t *testing.T) {
+	r := require.New(t)
+
+	d := ownership(t, "DATABASE|||test-db", map[string]interface{}{
+		"object_type": "DATABASE",
+		"object_name": "test-db",
+		"role_name":   "test-role",
+	})
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`^GRANT OWNERSHIP ON DATABASE "test-db" TO ROLE "SYSADMIN" COPY CURRENT GRANTS$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		err := resources.DeleteOwnership(d, client)
+		r.NoError(err)
+		r.Equal("", d.Id())
+	})
+}
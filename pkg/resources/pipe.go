@@ -2,7 +2,6 @@ package resources
 
 import (
 	"bytes"
-	"database/sql"
 	"encoding/csv"
 	"fmt"
 	"strings"
@@ -18,22 +17,25 @@ const (
 
 var pipeSchema = map[string]*schema.Schema{
 	"name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		ForceNew:    true,
-		Description: "Specifies the identifier for the pipe; must be unique for the database and schema in which the pipe is created.",
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		Description:      "Specifies the identifier for the pipe; must be unique for the database and schema in which the pipe is created.",
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"schema": {
-		Type:        schema.TypeString,
-		Required:    true,
-		ForceNew:    true,
-		Description: "The schema in which to create the pipe.",
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		Description:      "The schema in which to create the pipe.",
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"database": {
-		Type:        schema.TypeString,
-		Required:    true,
-		ForceNew:    true,
-		Description: "The database in which to create the pipe.",
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		Description:      "The database in which to create the pipe.",
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"comment": {
 		Type:        schema.TypeString,
@@ -45,7 +47,7 @@ var pipeSchema = map[string]*schema.Schema{
 		Required:         true,
 		ForceNew:         true,
 		Description:      "Specifies the copy statement for the pipe.",
-		DiffSuppressFunc: pipeCopyStatementDiffSuppress,
+		DiffSuppressFunc: DiffSuppressStatement,
 	},
 	"auto_ingest": {
 		Type:        schema.TypeBool,
@@ -86,18 +88,6 @@ func Pipe() *schema.Resource {
 	}
 }
 
-func pipeCopyStatementDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
-	// standardise line endings
-	old = strings.ReplaceAll(old, "\r\n", "\n")
-	new = strings.ReplaceAll(new, "\r\n", "\n")
-
-	// trim off any trailing line endings
-	if strings.TrimRight(old, ";\r\n") == strings.TrimRight(new, ";\r\n") {
-		return true
-	}
-	return false
-}
-
 type pipeID struct {
 	DatabaseName string
 	SchemaName   string
@@ -146,7 +136,10 @@ func pipeIDFromString(stringID string) (*pipeID, error) {
 
 // CreatePipe implements schema.CreateFunc
 func CreatePipe(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	database := data.Get("database").(string)
 	schema := data.Get("schema").(string)
 	name := data.Get("name").(string)
@@ -172,7 +165,7 @@ func CreatePipe(data *schema.ResourceData, meta interface{}) error {
 
 	q := builder.Create()
 
-	err := snowflake.Exec(db, q)
+	err := client.ExecContext(ctx, q)
 	if err != nil {
 		return errors.Wrapf(err, "error creating pipe %v", name)
 	}
@@ -193,7 +186,10 @@ func CreatePipe(data *schema.ResourceData, meta interface{}) error {
 
 // ReadPipe implements schema.ReadFunc
 func ReadPipe(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
+
 	pipeID, err := pipeIDFromString(data.Id())
 	if err != nil {
 		return err
@@ -203,9 +199,7 @@ func ReadPipe(data *schema.ResourceData, meta interface{}) error {
 	schema := pipeID.SchemaName
 	name := pipeID.PipeName
 
-	sq := snowflake.Pipe(name, dbName, schema).Show()
-	row := snowflake.QueryRow(db, sq)
-	pipe, err := snowflake.ScanPipe(row)
+	pipe, err := snowflake.ReadPipe(ctx, client, dbName, schema, name)
 	if err != nil {
 		return err
 	}
@@ -260,6 +254,9 @@ func ReadPipe(data *schema.ResourceData, meta interface{}) error {
 
 // UpdatePipe implements schema.UpdateFunc
 func UpdatePipe(data *schema.ResourceData, meta interface{}) error {
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutUpdate)
+	defer cancel()
+
 	// https://www.terraform.io/docs/extend/writing-custom-providers.html#error-handling-amp-partial-state
 	data.Partial(true)
 
@@ -274,11 +271,11 @@ func UpdatePipe(data *schema.ResourceData, meta interface{}) error {
 
 	builder := snowflake.Pipe(pipe, dbName, schema)
 
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
 	if data.HasChange("comment") {
 		_, comment := data.GetChange("comment")
 		q := builder.ChangeComment(comment.(string))
-		err := snowflake.Exec(db, q)
+		err := client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error updating pipe comment on %v", data.Id())
 		}
@@ -291,7 +288,10 @@ func UpdatePipe(data *schema.ResourceData, meta interface{}) error {
 
 // DeletePipe implements schema.DeleteFunc
 func DeletePipe(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutDelete)
+	defer cancel()
+
 	pipeID, err := pipeIDFromString(data.Id())
 	if err != nil {
 		return err
@@ -303,7 +303,7 @@ func DeletePipe(data *schema.ResourceData, meta interface{}) error {
 
 	q := snowflake.Pipe(pipe, dbName, schema).Drop()
 
-	err = snowflake.Exec(db, q)
+	err = client.ExecContext(ctx, q)
 	if err != nil {
 		return errors.Wrapf(err, "error deleting pipe %v", data.Id())
 	}
@@ -315,7 +315,9 @@ func DeletePipe(data *schema.ResourceData, meta interface{}) error {
 
 // PipeExists implements schema.ExistsFunc
 func PipeExists(data *schema.ResourceData, meta interface{}) (bool, error) {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
 	pipeID, err := pipeIDFromString(data.Id())
 	if err != nil {
 		return false, err
@@ -326,7 +328,7 @@ func PipeExists(data *schema.ResourceData, meta interface{}) (bool, error) {
 	pipe := pipeID.PipeName
 
 	q := snowflake.Pipe(pipe, dbName, schema).Show()
-	rows, err := db.Query(q)
+	rows, err := client.QueryContext(ctx, q)
 	if err != nil {
 		return false, err
 	}
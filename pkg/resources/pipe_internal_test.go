@@ -162,7 +162,7 @@ func TestPipeCopyStatementDiffSuppress(t *testing.T) {
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
 			r := require.New(t)
-			r.Equal(testCase.expected, pipeCopyStatementDiffSuppress("", testCase.declared, testCase.showPipes, nil))
+			r.Equal(testCase.expected, DiffSuppressStatement("", testCase.declared, testCase.showPipes, nil))
 		})
 	}
 }
@@ -0,0 +1,250 @@
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+)
+
+// grantObjectType is the kind of object a grant resource grants privileges on
+type grantObjectType string
+
+const (
+	grantObjectAccount         grantObjectType = "ACCOUNT"
+	grantObjectDatabase        grantObjectType = "DATABASE"
+	grantObjectIntegration     grantObjectType = "INTEGRATION"
+	grantObjectResourceMonitor grantObjectType = "RESOURCE MONITOR"
+	grantObjectSchema          grantObjectType = "SCHEMA"
+	grantObjectStage           grantObjectType = "STAGE"
+	grantObjectTable           grantObjectType = "TABLE"
+	grantObjectView            grantObjectType = "VIEW"
+	grantObjectWarehouse       grantObjectType = "WAREHOUSE"
+)
+
+// privilegeRule describes how a single privilege on an object type may be
+// granted: to which kinds of grantee, whether on future objects and whether
+// WITH GRANT OPTION.
+type privilegeRule struct {
+	grantees    []snowflake.GranteeType
+	future      bool
+	grantOption bool
+}
+
+var (
+	toRoles            = []snowflake.GranteeType{snowflake.RoleGrantee}
+	toDBRoles          = []snowflake.GranteeType{snowflake.RoleGrantee, snowflake.DatabaseRoleGrantee}
+	toDBRolesAndShares = []snowflake.GranteeType{snowflake.RoleGrantee, snowflake.DatabaseRoleGrantee, snowflake.ShareGrantee}
+)
+
+// privilegeMatrix is the single source of truth for which privileges each
+// grant resource accepts and how they may be combined. It backs the privilege
+// validation of every grant resource, both in the schema and at plan time,
+// and the privilege tables in the generated docs.
+var privilegeMatrix = map[grantObjectType]map[privilege]privilegeRule{
+	grantObjectAccount: {
+		privilegeCreateRole:        {grantees: toRoles, grantOption: true},
+		privilegeCreateUser:        {grantees: toRoles, grantOption: true},
+		privilegeCreateWarehouse:   {grantees: toRoles, grantOption: true},
+		privilegeCreateDatabase:    {grantees: toRoles, grantOption: true},
+		privilegeCreateIntegration: {grantees: toRoles, grantOption: true},
+		privilegeManageGrants:      {grantees: toRoles, grantOption: true},
+		privilegeMonitorUsage:      {grantees: toRoles, grantOption: true},
+		privilegeMonitorExecution:  {grantees: toRoles, grantOption: true},
+		privilegeExecuteTask:       {grantees: toRoles, grantOption: true},
+	},
+	grantObjectDatabase: {
+		privilegeAll:                {grantees: toDBRoles, grantOption: true},
+		privilegeCreateSchema:       {grantees: toDBRoles, grantOption: true},
+		privilegeImportedPrivileges: {grantees: toRoles, grantOption: true},
+		privilegeModify:             {grantees: toDBRoles, grantOption: true},
+		privilegeMonitor:            {grantees: toDBRoles, grantOption: true},
+		privilegeOwnership:          {grantees: toRoles},
+		privilegeReferenceUsage:     {grantees: toDBRolesAndShares, grantOption: true},
+		privilegeUsage:              {grantees: toDBRolesAndShares, grantOption: true},
+	},
+	grantObjectIntegration: {
+		privilegeAll:       {grantees: toRoles, grantOption: true},
+		privilegeUsage:     {grantees: toRoles, grantOption: true},
+		privilegeOwnership: {grantees: toRoles},
+	},
+	grantObjectResourceMonitor: {
+		privilegeAll:     {grantees: toRoles, grantOption: true},
+		privilegeModify:  {grantees: toRoles, grantOption: true},
+		privilegeMonitor: {grantees: toRoles, grantOption: true},
+	},
+	grantObjectSchema: {
+		privilegeAll:                    {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeModify:                 {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeMonitor:                {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeOwnership:              {grantees: toRoles},
+		privilegeUsage:                  {grantees: toDBRolesAndShares, future: true, grantOption: true},
+		privilegeCreateTable:            {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeCreateView:             {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeCreateFileFormat:       {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeCreateStage:            {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeCreatePipe:             {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeCreateStream:           {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeCreateTask:             {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeCreateSequence:         {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeCreateFunction:         {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeCreateProcedure:        {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeCreateExternalTable:    {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeCreateMaterializedView: {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeCreateTemporaryTable:   {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeCreateMaskingPolicy:    {grantees: toDBRoles, future: true, grantOption: true},
+	},
+	grantObjectStage: {
+		privilegeAll:       {grantees: toDBRoles, grantOption: true},
+		privilegeOwnership: {grantees: toRoles},
+		privilegeUsage:     {grantees: toDBRoles, grantOption: true},
+		// These privileges are only valid for internal stages
+		privilegeRead:  {grantees: toDBRoles, grantOption: true},
+		privilegeWrite: {grantees: toDBRoles, grantOption: true},
+	},
+	grantObjectTable: {
+		privilegeSelect:     {grantees: toDBRolesAndShares, future: true, grantOption: true},
+		privilegeInsert:     {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeUpdate:     {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeDelete:     {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeTruncate:   {grantees: toDBRoles, future: true, grantOption: true},
+		privilegeReferences: {grantees: toDBRoles, future: true, grantOption: true},
+	},
+	grantObjectView: {
+		privilegeSelect: {grantees: toDBRolesAndShares, future: true, grantOption: true},
+	},
+	grantObjectWarehouse: {
+		privilegeAll:       {grantees: toRoles, grantOption: true},
+		privilegeModify:    {grantees: toRoles, grantOption: true},
+		privilegeMonitor:   {grantees: toRoles, grantOption: true},
+		privilegeOperate:   {grantees: toRoles, grantOption: true},
+		privilegeOwnership: {grantees: toRoles},
+		privilegeUsage:     {grantees: toRoles, grantOption: true},
+	},
+}
+
+// privileges returns the set of privileges that can be granted on the object type
+func (t grantObjectType) privileges() privilegeSet {
+	ps := privilegeSet{}
+	for p := range privilegeMatrix[t] {
+		ps[p] = struct{}{}
+	}
+	return ps
+}
+
+func granteeAttribute(t snowflake.GranteeType) string {
+	for _, ga := range granteeAttributes {
+		if ga.Type == t {
+			return ga.Attribute
+		}
+	}
+	return strings.ToLower(string(t))
+}
+
+// validateGrantPrivilege checks a combination of privilege, grantee types,
+// future and grant option against the privilege matrix.
+func validateGrantPrivilege(t grantObjectType, priv string, grantees []snowflake.GranteeType, onFuture, grantOption bool) error {
+	rule, ok := privilegeMatrix[t][privilege(strings.ToUpper(priv))]
+	if !ok {
+		return fmt.Errorf("privilege %v cannot be granted on %v", priv, t)
+	}
+	if onFuture && !rule.future {
+		return fmt.Errorf("privilege %v cannot be granted on future %v objects", priv, t)
+	}
+	if grantOption && !rule.grantOption {
+		return fmt.Errorf("privilege %v on %v cannot be granted with_grant_option", priv, t)
+	}
+
+	for _, g := range grantees {
+		if g == snowflake.ShareGrantee && grantOption {
+			return fmt.Errorf("with_grant_option cannot be used when granting to shares")
+		}
+		if g == snowflake.ShareGrantee && onFuture {
+			return fmt.Errorf("privileges on future objects cannot be granted to shares")
+		}
+		allowed := false
+		for _, rg := range rule.grantees {
+			if rg == g {
+				allowed = true
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("privilege %v on %v cannot be granted to %v", priv, t, granteeAttribute(g))
+		}
+	}
+	return nil
+}
+
+// customizeDiffGrant returns a CustomizeDiffFunc that validates the planned
+// grant against the privilege matrix, so that invalid combinations fail at
+// plan time rather than at the Snowflake API. The grant is validated at apply
+// time instead if any attribute it depends on is interpolated and not known
+// yet.
+func customizeDiffGrant(t grantObjectType, s map[string]*schema.Schema) schema.CustomizeDiffFunc {
+	attributes := []string{"privilege", "with_grant_option", "on_future"}
+	for _, ga := range granteeAttributes {
+		attributes = append(attributes, ga.Attribute)
+	}
+
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		for _, attr := range attributes {
+			if _, ok := s[attr]; ok && !d.NewValueKnown(attr) {
+				return nil
+			}
+		}
+
+		priv := d.Get("privilege").(string)
+		grantOption := d.Get("with_grant_option").(bool)
+
+		onFuture := false
+		if _, ok := s["on_future"]; ok {
+			onFuture = d.Get("on_future").(bool)
+		}
+
+		grantees := []snowflake.GranteeType{}
+		for _, ga := range granteeAttributes {
+			if _, ok := s[ga.Attribute]; !ok {
+				continue
+			}
+			if v, ok := d.GetOk(ga.Attribute); ok && v.(*schema.Set).Len() > 0 {
+				grantees = append(grantees, ga.Type)
+			}
+		}
+
+		return validateGrantPrivilege(t, priv, grantees, onFuture, grantOption)
+	}
+}
+
+// PrivilegeRule describes how a privilege on an object type may be granted.
+type PrivilegeRule struct {
+	Privilege   string
+	Grantees    []string
+	OnFuture    bool
+	GrantOption bool
+}
+
+// PrivilegeRules returns the privilege matrix for grants on objectType (e.g.
+// "TABLE" or "RESOURCE MONITOR"), sorted by privilege. It is used to generate
+// the docs of the grant resources.
+func PrivilegeRules(objectType string) []PrivilegeRule {
+	rules := []PrivilegeRule{}
+	for p, rule := range privilegeMatrix[grantObjectType(objectType)] {
+		grantees := []string{}
+		for _, g := range rule.grantees {
+			grantees = append(grantees, granteeAttribute(g))
+		}
+		rules = append(rules, PrivilegeRule{
+			Privilege:   p.string(),
+			Grantees:    grantees,
+			OnFuture:    rule.future,
+			GrantOption: rule.grantOption,
+		})
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Privilege < rules[j].Privilege
+	})
+	return rules
+}
@@ -0,0 +1,82 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateGrantPrivilege(t *testing.T) {
+	roles := []snowflake.GranteeType{snowflake.RoleGrantee}
+	shares := []snowflake.GranteeType{snowflake.RoleGrantee, snowflake.ShareGrantee}
+
+	cases := []struct {
+		name        string
+		objectType  grantObjectType
+		privilege   string
+		grantees    []snowflake.GranteeType
+		onFuture    bool
+		grantOption bool
+		err         string
+	}{
+		{"select on table", grantObjectTable, "SELECT", shares, false, false, ""},
+		{"lower case privilege", grantObjectTable, "select", roles, true, true, ""},
+		{"unknown privilege", grantObjectView, "INSERT", roles, false, false, "privilege INSERT cannot be granted on VIEW"},
+		{"grant option to share", grantObjectTable, "SELECT", shares, false, true, "with_grant_option cannot be used when granting to shares"},
+		{"future to share", grantObjectSchema, "USAGE", shares, true, false, "privileges on future objects cannot be granted to shares"},
+		{"future ownership", grantObjectSchema, "OWNERSHIP", roles, true, false, "privilege OWNERSHIP cannot be granted on future SCHEMA objects"},
+		{"ownership with grant option", grantObjectWarehouse, "OWNERSHIP", roles, false, true, "privilege OWNERSHIP on WAREHOUSE cannot be granted with_grant_option"},
+		{"insert to share", grantObjectTable, "INSERT", shares, false, false, "privilege INSERT on TABLE cannot be granted to shares"},
+		{"database role on warehouse", grantObjectWarehouse, "USAGE", []snowflake.GranteeType{snowflake.DatabaseRoleGrantee}, false, false, "privilege USAGE on WAREHOUSE cannot be granted to database_roles"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+			err := validateGrantPrivilege(tc.objectType, tc.privilege, tc.grantees, tc.onFuture, tc.grantOption)
+			if tc.err == "" {
+				r.NoError(err)
+			} else {
+				r.EqualError(err, tc.err)
+			}
+		})
+	}
+}
+
+func Test_customizeDiffGrantUnknown(t *testing.T) {
+	// the value Terraform passes for attributes that are not known yet
+	const unknown = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+	cases := []struct {
+		name string
+		cfg  map[string]interface{}
+	}{
+		{"privilege", map[string]interface{}{"privilege": unknown, "roles": []interface{}{"role"}}},
+		{"shares", map[string]interface{}{"privilege": "USAGE", "on_future": true, "shares": unknown}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+			cfg := map[string]interface{}{"database_name": "db"}
+			for k, v := range tc.cfg {
+				cfg[k] = v
+			}
+			_, err := SchemaGrant().Diff(nil, terraform.NewResourceConfigRaw(cfg), nil)
+			r.NoError(err)
+		})
+	}
+}
+
+func TestPrivilegeRules(t *testing.T) {
+	r := require.New(t)
+
+	rules := PrivilegeRules("VIEW")
+	r.Equal([]PrivilegeRule{
+		{Privilege: "SELECT", Grantees: []string{"roles", "database_roles", "shares"}, OnFuture: true, GrantOption: true},
+	}, rules)
+
+	r.Len(PrivilegeRules("RESOURCE MONITOR"), 3)
+	r.Empty(PrivilegeRules("UNKNOWN"))
+}
@@ -0,0 +1,302 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// PropertyType is the type of the value of a Property. It decides both how
+// the value is taken from the configuration and how it is written in SQL.
+type PropertyType int
+
+const (
+	// StringProperty is a TypeString attribute written as a string literal
+	StringProperty PropertyType = iota
+	// IdentifierProperty is a TypeString attribute that names an object,
+	// written as a quoted identifier, e.g. a warehouse's resource monitor
+	IdentifierProperty
+	// EnumProperty is a TypeString attribute that takes one of a few
+	// keywords, written uppercased as a string literal
+	EnumProperty
+	// BoolProperty is a TypeBool attribute
+	BoolProperty
+	// IntProperty is a TypeInt attribute
+	IntProperty
+	// FloatProperty is a TypeFloat attribute
+	FloatProperty
+	// StringListProperty is a TypeList or TypeSet attribute of strings,
+	// written as a list of string literals
+	StringListProperty
+)
+
+// UnsetBehavior decides what an update does with a property that was removed
+// from the configuration
+type UnsetBehavior int
+
+const (
+	// UnsetDefault unsets strings, identifiers, enums and lists, so that they
+	// revert to their default rather than becoming empty, and sets booleans
+	// and numbers to their zero value
+	UnsetDefault UnsetBehavior = iota
+	// UnsetAlways unsets the property whatever its type. The attribute of an
+	// IntProperty that is unset always has the Default intUnset, so that an
+	// explicit 0 is set rather than unset.
+	UnsetAlways
+	// UnsetNever leaves the property as it is in Snowflake
+	UnsetNever
+)
+
+// intUnset is the Default of the attributes of the IntProperty properties
+// that are unset always. It stands for a property that is not configured, as
+// the zero value is a valid setting.
+const intUnset = -1
+
+// Property maps an attribute of a resource to a property of the Snowflake
+// object
+type Property struct {
+	// Attribute is the name of the attribute in the resource's schema
+	Attribute string
+	// Parameter is the name of the property in SQL, Attribute if empty
+	Parameter string
+	Type      PropertyType
+	// Column is the column of the SHOW output that the property is read back
+	// from. Properties without one are not read back.
+	Column string
+	// ObjectParameter properties are parameters of the object, read back from
+	// SHOW PARAMETERS rather than a column. Only a value set on the object
+	// itself is read; an inherited one reads as the zero value, or intUnset,
+	// so that changes to the account's defaults are not taken for drift.
+	ObjectParameter bool
+	// CreateOnly properties can only be set by CREATE, so they are not
+	// altered on update
+	CreateOnly bool
+	Unset      UnsetBehavior
+	// Normalize, if set, is applied to the value of a string before it is
+	// written. The attribute's StateFunc does not suffice, as the resource
+	// data returns configured values as written during an apply.
+	Normalize func(string) string
+}
+
+// Properties is the registry of the properties of a resource, which drives
+// CreateResource, ReadResource and UpdateResource
+type Properties []Property
+
+func (p Property) parameter() string {
+	if p.Parameter != "" {
+		return p.Parameter
+	}
+	return p.Attribute
+}
+
+// get returns the value of the property's attribute and whether it is
+// configured. Like GetOk, the zero value counts as not configured, except for
+// an IntProperty that is unset always: it is not configured when it has the
+// value intUnset, or when the block it is an attribute of is left out.
+func (p Property) get(data *schema.ResourceData) (interface{}, bool) {
+	if !p.distinguishesZero() {
+		return data.GetOk(p.Attribute)
+	}
+
+	if i := strings.Index(p.Attribute, ".0."); i >= 0 {
+		if block, ok := data.GetOk(p.Attribute[:i]); !ok || len(block.([]interface{})) == 0 {
+			return intUnset, false
+		}
+	}
+	v := data.Get(p.Attribute)
+	return v, v.(int) != intUnset
+}
+
+// distinguishesZero reports whether the property's attribute tells an explicit
+// zero value from one that is not configured
+func (p Property) distinguishesZero() bool {
+	return p.Type == IntProperty && p.Unset == UnsetAlways
+}
+
+// set sets the property to v, the value of its attribute, in b
+func (p Property) set(b snowflake.SettingBuilder, v interface{}) {
+	if s, ok := v.(string); ok && p.Normalize != nil {
+		v = p.Normalize(s)
+	}
+	switch p.Type {
+	case StringProperty:
+		b.SetString(p.parameter(), v.(string))
+	case IdentifierProperty:
+		b.SetIdentifier(p.parameter(), v.(string))
+	case EnumProperty:
+		b.SetString(p.parameter(), strings.ToUpper(v.(string)))
+	case BoolProperty:
+		b.SetBool(p.parameter(), v.(bool))
+	case IntProperty:
+		b.SetInt(p.parameter(), v.(int))
+	case FloatProperty:
+		b.SetFloat(p.parameter(), v.(float64))
+	case StringListProperty:
+		if set, ok := v.(*schema.Set); ok {
+			v = set.List()
+		}
+		b.SetStringList(p.parameter(), expandStringList(v.([]interface{})))
+	}
+}
+
+// unset applies the property's UnsetBehavior in b, given v, the zero value
+// of its attribute
+func (p Property) unset(b *snowflake.AlterPropertiesBuilder, v interface{}) {
+	switch {
+	case p.Unset == UnsetNever:
+	case p.Unset == UnsetAlways:
+		b.Unset(p.parameter())
+	case p.Type == BoolProperty || p.Type == IntProperty || p.Type == FloatProperty:
+		p.set(b, v)
+	default:
+		b.Unset(p.parameter())
+	}
+}
+
+// value converts v, a value of the property's column, to the type of its
+// attribute. The driver returns most columns of SHOW output as strings, so
+// they are parsed; NULL becomes the zero value.
+func (p Property) value(v interface{}) (interface{}, error) {
+	s := ""
+	switch v := v.(type) {
+	case nil:
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		s = fmt.Sprint(v)
+	}
+
+	switch p.Type {
+	case BoolProperty:
+		if s == "" {
+			return false, nil
+		}
+		return strconv.ParseBool(s)
+	case IntProperty:
+		if s == "" {
+			return 0, nil
+		}
+		return strconv.Atoi(s)
+	case FloatProperty:
+		if s == "" {
+			return 0.0, nil
+		}
+		return strconv.ParseFloat(s, 64)
+	case StringListProperty:
+		list := []string{}
+		for _, e := range strings.Split(s, ",") {
+			if e = strings.TrimSpace(e); e != "" {
+				list = append(list, e)
+			}
+		}
+		return list, nil
+	}
+	return s, nil
+}
+
+// read sets the attributes of the properties that have a column from row, a
+// row of SHOW output. Columns missing from row are skipped.
+func (props Properties) read(data *schema.ResourceData, row map[string]interface{}) error {
+	for _, p := range props {
+		if p.Column == "" || p.ObjectParameter {
+			continue
+		}
+		v, ok := row[p.Column]
+		if !ok {
+			continue
+		}
+		value, err := p.value(v)
+		if err != nil {
+			return fmt.Errorf("unable to read %v from column %v: %v", p.Attribute, p.Column, err)
+		}
+		err = data.Set(p.Attribute, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// without returns the properties but those of the attributes attrs, e.g. for
+// attributes an update handles on its own
+func (props Properties) without(attrs ...string) Properties {
+	rest := Properties{}
+	for _, p := range props {
+		skip := false
+		for _, a := range attrs {
+			skip = skip || p.Attribute == a
+		}
+		if !skip {
+			rest = append(rest, p)
+		}
+	}
+	return rest
+}
+
+func (props Properties) hasObjectParameters() bool {
+	for _, p := range props {
+		if p.ObjectParameter {
+			return true
+		}
+	}
+	return false
+}
+
+// readObjectParameters sets the attributes of the object parameters from
+// values, the parameters set on the object by key. A parameter that is not set
+// reads as the zero value, or intUnset.
+func (props Properties) readObjectParameters(data *schema.ResourceData, values map[string]string) error {
+	for _, p := range props {
+		if !p.ObjectParameter {
+			continue
+		}
+		value, _, err := p.parameterValue(values)
+		if err != nil {
+			return err
+		}
+		err = data.Set(p.Attribute, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parameterValue returns the value of the object parameter in values, the
+// parameters set on the object by key, and whether it is set at all
+func (p Property) parameterValue(values map[string]string) (interface{}, bool, error) {
+	key := strings.ToUpper(p.parameter())
+	s, ok := values[key]
+	if !ok && p.distinguishesZero() {
+		return intUnset, false, nil
+	}
+	var v interface{}
+	if ok {
+		v = s
+	}
+	value, err := p.value(v)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to read parameter %v: %v", key, err)
+	}
+	return value, ok, nil
+}
+
+// readParameters runs query, a SHOW PARAMETERS, and returns the values of the
+// parameters set on the object of type t itself by key
+func readParameters(ctx context.Context, client *snowflake.Client, query string, t snowflake.EntityType) (map[string]string, error) {
+	rows, err := client.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	params, err := snowflake.ScanParameters(rows)
+	if err != nil {
+		return nil, err
+	}
+	return snowflake.ParametersSetOn(params, t), nil
+}
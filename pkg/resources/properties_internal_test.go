@@ -0,0 +1,160 @@
+package resources
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPropertiesMatchSchemas checks that each registry only names attributes
+// of its resource's schema, of a type that fits the property
+func TestPropertiesMatchSchemas(t *testing.T) {
+	registries := map[string]struct {
+		props  Properties
+		schema map[string]*schema.Schema
+	}{
+		"database":        {databaseProperties, databaseSchema},
+		"managed_account": {managedAccountProperties, managedAccountSchema},
+		"role":            {roleProperties, roleSchema},
+		"share":           {shareProperties, shareSchema},
+		"user":            {append(userProperties, userParameterProperties...), userSchema},
+		"warehouse":       {warehouseProperties, warehouseSchema},
+	}
+	types := map[PropertyType][]schema.ValueType{
+		StringProperty:     {schema.TypeString},
+		IdentifierProperty: {schema.TypeString},
+		EnumProperty:       {schema.TypeString},
+		BoolProperty:       {schema.TypeBool},
+		IntProperty:        {schema.TypeInt},
+		FloatProperty:      {schema.TypeFloat},
+		StringListProperty: {schema.TypeList, schema.TypeSet},
+	}
+
+	for name, reg := range registries {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			for _, p := range reg.props {
+				s := attributeSchema(reg.schema, p.Attribute)
+				r.NotNil(s, "%v is not in the schema", p.Attribute)
+				r.Contains(types[p.Type], s.Type, "%v has the wrong type", p.Attribute)
+				if p.distinguishesZero() {
+					r.Equal(intUnset, s.Default, "%v is unset always, so it needs the Default intUnset", p.Attribute)
+				}
+			}
+		})
+	}
+}
+
+func TestPropertySet(t *testing.T) {
+	r := require.New(t)
+
+	props := Properties{
+		{Attribute: "comment", Type: StringProperty},
+		{Attribute: "resource_monitor", Type: IdentifierProperty},
+		{Attribute: "scaling_policy", Type: EnumProperty},
+		{Attribute: "auto_resume", Type: BoolProperty},
+		{Attribute: "auto_suspend", Type: IntProperty},
+		{Attribute: "quota", Parameter: "credit_quota", Type: FloatProperty},
+		{Attribute: "accounts", Type: StringListProperty},
+		{Attribute: "locations", Type: StringListProperty},
+	}
+	values := []interface{}{
+		"c", "rm", "economy", true, 60, 1.5,
+		[]interface{}{"a", "b"},
+		schema.NewSet(schema.HashString, []interface{}{"s"}),
+	}
+
+	b := snowflake.Warehouse("w").Create()
+	for i, p := range props {
+		p.set(b, values[i])
+	}
+	r.Equal(`CREATE WAREHOUSE "w" COMMENT='c' SCALING_POLICY='ECONOMY' RESOURCE_MONITOR="rm" ACCOUNTS=('a', 'b') LOCATIONS=('s') AUTO_RESUME=true AUTO_SUSPEND=60 CREDIT_QUOTA=1.50`, b.Statement())
+}
+
+func TestPropertyUnset(t *testing.T) {
+	r := require.New(t)
+
+	b := snowflake.Warehouse("w").Alter()
+	Property{Attribute: "comment", Type: StringProperty}.unset(b, "")
+	Property{Attribute: "accounts", Type: StringListProperty}.unset(b, []interface{}{})
+	Property{Attribute: "auto_resume", Type: BoolProperty}.unset(b, false)
+	Property{Attribute: "auto_suspend", Type: IntProperty, Unset: UnsetAlways}.unset(b, 0)
+	Property{Attribute: "resource_monitor", Type: IdentifierProperty, Unset: UnsetNever}.unset(b, "")
+	r.Equal([]string{
+		`ALTER WAREHOUSE "w" SET AUTO_RESUME=false`,
+		`ALTER WAREHOUSE "w" UNSET ACCOUNTS, AUTO_SUSPEND, COMMENT`,
+	}, b.Statements())
+}
+
+func TestPropertyValue(t *testing.T) {
+	cases := []struct {
+		typ      PropertyType
+		in       interface{}
+		expected interface{}
+	}{
+		{StringProperty, "s", "s"},
+		{StringProperty, []byte("s"), "s"},
+		{StringProperty, nil, ""},
+		{EnumProperty, "STANDARD", "STANDARD"},
+		{BoolProperty, "true", true},
+		{BoolProperty, false, false},
+		{BoolProperty, nil, false},
+		{IntProperty, "60", 60},
+		{IntProperty, int64(2), 2},
+		{IntProperty, nil, 0},
+		{FloatProperty, "1.5", 1.5},
+		{StringListProperty, "a, b", []string{"a", "b"}},
+		{StringListProperty, "", []string{}},
+	}
+	for _, tc := range cases {
+		r := require.New(t)
+		v, err := Property{Type: tc.typ}.value(tc.in)
+		r.NoError(err)
+		r.Equal(tc.expected, v)
+	}
+
+	_, err := Property{Type: IntProperty}.value("many")
+	require.Error(t, err)
+}
+
+// attributeSchema returns the schema of the attribute at path, e.g.
+// parameters.0.timezone, or nil
+func attributeSchema(s map[string]*schema.Schema, path string) *schema.Schema {
+	parts := strings.Split(path, ".")
+	attr, ok := s[parts[0]]
+	if !ok {
+		return nil
+	}
+	if len(parts) == 1 {
+		return attr
+	}
+	elem, ok := attr.Elem.(*schema.Resource)
+	if !ok || len(parts) < 3 {
+		return nil
+	}
+	return attributeSchema(elem.Schema, strings.Join(parts[2:], "."))
+}
+
+// TestObjectParametersInCatalog checks that the object parameters of the
+// registries can be set on their objects
+func TestObjectParametersInCatalog(t *testing.T) {
+	r := require.New(t)
+
+	registries := map[snowflake.EntityType]Properties{
+		snowflake.DatabaseType:  databaseProperties,
+		snowflake.UserType:      userParameterProperties,
+		snowflake.WarehouseType: warehouseProperties,
+	}
+	for level, props := range registries {
+		for _, p := range props {
+			if !p.ObjectParameter {
+				continue
+			}
+			_, err := snowflake.LookupParameter(p.parameter(), level)
+			r.NoError(err)
+		}
+	}
+}
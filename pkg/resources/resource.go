@@ -1,43 +1,37 @@
 package resources
 
 import (
-	"database/sql"
+	"fmt"
+	"log"
 
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/pkg/errors"
 )
 
+// CreateResource returns a schema.CreateFunc that creates an object of type
+// t with the configured properties of props
 func CreateResource(
 	t string,
-	properties []string,
-	s map[string]*schema.Schema,
+	props Properties,
 	builder func(string) *snowflake.Builder,
 	read func(*schema.ResourceData, interface{}) error,
 ) func(*schema.ResourceData, interface{}) error {
 	return func(data *schema.ResourceData, meta interface{}) error {
-		db := meta.(*sql.DB)
+		client := meta.(*snowflake.Client)
+		ctx, cancel := OperationContext(data, meta, schema.TimeoutCreate)
+		defer cancel()
+
 		name := data.Get("name").(string)
 
 		qb := builder(name).Create()
 
-		for _, field := range properties {
-			val, ok := data.GetOk(field)
-			if ok {
-				switch s[field].Type {
-				case schema.TypeString:
-					valStr := val.(string)
-					qb.SetString(field, valStr)
-				case schema.TypeBool:
-					valBool := val.(bool)
-					qb.SetBool(field, valBool)
-				case schema.TypeInt:
-					valInt := val.(int)
-					qb.SetInt(field, valInt)
-				}
+		for _, p := range props {
+			if val, ok := p.get(data); ok {
+				p.set(qb, val)
 			}
 		}
-		err := snowflake.Exec(db, qb.Statement())
+		err := client.ExecContext(ctx, qb.Statement())
 
 		if err != nil {
 			return errors.Wrapf(err, "error creating %s", t)
@@ -49,79 +43,157 @@ func CreateResource(
 	}
 }
 
+// ReadResource returns a schema.ReadFunc that reads the name and the
+// properties of props that have a column from the SHOW output of an object of
+// type t, and its object parameters from SHOW PARAMETERS. All objects of the
+// type are shown at once, and the result is cached for the Reads of the
+// others. As Snowflake caps the output of SHOW at 10,000 rows, an object that
+// is not shown is looked up by name before it is removed from the state.
+func ReadResource(
+	t string,
+	props Properties,
+	builder func(string) *snowflake.Builder,
+) func(*schema.ResourceData, interface{}) error {
+	return func(data *schema.ResourceData, meta interface{}) error {
+		client := meta.(*snowflake.Client)
+		ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+		defer cancel()
+
+		id := data.Id()
+
+		rows, err := client.ShowRows(ctx, builder(id).ShowAll())
+		if err != nil {
+			return errors.Wrapf(err, "unable to read %s %v", t, id)
+		}
+		values := findRow(rows, id)
+		if values == nil {
+			rows, err = client.ShowRows(ctx, builder(id).Show())
+			if err != nil {
+				return errors.Wrapf(err, "unable to read %s %v", t, id)
+			}
+			values = findRow(rows, id)
+		}
+		if values == nil {
+			log.Printf("[WARN] %s %v not found, removing from state file", t, id)
+			data.SetId("")
+			return nil
+		}
+
+		name, err := Property{Attribute: "name"}.value(values["name"])
+		if err != nil {
+			return err
+		}
+		err = data.Set("name", name)
+		if err != nil {
+			return err
+		}
+		err = props.read(data, values)
+		if err != nil {
+			return err
+		}
+
+		if !props.hasObjectParameters() {
+			return nil
+		}
+		b := builder(id)
+		params, err := readParameters(ctx, client, b.ShowParameters(), b.Type())
+		if err != nil {
+			return errors.Wrapf(err, "unable to read the parameters of %s %v", t, id)
+		}
+		return props.readObjectParameters(data, params)
+	}
+}
+
+// findRow returns the row of SHOW output that shows the object name, or nil
+func findRow(rows []map[string]interface{}, name string) map[string]interface{} {
+	for _, row := range rows {
+		v, err := Property{Attribute: "name"}.value(row["name"])
+		if err == nil && v == name {
+			return row
+		}
+	}
+	return nil
+}
+
+// UpdateResource returns a schema.UpdateFunc that renames an object of type
+// t and alters the changed properties of props, except those that are
+// CreateOnly
 func UpdateResource(
 	t string,
-	properties []string,
-	s map[string]*schema.Schema,
+	props Properties,
 	builder func(string) *snowflake.Builder,
 	read func(*schema.ResourceData, interface{}) error,
 ) func(*schema.ResourceData, interface{}) error {
 	return func(data *schema.ResourceData, meta interface{}) error {
-		// https://www.terraform.io/docs/extend/writing-custom-providers.html#error-handling-amp-partial-state
-		data.Partial(true)
+		client := meta.(*snowflake.Client)
+		ctx, cancel := OperationContext(data, meta, schema.TimeoutUpdate)
+		defer cancel()
+
+		// The rename and the alter are applied as one plan, so a failed alter
+		// renames the object back instead of leaving a partial change.
+		plan := snowflake.NewPlan()
 
-		db := meta.(*sql.DB)
 		if data.HasChange("name") {
 			// I wish this could be done on one line.
 			oldNameI, newNameI := data.GetChange("name")
 			oldName := oldNameI.(string)
 			newName := newNameI.(string)
 
-			stmt := builder(oldName).Rename(newName)
-
-			err := snowflake.Exec(db, stmt)
-			if err != nil {
-				return errors.Wrapf(err, "error renaming %s %s to %s", t, oldName, newName)
-			}
-
-			data.SetId(newName)
-			data.SetPartial("name")
+			plan.Add(
+				fmt.Sprintf("renaming %s %s to %s", t, oldName, newName),
+				builder(oldName).Rename(newName),
+				builder(newName).Rename(oldName),
+			)
 		}
-		data.Partial(false)
 
-		changes := []string{}
+		changes := Properties{}
 
-		for _, prop := range properties {
-			if data.HasChange(prop) {
-				changes = append(changes, prop)
+		for _, p := range props {
+			if !p.CreateOnly && data.HasChange(p.Attribute) {
+				changes = append(changes, p)
 			}
 		}
 		if len(changes) > 0 {
 			name := data.Get("name").(string)
 			qb := builder(name).Alter()
 
-			for _, field := range changes {
-				val := data.Get(field)
-				switch s[field].Type {
-				case schema.TypeString:
-					valStr := val.(string)
-					qb.SetString(field, valStr)
-				case schema.TypeBool:
-					valBool := val.(bool)
-					qb.SetBool(field, valBool)
-				case schema.TypeInt:
-					valInt := val.(int)
-					qb.SetInt(field, valInt)
+			for _, p := range changes {
+				val, ok := p.get(data)
+				if !ok {
+					p.unset(qb, val)
+					continue
 				}
+				p.set(qb, val)
 			}
 
-			err := snowflake.Exec(db, qb.Statement())
-			if err != nil {
-				return errors.Wrapf(err, "error altering %s", t)
+			for _, stmt := range qb.Statements() {
+				plan.Add(fmt.Sprintf("altering %s", t), stmt, "")
 			}
 		}
+
+		err := plan.Apply(ctx, client)
+		if err != nil {
+			return err
+		}
+
+		if data.HasChange("name") {
+			data.SetId(data.Get("name").(string))
+		}
 		return read(data, meta)
 	}
 }
 
 func DeleteResource(t string, builder func(string) *snowflake.Builder) func(*schema.ResourceData, interface{}) error {
 	return func(data *schema.ResourceData, meta interface{}) error {
-		db := meta.(*sql.DB)
+		client := meta.(*snowflake.Client)
+		ctx, cancel := OperationContext(data, meta, schema.TimeoutDelete)
+		defer cancel()
+
 		name := data.Get("name").(string)
 
 		stmt := builder(name).Drop()
 
-		err := snowflake.Exec(db, stmt)
+		err := client.ExecContext(ctx, stmt)
 		if err != nil {
 			return errors.Wrapf(err, "error dropping %s %s", t, name)
 		}
@@ -16,10 +16,11 @@ var validFrequencies = []string{"MONTHLY", "DAILY", "WEEKLY", "YEARLY", "NEVER"}
 
 var resourceMonitorSchema = map[string]*schema.Schema{
 	"name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "Identifier for the resource monitor; must be unique for your account.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "Identifier for the resource monitor; must be unique for your account.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"credit_quota": {
 		Type:        schema.TypeFloat,
@@ -90,7 +91,10 @@ func ResourceMonitor() *schema.Resource {
 
 // CreateResourceMonitor implents schema.CreateFunc
 func CreateResourceMonitor(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	name := data.Get("name").(string)
 
 	cb := snowflake.ResourceMonitor(name).Create()
@@ -123,7 +127,7 @@ func CreateResourceMonitor(data *schema.ResourceData, meta interface{}) error {
 
 	stmt := cb.Statement()
 
-	err := snowflake.Exec(db, stmt)
+	err := client.ExecContext(ctx, stmt)
 	if err != nil {
 		return errors.Wrapf(err, "error creating resource monitor %v", name)
 	}
@@ -135,10 +139,13 @@ func CreateResourceMonitor(data *schema.ResourceData, meta interface{}) error {
 
 // ReadResourceMonitor implements schema.ReadFunc
 func ReadResourceMonitor(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
+
 	stmt := snowflake.ResourceMonitor(data.Id()).Show()
 
-	row := snowflake.QueryRow(db, stmt)
+	row := client.QueryRowContext(ctx, stmt)
 
 	rm, err := snowflake.ScanResourceMonitor(row)
 	if err != nil {
@@ -230,11 +237,13 @@ func extractTriggerInts(s sql.NullString) ([]int, error) {
 
 // DeleteResourceMonitor implements schema.DeleteFunc
 func DeleteResourceMonitor(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutDelete)
+	defer cancel()
 
 	stmt := snowflake.ResourceMonitor(data.Id()).Drop()
 
-	err := snowflake.Exec(db, stmt)
+	err := client.ExecContext(ctx, stmt)
 	if err != nil {
 		return errors.Wrapf(err, "error deleting resource monitor %v", data.Id())
 	}
@@ -245,11 +254,13 @@ func DeleteResourceMonitor(data *schema.ResourceData, meta interface{}) error {
 
 // ResourceMonitorExists implements schema.ExistsFunc
 func ResourceMonitorExists(data *schema.ResourceData, meta interface{}) (bool, error) {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
 
 	q := snowflake.ResourceMonitor(data.Id()).Show()
 
-	rows, err := db.Query(q)
+	rows, err := client.QueryContext(ctx, q)
 	if err != nil {
 		return false, err
 	}
@@ -6,18 +6,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
-var validResourceMonitorPrivileges = newPrivilegeSet(
-	privilegeAll,
-	privilegeModify,
-	privilegeMonitor,
-)
+var validResourceMonitorPrivileges = grantObjectResourceMonitor.privileges()
 
 var resourceMonitorGrantSchema = map[string]*schema.Schema{
 	"monitor_name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "Identifier for the resource monitor; must be unique for your account.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "Identifier for the resource monitor; must be unique for your account.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"privilege": {
 		Type:         schema.TypeString,
@@ -33,6 +30,7 @@ var resourceMonitorGrantSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Grants privilege to these roles.",
 		ForceNew:    true,
+		Set:         hashIdentifier,
 	},
 	"with_grant_option": {
 		Type:        schema.TypeBool,
@@ -50,7 +48,8 @@ func ResourceMonitorGrant() *schema.Resource {
 		Read:   ReadResourceMonitorGrant,
 		Delete: DeleteResourceMonitorGrant,
 
-		Schema: resourceMonitorGrantSchema,
+		Schema:        resourceMonitorGrantSchema,
+		CustomizeDiff: customizeDiffGrant(grantObjectResourceMonitor, resourceMonitorGrantSchema),
 	}
 }
 
@@ -1,7 +1,6 @@
 package resources_test
 
 import (
-	"database/sql"
 	"testing"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
@@ -10,6 +9,7 @@ import (
 
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
 )
 
@@ -33,13 +33,13 @@ func TestResourceMonitorCreate(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.ResourceMonitor().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(
 			`^CREATE RESOURCE MONITOR "good_name" CREDIT_QUOTA=100.00 TRIGGERS ON 99 PERCENT DO SUSPEND ON 105 PERCENT DO SUSPEND_IMMEDIATE ON 88 PERCENT DO NOTIFY ON 75 PERCENT DO NOTIFY$`,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
 
 		expectReadResourceMonitor(mock)
-		err := resources.CreateResourceMonitor(d, db)
+		err := resources.CreateResourceMonitor(d, client)
 		r.NoError(err)
 	})
 }
@@ -52,7 +52,7 @@ func expectReadResourceMonitor(mock sqlmock.Sqlmock) {
 	}).AddRow(
 		"good_name", 100.00, 0, 100, "", "MONTHLY", "2001-01-01 00:00:00.000 -0700",
 		"", "75%,88%", "99%", "105%", "2001-01-01 00:00:00.000 -0700", "ACCOUNTADMIN", "")
-	mock.ExpectQuery(`^SHOW RESOURCE MONITORS LIKE 'good_name'$`).WillReturnRows(rows)
+	mock.ExpectQuery(`^SHOW RESOURCE MONITORS LIKE 'good\\\\_name'$`).WillReturnRows(rows)
 }
 
 func TestResourceMonitorDelete(t *testing.T) {
@@ -65,10 +65,10 @@ func TestResourceMonitorDelete(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.ResourceMonitor().Schema, in)
 	d.SetId("good_name")
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`^DROP RESOURCE MONITOR "good_name"$`).WillReturnResult(sqlmock.NewResult(1, 1))
 
-		err := resources.DeleteResourceMonitor(d, db)
+		err := resources.DeleteResourceMonitor(d, client)
 		r.NoError(err)
 	})
 }
@@ -83,10 +83,10 @@ func TestResourceMonitorExists(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.ResourceMonitor().Schema, in)
 	d.SetId("good_name")
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		expectReadResourceMonitor(mock)
 
-		ok, err := resources.ResourceMonitorExists(d, db)
+		ok, err := resources.ResourceMonitorExists(d, client)
 		r.NoError(err)
 		r.True(ok)
 	})
@@ -1,18 +1,19 @@
 package resources
 
 import (
-	"database/sql"
-	"fmt"
-
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
-var roleProperties = []string{"comment"}
+var roleProperties = Properties{
+	{Attribute: "comment", Type: StringProperty, Column: "comment"},
+}
+
 var roleSchema = map[string]*schema.Schema{
 	"name": {
-		Type:     schema.TypeString,
-		Required: true,
+		Type:             schema.TypeString,
+		Required:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"comment": {
 		Type:     schema.TypeString,
@@ -36,33 +37,15 @@ func Role() *schema.Resource {
 }
 
 func CreateRole(data *schema.ResourceData, meta interface{}) error {
-	return CreateResource("role", roleProperties, roleSchema, snowflake.Role, ReadRole)(data, meta)
+	return CreateResource("role", roleProperties, snowflake.Role, ReadRole)(data, meta)
 }
 
 func ReadRole(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
-	id := data.Id()
-
-	row := snowflake.QueryRow(db, fmt.Sprintf("SHOW ROLES LIKE '%s'", id))
-	role, err := snowflake.ScanRole(row)
-	if err != nil {
-		return err
-	}
-
-	err = data.Set("name", role.Name.String)
-	if err != nil {
-		return err
-	}
-	err = data.Set("comment", role.Comment.String)
-	if err != nil {
-		return err
-	}
-
-	return err
+	return ReadResource("role", roleProperties, snowflake.Role)(data, meta)
 }
 
 func UpdateRole(data *schema.ResourceData, meta interface{}) error {
-	return UpdateResource("role", roleProperties, roleSchema, snowflake.Role, ReadRole)(data, meta)
+	return UpdateResource("role", roleProperties, snowflake.Role, ReadRole)(data, meta)
 }
 
 func DeleteRole(data *schema.ResourceData, meta interface{}) error {
@@ -1,13 +1,13 @@
 package resources
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
 
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/jmoiron/sqlx"
 )
 
 func RoleGrants() *schema.Resource {
@@ -17,6 +17,8 @@ func RoleGrants() *schema.Resource {
 		Delete: DeleteRoleGrants,
 		Update: UpdateRoleGrants,
 
+		CustomizeDiff: customizeDiffRoleGrants,
+
 		Schema: map[string]*schema.Schema{
 			"role_name": {
 				Type:        schema.TypeString,
@@ -26,18 +28,27 @@ func RoleGrants() *schema.Resource {
 				ValidateFunc: func(val interface{}, key string) ([]string, []error) {
 					return snowflake.ValidateIdentifier(val)
 				},
+				DiffSuppressFunc: diffIdentifier,
 			},
 			"roles": {
 				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Description: "Grants role to this specified role.",
+				Set:         hashIdentifier,
 			},
 			"users": {
 				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Description: "Grants role to this specified user.",
+				Set:         hashIdentifier,
+			},
+			"enable_multiple_grants": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When this is set to true, only the roles and users listed here are managed, and grants of the role made outside this resource (by other tools or other snowflake_role_grant(s) resources) are left untouched. Importing reads every role and user the role is granted to, so those not listed here are planned to be revoked after an import.",
 			},
 		},
 
@@ -48,7 +59,10 @@ func RoleGrants() *schema.Resource {
 }
 
 func CreateRoleGrants(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	roleName := data.Get("role_name").(string)
 	roles := expandStringList(data.Get("roles").(*schema.Set).List())
 	users := expandStringList(data.Get("users").(*schema.Set).List())
@@ -58,14 +72,14 @@ func CreateRoleGrants(data *schema.ResourceData, meta interface{}) error {
 	}
 
 	for _, role := range roles {
-		err := grantRoleToRole(db, roleName, role)
+		err := grantRoleToRole(ctx, client, roleName, role)
 		if err != nil {
 			return err
 		}
 	}
 
 	for _, user := range users {
-		err := grantRoleToUser(db, roleName, user)
+		err := grantRoleToUser(ctx, client, roleName, user)
 		if err != nil {
 			return err
 		}
@@ -74,15 +88,15 @@ func CreateRoleGrants(data *schema.ResourceData, meta interface{}) error {
 	return ReadRoleGrants(data, meta)
 }
 
-func grantRoleToRole(db *sql.DB, role1, role2 string) error {
+func grantRoleToRole(ctx context.Context, client *snowflake.Client, role1, role2 string) error {
 	g := snowflake.RoleGrant(role1)
-	err := snowflake.Exec(db, g.Role(role2).Grant())
+	err := client.ExecContext(ctx, g.Role(role2).Grant())
 	return err
 }
 
-func grantRoleToUser(db *sql.DB, role1, user string) error {
+func grantRoleToUser(ctx context.Context, client *snowflake.Client, role1, user string) error {
 	g := snowflake.RoleGrant(role1)
-	err := snowflake.Exec(db, g.User(user).Grant())
+	err := client.ExecContext(ctx, g.User(user).Grant())
 	return err
 }
 
@@ -95,22 +109,38 @@ type roleGrant struct {
 }
 
 func ReadRoleGrants(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
+
 	roleName := data.Id()
 
 	roles := make([]string, 0)
 	users := make([]string, 0)
 
-	grants, err := readGrants(db, roleName)
+	grants, err := readGrants(ctx, client, roleName)
 	if err != nil {
 		return err
 	}
 
+	// In non-exclusive mode we only report on the grantees this resource
+	// manages, so that grants made elsewhere don't show up as drift. Until it
+	// manages any, e.g. right after an import, every grantee is read.
+	managedRoles := data.Get("roles").(*schema.Set)
+	managedUsers := data.Get("users").(*schema.Set)
+	multipleGrants := data.Get("enable_multiple_grants").(bool) && managedRoles.Len()+managedUsers.Len() > 0
+
 	for _, grant := range grants {
 		switch grant.GrantedTo.String {
 		case "ROLE":
+			if multipleGrants && !managedRoles.Contains(grant.GranteeName.String) {
+				continue
+			}
 			roles = append(roles, grant.GranteeName.String)
 		case "USER":
+			if multipleGrants && !managedUsers.Contains(grant.GranteeName.String) {
+				continue
+			}
 			users = append(users, grant.GranteeName.String)
 		default:
 			return fmt.Errorf("unknown grant type %s", grant.GrantedTo.String)
@@ -133,11 +163,21 @@ func ReadRoleGrants(data *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func readGrants(db *sql.DB, roleName string) ([]*roleGrant, error) {
-	sdb := sqlx.NewDb(db, "snowflake")
+// readGrants returns the grants of roleName. They are cached in the ShowCache
+// of client and must not be modified.
+func readGrants(ctx context.Context, client *snowflake.Client, roleName string) ([]*roleGrant, error) {
+	stmt := fmt.Sprintf(`SHOW GRANTS OF ROLE %v`, snowflake.Identifier{roleName})
+	grants, err := client.Cache().Get(stmt, func() (interface{}, error) {
+		return queryGrants(ctx, client, stmt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return grants.([]*roleGrant), nil
+}
 
-	stmt := fmt.Sprintf(`SHOW GRANTS OF ROLE "%s"`, roleName)
-	rows, err := sdb.Queryx(stmt)
+func queryGrants(ctx context.Context, client *snowflake.Client, stmt string) ([]*roleGrant, error) {
+	rows, err := client.QueryContext(ctx, stmt)
 	if err != nil {
 		return nil, err
 	}
@@ -167,21 +207,24 @@ func readGrants(db *sql.DB, roleName string) ([]*roleGrant, error) {
 }
 
 func DeleteRoleGrants(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutDelete)
+	defer cancel()
+
 	roleName := data.Get("role_name").(string)
 
 	roles := expandStringList(data.Get("roles").(*schema.Set).List())
 	users := expandStringList(data.Get("users").(*schema.Set).List())
 
 	for _, role := range roles {
-		err := revokeRoleFromRole(db, roleName, role)
+		err := revokeRoleFromRole(ctx, client, roleName, role)
 		if err != nil {
 			return err
 		}
 	}
 
 	for _, user := range users {
-		err := revokeRoleFromUser(db, roleName, user)
+		err := revokeRoleFromUser(ctx, client, roleName, user)
 		if err != nil {
 			return err
 		}
@@ -191,23 +234,42 @@ func DeleteRoleGrants(data *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func revokeRoleFromRole(db *sql.DB, role1, role2 string) error {
+func revokeRoleFromRole(ctx context.Context, client *snowflake.Client, role1, role2 string) error {
 	rg := snowflake.RoleGrant(role1).Role(role2)
-	err := snowflake.Exec(db, rg.Revoke())
+	err := client.ExecContext(ctx, rg.Revoke())
 	return err
 }
 
-func revokeRoleFromUser(db *sql.DB, role1, user string) error {
+func revokeRoleFromUser(ctx context.Context, client *snowflake.Client, role1, user string) error {
 	rg := snowflake.RoleGrant(role1).User(user)
-	err := snowflake.Exec(db, rg.Revoke())
+	err := client.ExecContext(ctx, rg.Revoke())
 	return err
 }
 
+// customizeDiffRoleGrants rejects plans that would create a cycle in the role
+// hierarchy.
+func customizeDiffRoleGrants(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("roles") || !d.NewValueKnown("role_name") || !d.NewValueKnown("roles") {
+		return nil
+	}
+
+	o, n := d.GetChange("roles")
+	os := o.(*schema.Set)
+	ns := n.(*schema.Set)
+	add := expandStringList(ns.Difference(os).List())
+	remove := expandStringList(os.Difference(ns).List())
+
+	return checkRoleHierarchy(meta, d.Get("role_name").(string), add, remove)
+}
+
 func UpdateRoleGrants(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutUpdate)
+	defer cancel()
+
 	roleName := data.Get("role_name").(string)
 
-	x := func(resource string, grant func(db *sql.DB, role string, target string) error, revoke func(db *sql.DB, role string, target string) error) error {
+	x := func(resource string, grant func(ctx context.Context, client *snowflake.Client, role string, target string) error, revoke func(ctx context.Context, client *snowflake.Client, role string, target string) error) error {
 		o, n := data.GetChange(resource)
 
 		if o == nil {
@@ -223,13 +285,13 @@ func UpdateRoleGrants(data *schema.ResourceData, meta interface{}) error {
 		add := expandStringList(ns.Difference(os).List())
 
 		for _, user := range remove {
-			err := revoke(db, roleName, user)
+			err := revoke(ctx, client, roleName, user)
 			if err != nil {
 				return err
 			}
 		}
 		for _, user := range add {
-			err := grant(db, roleName, user)
+			err := grant(ctx, client, roleName, user)
 			if err != nil {
 				return err
 			}
@@ -1,10 +1,11 @@
 package resources
 
 import (
-	"database/sql"
+	"context"
 	"testing"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
 	"github.com/stretchr/testify/require"
 )
@@ -12,9 +13,9 @@ import (
 func Test_grantToRole(t *testing.T) {
 	r := require.New(t)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`GRANT ROLE "foo" TO ROLE "bar"`).WillReturnResult(sqlmock.NewResult(1, 1))
-		err := grantRoleToRole(db, "foo", "bar")
+		err := grantRoleToRole(context.Background(), client, "foo", "bar")
 		r.NoError(err)
 	})
 }
@@ -22,9 +23,9 @@ func Test_grantToRole(t *testing.T) {
 func Test_grantToUser(t *testing.T) {
 	r := require.New(t)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`GRANT ROLE "foo" TO USER "bar"`).WillReturnResult(sqlmock.NewResult(1, 1))
-		err := grantRoleToUser(db, "foo", "bar")
+		err := grantRoleToUser(context.Background(), client, "foo", "bar")
 		r.NoError(err)
 	})
 }
@@ -32,10 +33,10 @@ func Test_grantToUser(t *testing.T) {
 func Test_readGrants(t *testing.T) {
 	r := require.New(t)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		rows := sqlmock.NewRows([]string{"created_on", "role", "granted_to", "grantee_name", "granted_by"}).AddRow("_", "foo", "ROLE", "bam", "")
 		mock.ExpectQuery(`SHOW GRANTS OF ROLE "foo"`).WillReturnRows(rows)
-		read, err := readGrants(db, "foo")
+		read, err := readGrants(context.Background(), client, "foo")
 		r.NoError(err)
 		r.Len(read, 1)
 		g := read[0]
@@ -46,9 +47,9 @@ func Test_readGrants(t *testing.T) {
 
 func Test_revokeRoleFromRole(t *testing.T) {
 	r := require.New(t)
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`REVOKE ROLE "foo" FROM ROLE "bar"`).WillReturnResult(sqlmock.NewResult(1, 1))
-		err := revokeRoleFromRole(db, "foo", "bar")
+		err := revokeRoleFromRole(context.Background(), client, "foo", "bar")
 		r.NoError(err)
 
 	})
@@ -56,9 +57,9 @@ func Test_revokeRoleFromRole(t *testing.T) {
 }
 func Test_revokeRoleFromUser(t *testing.T) {
 	r := require.New(t)
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`REVOKE ROLE "foo" FROM USER "bar"`).WillReturnResult(sqlmock.NewResult(1, 1))
-		err := revokeRoleFromUser(db, "foo", "bar")
+		err := revokeRoleFromUser(context.Background(), client, "foo", "bar")
 		r.NoError(err)
 
 	})
@@ -1,12 +1,12 @@
 package resources_test
 
 import (
-	"database/sql"
 	"testing"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/stretchr/testify/require"
@@ -27,13 +27,13 @@ func TestRoleGrantsCreate(t *testing.T) {
 		"users":     []interface{}{"user1", "user2"},
 	})
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`GRANT ROLE "good_name" TO ROLE "role2"`).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectExec(`GRANT ROLE "good_name" TO ROLE "role1"`).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectExec(`GRANT ROLE "good_name" TO USER "user1"`).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectExec(`GRANT ROLE "good_name" TO USER "user2"`).WillReturnResult(sqlmock.NewResult(1, 1))
 		expectReadRoleGrants(mock)
-		err := resources.CreateRoleGrants(d, db)
+		err := resources.CreateRoleGrants(d, client)
 		r.NoError(err)
 	})
 }
@@ -62,9 +62,50 @@ func TestRoleGrantsRead(t *testing.T) {
 		"users":     []interface{}{"user1", "user2"},
 	})
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		expectReadRoleGrants(mock)
-		err := resources.ReadRoleGrants(d, db)
+		err := resources.ReadRoleGrants(d, client)
+		r.NoError(err)
+		r.Len(d.Get("users").(*schema.Set).List(), 2)
+		r.Len(d.Get("roles").(*schema.Set).List(), 2)
+	})
+}
+
+func TestRoleGrantsReadMultipleGrants(t *testing.T) {
+	r := require.New(t)
+
+	d := roleGrants(t, "good_name", map[string]interface{}{
+		"role_name":              "good_name",
+		"roles":                  []interface{}{"role1"},
+		"users":                  []interface{}{"user1"},
+		"enable_multiple_grants": true,
+	})
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		expectReadRoleGrants(mock)
+		err := resources.ReadRoleGrants(d, client)
+		r.NoError(err)
+		r.Equal([]interface{}{"user1"}, d.Get("users").(*schema.Set).List())
+		r.Equal([]interface{}{"role1"}, d.Get("roles").(*schema.Set).List())
+	})
+}
+
+func TestRoleGrantsImportMultipleGrants(t *testing.T) {
+	r := require.New(t)
+
+	res := resources.RoleGrants()
+	d := res.TestResourceData()
+	d.SetId("good_name")
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		imported, err := res.Importer.State(d, client)
+		r.NoError(err)
+		r.Len(imported, 1)
+		d = imported[0]
+		r.NoError(d.Set("enable_multiple_grants", true))
+
+		expectReadRoleGrants(mock)
+		err = resources.ReadRoleGrants(d, client)
 		r.NoError(err)
 		r.Len(d.Get("users").(*schema.Set).List(), 2)
 		r.Len(d.Get("roles").(*schema.Set).List(), 2)
@@ -80,13 +121,13 @@ func TestRoleGrantsDelete(t *testing.T) {
 		"users":     []interface{}{"user1", "user2"},
 	})
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 
 		mock.ExpectExec(`REVOKE ROLE "drop_it" FROM ROLE "role1"`).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectExec(`REVOKE ROLE "drop_it" FROM ROLE "role2"`).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectExec(`REVOKE ROLE "drop_it" FROM USER "user1"`).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectExec(`REVOKE ROLE "drop_it" FROM USER "user2"`).WillReturnResult(sqlmock.NewResult(1, 1))
-		err := resources.DeleteRoleGrants(d, db)
+		err := resources.DeleteRoleGrants(d, client)
 		r.NoError(err)
 	})
 }
@@ -0,0 +1,173 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/pkg/errors"
+	"github.com/snowflakedb/gosnowflake"
+)
+
+// Snowflake error code for "Object does not exist or not authorized".
+const snowflakeErrObjectDoesNotExist = 2003
+
+// systemRoles are the roles Snowflake creates in every account. They are not
+// expected to roll up to SYSADMIN.
+var systemRoles = []string{
+	"ACCOUNTADMIN",
+	"ORGADMIN",
+	"PUBLIC",
+	"SECURITYADMIN",
+	"SYSADMIN",
+	"USERADMIN",
+}
+
+// roleGraph is a lazily loaded view of the role hierarchy. An edge goes from a
+// role to each of the roles it is granted to, as reported by SHOW GRANTS OF
+// ROLE. Planned changes are overlaid on top of what is in Snowflake.
+type roleGraph struct {
+	ctx     context.Context
+	client  *snowflake.Client
+	parents map[string][]string
+}
+
+func newRoleGraph(ctx context.Context, client *snowflake.Client) *roleGraph {
+	return &roleGraph{
+		ctx:     ctx,
+		client:  client,
+		parents: map[string][]string{},
+	}
+}
+
+// parentsOf returns the roles that role is granted to. Roles that do not
+// exist yet, e.g. because they are created in the same apply, have none.
+func (g *roleGraph) parentsOf(role string) ([]string, error) {
+	if parents, ok := g.parents[role]; ok {
+		return parents, nil
+	}
+
+	parents := []string{}
+	grants, err := readGrants(g.ctx, g.client, role)
+	if err != nil {
+		if sfErr, ok := errors.Cause(err).(*gosnowflake.SnowflakeError); !ok || sfErr.Number != snowflakeErrObjectDoesNotExist {
+			return nil, err
+		}
+	}
+	for _, grant := range grants {
+		if grant.GrantedTo.String == "ROLE" {
+			parents = append(parents, grant.GranteeName.String)
+		}
+	}
+	g.parents[role] = parents
+	return parents, nil
+}
+
+// plan overlays the planned change to the roles that role is granted to.
+func (g *roleGraph) plan(role string, add, remove []string) error {
+	parents, err := g.parentsOf(role)
+	if err != nil {
+		return err
+	}
+
+	planned := []string{}
+	for _, p := range parents {
+		if !stringInSlice(p, remove) && !stringInSlice(p, add) {
+			planned = append(planned, p)
+		}
+	}
+	g.parents[role] = append(planned, add...)
+	return nil
+}
+
+// path returns the chain of grants leading from one role up to another, or nil
+// if from is not (transitively) granted to to.
+func (g *roleGraph) path(from, to string) ([]string, error) {
+	prev := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		role := queue[0]
+		queue = queue[1:]
+		if role == to {
+			path := []string{}
+			for r := role; r != ""; r = prev[r] {
+				path = append([]string{r}, path...)
+			}
+			return path, nil
+		}
+
+		parents, err := g.parentsOf(role)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range parents {
+			if _, seen := prev[p]; !seen {
+				prev[p] = role
+				queue = append(queue, p)
+			}
+		}
+	}
+	return nil, nil
+}
+
+// validateRoleHierarchy checks the planned grants of role to the roles in add
+// (and revokes from the roles in remove) against the role hierarchy. It
+// returns an error if a grant would create a cycle and a warning if role would
+// not roll up to SYSADMIN.
+func validateRoleHierarchy(ctx context.Context, client *snowflake.Client, role string, add, remove []string) ([]string, error) {
+	g := newRoleGraph(ctx, client)
+	err := g.plan(role, add, remove)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, parent := range add {
+		path, err := g.path(parent, role)
+		if err != nil {
+			return nil, err
+		}
+		if path != nil {
+			cycle := append([]string{role}, path...)
+			return nil, fmt.Errorf("granting role %v to role %v would create a cycle: %v", role, parent, strings.Join(cycle, " -> "))
+		}
+	}
+
+	warnings := []string{}
+	if stringInSlice(strings.ToUpper(role), systemRoles) {
+		return warnings, nil
+	}
+	path, err := g.path(role, "SYSADMIN")
+	if err != nil {
+		return nil, err
+	}
+	if path == nil {
+		warnings = append(warnings, fmt.Sprintf("role %v does not roll up to SYSADMIN, objects it owns will not be manageable by SYSADMIN", role))
+	}
+	return warnings, nil
+}
+
+// checkRoleHierarchy validates the role hierarchy at plan time for a resource
+// that grants roleName to the roles in add and revokes it from those in remove.
+// Cycles always fail the plan. A role that does not roll up to SYSADMIN fails
+// it too, unless the provider is configured not to require that, in which
+// case it is logged since a plan cannot surface warnings otherwise.
+func checkRoleHierarchy(meta interface{}, roleName string, add, remove []string) error {
+	client, ok := meta.(*snowflake.Client)
+	if !ok || client == nil {
+		return nil
+	}
+
+	warnings, err := validateRoleHierarchy(client.StopContext, client, roleName, add, remove)
+	if err != nil {
+		return err
+	}
+	if client.RequireSysadminRollup && len(warnings) > 0 {
+		return errors.New(strings.Join(warnings, "; "))
+	}
+	for _, w := range warnings {
+		log.Printf("[WARN] %v", w)
+	}
+	return nil
+}
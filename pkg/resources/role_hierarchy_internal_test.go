@@ -0,0 +1,95 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
+	"github.com/snowflakedb/gosnowflake"
+	"github.com/stretchr/testify/require"
+)
+
+func expectShowGrantsOfRole(mock sqlmock.Sqlmock, role string, parents ...string) {
+	rows := sqlmock.NewRows([]string{"created_on", "role", "granted_to", "grantee_name", "granted_by"})
+	for _, p := range parents {
+		rows.AddRow("_", role, "ROLE", p, "")
+	}
+	mock.ExpectQuery(`^SHOW GRANTS OF ROLE "` + role + `"$`).WillReturnRows(rows)
+}
+
+func Test_validateRoleHierarchy(t *testing.T) {
+	r := require.New(t)
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		expectShowGrantsOfRole(mock, "analyst", "reporting")
+		expectShowGrantsOfRole(mock, "engineer", "SYSADMIN")
+		expectShowGrantsOfRole(mock, "SYSADMIN", "ACCOUNTADMIN")
+		expectShowGrantsOfRole(mock, "ACCOUNTADMIN")
+		warnings, err := validateRoleHierarchy(context.Background(), client, "analyst", []string{"engineer"}, []string{"reporting"})
+		r.NoError(err)
+		r.Empty(warnings)
+	})
+}
+
+func Test_validateRoleHierarchyCycle(t *testing.T) {
+	r := require.New(t)
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		expectShowGrantsOfRole(mock, "analyst")
+		expectShowGrantsOfRole(mock, "engineer", "lead")
+		expectShowGrantsOfRole(mock, "lead", "analyst")
+		_, err := validateRoleHierarchy(context.Background(), client, "analyst", []string{"engineer"}, nil)
+		r.EqualError(err, "granting role analyst to role engineer would create a cycle: analyst -> engineer -> lead -> analyst")
+	})
+}
+
+func Test_validateRoleHierarchySelfGrant(t *testing.T) {
+	r := require.New(t)
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		expectShowGrantsOfRole(mock, "analyst")
+		_, err := validateRoleHierarchy(context.Background(), client, "analyst", []string{"analyst"}, nil)
+		r.EqualError(err, "granting role analyst to role analyst would create a cycle: analyst -> analyst")
+	})
+}
+
+func Test_validateRoleHierarchyNoSysadmin(t *testing.T) {
+	r := require.New(t)
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`^SHOW GRANTS OF ROLE "new_role"$`).WillReturnError(&gosnowflake.SnowflakeError{Number: 2003})
+		expectShowGrantsOfRole(mock, "orphan")
+		warnings, err := validateRoleHierarchy(context.Background(), client, "new_role", []string{"orphan"}, nil)
+		r.NoError(err)
+		r.Equal([]string{"role new_role does not roll up to SYSADMIN, objects it owns will not be manageable by SYSADMIN"}, warnings)
+	})
+}
+
+func Test_checkRoleHierarchyRequireSysadminRollup(t *testing.T) {
+	r := require.New(t)
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		expectShowGrantsOfRole(mock, "analyst")
+		expectShowGrantsOfRole(mock, "orphan")
+		err := checkRoleHierarchy(client, "analyst", []string{"orphan"}, nil)
+		r.EqualError(err, "role analyst does not roll up to SYSADMIN, objects it owns will not be manageable by SYSADMIN")
+
+		// the grants shown above are cached by the client
+		client.RequireSysadminRollup = false
+		r.NoError(checkRoleHierarchy(client, "analyst", []string{"orphan"}, nil))
+	})
+}
+
+func Test_checkRoleHierarchyCycle(t *testing.T) {
+	r := require.New(t)
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		client.RequireSysadminRollup = false
+		expectShowGrantsOfRole(mock, "analyst")
+		expectShowGrantsOfRole(mock, "engineer", "analyst")
+		err := checkRoleHierarchy(client, "analyst", []string{"engineer"}, nil)
+		r.EqualError(err, "granting role analyst to role engineer would create a cycle: analyst -> engineer -> analyst")
+	})
+}
@@ -0,0 +1,219 @@
+package resources
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pkg/errors"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+)
+
+const (
+	roleMembershipIDDelimiter = '|'
+)
+
+var roleMembershipSchema = map[string]*schema.Schema{
+	"role_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "The name of the role we are granting.",
+		ForceNew:    true,
+		ValidateFunc: func(val interface{}, key string) ([]string, []error) {
+			return snowflake.ValidateIdentifier(val)
+		},
+		DiffSuppressFunc: diffIdentifier,
+	},
+	"grantee_type": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "The kind of grantee the role is granted to, either ROLE or USER.",
+		Default:      string(snowflake.RoleGrantee),
+		ForceNew:     true,
+		ValidateFunc: validation.StringInSlice([]string{string(snowflake.RoleGrantee), string(snowflake.UserGrantee)}, false),
+	},
+	"grantee_name": {
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The name of the role or user the role is granted to.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
+	},
+}
+
+// RoleMembership returns a pointer to the resource representing a single grant
+// of a role to another role or to a user. Unlike snowflake_role_grants, it
+// does not claim the full membership of the role, so several modules can each
+// add members to the same role.
+func RoleMembership() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateRoleMembership,
+		Read:   ReadRoleMembership,
+		Delete: DeleteRoleMembership,
+
+		CustomizeDiff: customizeDiffRoleMembership,
+
+		Schema: roleMembershipSchema,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+// customizeDiffRoleMembership rejects plans that would create a cycle in the
+// role hierarchy.
+func customizeDiffRoleMembership(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" || d.Get("grantee_type").(string) != string(snowflake.RoleGrantee) {
+		return nil
+	}
+	if !d.NewValueKnown("role_name") || !d.NewValueKnown("grantee_name") {
+		return nil
+	}
+
+	add := []string{d.Get("grantee_name").(string)}
+	return checkRoleHierarchy(meta, d.Get("role_name").(string), add, nil)
+}
+
+type roleMembershipID struct {
+	RoleName    string
+	GranteeType string
+	GranteeName string
+}
+
+// String() takes in a roleMembershipID object and returns a pipe-delimited string:
+// RoleName|GranteeType|GranteeName
+func (ri *roleMembershipID) String() (string, error) {
+	var buf bytes.Buffer
+	csvWriter := csv.NewWriter(&buf)
+	csvWriter.Comma = roleMembershipIDDelimiter
+	dataIdentifiers := [][]string{{ri.RoleName, ri.GranteeType, ri.GranteeName}}
+	err := csvWriter.WriteAll(dataIdentifiers)
+	if err != nil {
+		return "", err
+	}
+	strRoleMembershipID := strings.TrimSpace(buf.String())
+	return strRoleMembershipID, nil
+}
+
+// roleMembershipIDFromString() takes in a pipe-delimited string: RoleName|GranteeType|GranteeName
+// and returns a roleMembershipID object
+func roleMembershipIDFromString(stringID string) (*roleMembershipID, error) {
+	reader := csv.NewReader(strings.NewReader(stringID))
+	reader.Comma = roleMembershipIDDelimiter
+	lines, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("Not CSV compatible")
+	}
+
+	if len(lines) != 1 {
+		return nil, fmt.Errorf("1 line per role membership")
+	}
+	if len(lines[0]) != 3 {
+		return nil, fmt.Errorf("3 fields allowed")
+	}
+
+	return &roleMembershipID{
+		RoleName:    lines[0][0],
+		GranteeType: lines[0][1],
+		GranteeName: lines[0][2],
+	}, nil
+}
+
+func (ri *roleMembershipID) executable() *snowflake.RoleGrantExecutable {
+	g := snowflake.RoleGrant(ri.RoleName)
+	if snowflake.GranteeType(ri.GranteeType) == snowflake.UserGrantee {
+		return g.User(ri.GranteeName)
+	}
+	return g.Role(ri.GranteeName)
+}
+
+// CreateRoleMembership implements schema.CreateFunc
+func CreateRoleMembership(data *schema.ResourceData, meta interface{}) error {
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutCreate)
+	defer cancel()
+
+	id := &roleMembershipID{
+		RoleName:    data.Get("role_name").(string),
+		GranteeType: data.Get("grantee_type").(string),
+		GranteeName: data.Get("grantee_name").(string),
+	}
+
+	err := client.ExecContext(ctx, id.executable().Grant())
+	if err != nil {
+		return errors.Wrapf(err, "error granting role %v to %v %v", id.RoleName, id.GranteeType, id.GranteeName)
+	}
+
+	dataIDInput, err := id.String()
+	if err != nil {
+		return err
+	}
+	data.SetId(dataIDInput)
+
+	return ReadRoleMembership(data, meta)
+}
+
+// ReadRoleMembership implements schema.ReadFunc
+func ReadRoleMembership(data *schema.ResourceData, meta interface{}) error {
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
+
+	id, err := roleMembershipIDFromString(data.Id())
+	if err != nil {
+		return err
+	}
+
+	grants, err := readGrants(ctx, client, id.RoleName)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, grant := range grants {
+		if grant.GrantedTo.String == id.GranteeType && grant.GranteeName.String == id.GranteeName {
+			found = true
+			break
+		}
+	}
+	if !found {
+		log.Printf("[WARN] role %v is no longer granted to %v %v, removing from state file", id.RoleName, id.GranteeType, id.GranteeName)
+		data.SetId("")
+		return nil
+	}
+
+	err = data.Set("role_name", id.RoleName)
+	if err != nil {
+		return err
+	}
+	err = data.Set("grantee_type", id.GranteeType)
+	if err != nil {
+		return err
+	}
+	return data.Set("grantee_name", id.GranteeName)
+}
+
+// DeleteRoleMembership implements schema.DeleteFunc
+func DeleteRoleMembership(data *schema.ResourceData, meta interface{}) error {
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutDelete)
+	defer cancel()
+
+	id, err := roleMembershipIDFromString(data.Id())
+	if err != nil {
+		return err
+	}
+
+	err = client.ExecContext(ctx, id.executable().Revoke())
+	if err != nil {
+		return errors.Wrapf(err, "error revoking role %v from %v %v", id.RoleName, id.GranteeType, id.GranteeName)
+	}
+
+	data.SetId("")
+	return nil
+}
@@ -0,0 +1,64 @@
+package resources_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccRoleMembership(t *testing.T) {
+	roleName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	parentName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	userName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		Providers: providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: roleMembershipConfig(roleName, parentName, userName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_role_membership.to_role", "role_name", roleName),
+					resource.TestCheckResourceAttr("snowflake_role_membership.to_role", "grantee_type", "ROLE"),
+					resource.TestCheckResourceAttr("snowflake_role_membership.to_role", "grantee_name", parentName),
+					resource.TestCheckResourceAttr("snowflake_role_membership.to_user", "grantee_type", "USER"),
+					resource.TestCheckResourceAttr("snowflake_role_membership.to_user", "grantee_name", userName),
+				),
+			},
+			// IMPORT
+			{
+				ResourceName:      "snowflake_role_membership.to_user",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func roleMembershipConfig(roleName, parentName, userName string) string {
+	return fmt.Sprintf(`
+resource "snowflake_role" "r" {
+  name = "%v"
+}
+
+resource "snowflake_role" "parent" {
+  name = "%v"
+}
+
+resource "snowflake_user" "u" {
+  name = "%v"
+}
+
+resource "snowflake_role_membership" "to_role" {
+  role_name    = snowflake_role.r.name
+  grantee_name = snowflake_role.parent.name
+}
+
+resource "snowflake_role_membership" "to_user" {
+  role_name    = snowflake_role.r.name
+  grantee_type = "USER"
+  grantee_name = snowflake_user.u.name
+}
+`, roleName, parentName, userName)
+}
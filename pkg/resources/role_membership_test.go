@@ -0,0 +1,67 @@
+package resources_test
+
+import (
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleMembership(t *testing.T) {
+	r := require.New(t)
+	err := resources.RoleMembership().InternalValidate(provider.Provider().Schema, true)
+	r.NoError(err)
+}
+
+func TestRoleMembershipCreate(t *testing.T) {
+	r := require.New(t)
+
+	d := roleMembership(t, "", map[string]interface{}{
+		"role_name":    "good_name",
+		"grantee_type": "USER",
+		"grantee_name": "user1",
+	})
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`^GRANT ROLE "good_name" TO USER "user1"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectReadRoleGrants(mock)
+		err := resources.CreateRoleMembership(d, client)
+		r.NoError(err)
+		r.Equal("good_name|USER|user1", d.Id())
+	})
+}
+
+func TestRoleMembershipReadRevoked(t *testing.T) {
+	r := require.New(t)
+
+	d := roleMembership(t, "good_name|ROLE|role3", map[string]interface{}{
+		"role_name":    "good_name",
+		"grantee_name": "role3",
+	})
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		expectReadRoleGrants(mock)
+		err := resources.ReadRoleMembership(d, client)
+		r.NoError(err)
+		r.Equal("", d.Id())
+	})
+}
+
+func TestRoleMembershipDelete(t *testing.T) {
+	r := require.New(t)
+
+	d := roleMembership(t, "drop_it|ROLE|role1", map[string]interface{}{
+		"role_name":    "drop_it",
+		"grantee_name": "role1",
+	})
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`^REVOKE ROLE "drop_it" FROM ROLE "role1"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		err := resources.DeleteRoleMembership(d, client)
+		r.NoError(err)
+	})
+}
@@ -1,14 +1,15 @@
 package resources_test
 
 import (
-	"database/sql"
 	"testing"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/stretchr/testify/require"
 )
 
@@ -28,10 +29,10 @@ func TestRoleCreate(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.Role().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`CREATE ROLE "good_name" COMMENT='great comment'`).WillReturnResult(sqlmock.NewResult(1, 1))
 		expectReadRole(mock)
-		err := resources.CreateRole(d, db)
+		err := resources.CreateRole(d, client)
 		r.NoError(err)
 	})
 }
@@ -40,8 +41,12 @@ func expectReadRole(mock sqlmock.Sqlmock) {
 	rows := sqlmock.NewRows([]string{
 		"created_on", "name", "is_default", "is_current", "is_inherited", "assigned_to_users", "granted_to_roles", "granted_roles", "owner", "comment",
 	},
-	).AddRow("created_on", "role name", "is_default", "is_current", "is_inherited", "assigned_to_users", "granted_to_roles", "granted_roles", "owner", "mock comment")
-	mock.ExpectQuery(`SHOW ROLES LIKE 'good_name'`).WillReturnRows(rows)
+	).AddRow(
+		"created_on", "good_name", "is_default", "is_current", "is_inherited", "assigned_to_users", "granted_to_roles", "granted_roles", "owner", "mock comment",
+	).AddRow(
+		"created_on", "other_name", "is_default", "is_current", "is_inherited", "assigned_to_users", "granted_to_roles", "granted_roles", "owner", "other comment",
+	)
+	mock.ExpectQuery(`^SHOW ROLES$`).WillReturnRows(rows)
 }
 
 func TestRoleRead(t *testing.T) {
@@ -49,12 +54,53 @@ func TestRoleRead(t *testing.T) {
 
 	d := role(t, "good_name", map[string]interface{}{"name": "good_name"})
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		expectReadRole(mock)
-		err := resources.ReadRole(d, db)
+		err := resources.ReadRole(d, client)
 		r.NoError(err)
 		r.Equal("mock comment", d.Get("comment").(string))
-		r.Equal("role name", d.Get("name").(string))
+		r.Equal("good_name", d.Get("name").(string))
+	})
+}
+
+func TestRoleReadSharesShow(t *testing.T) {
+	r := require.New(t)
+
+	d1 := role(t, "good_name", map[string]interface{}{"name": "good_name"})
+	d2 := role(t, "other_name", map[string]interface{}{"name": "other_name"})
+	d3 := role(t, "gone_name", map[string]interface{}{"name": "gone_name"})
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		// a single SHOW ROLES serves the Reads of all roles
+		expectReadRole(mock)
+		r.NoError(resources.ReadRole(d1, client))
+		r.NoError(resources.ReadRole(d2, client))
+		// a role missing from SHOW ROLES is looked up by name before it is
+		// removed from the state
+		mock.ExpectQuery(`^SHOW ROLES LIKE 'gone\\\\_name'$`).WillReturnRows(sqlmock.NewRows([]string{"name"}))
+		r.NoError(resources.ReadRole(d3, client))
+		r.Equal("mock comment", d1.Get("comment").(string))
+		r.Equal("other comment", d2.Get("comment").(string))
+		r.Equal("", d3.Id())
+	})
+}
+
+func TestRoleUpdateUnsetsRemovedComment(t *testing.T) {
+	r := require.New(t)
+
+	res := resources.Role()
+	state := &terraform.InstanceState{
+		ID:         "good_name",
+		Attributes: map[string]string{"id": "good_name", "name": "good_name", "comment": "great comment"},
+	}
+	diff, err := res.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{"name": "good_name"}), nil)
+	r.NoError(err)
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`^ALTER ROLE "good_name" UNSET COMMENT$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectReadRole(mock)
+		_, err := res.Apply(state, diff, client)
+		r.NoError(err)
 	})
 }
 
@@ -63,9 +109,9 @@ func TestRoleDelete(t *testing.T) {
 
 	d := role(t, "drop_it", map[string]interface{}{"name": "drop_it"})
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`DROP ROLE "drop_it"`).WillReturnResult(sqlmock.NewResult(1, 1))
-		err := resources.DeleteRole(d, db)
+		err := resources.DeleteRole(d, client)
 		r.NoError(err)
 	})
 }
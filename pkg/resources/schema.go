@@ -2,7 +2,6 @@ package resources
 
 import (
 	"bytes"
-	"database/sql"
 	"encoding/csv"
 	"fmt"
 	"strings"
@@ -20,16 +19,18 @@ const (
 
 var schemaSchema = map[string]*schema.Schema{
 	"name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "Specifies the identifier for the schema; must be unique for the database in which the schema is created.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "Specifies the identifier for the schema; must be unique for the database in which the schema is created.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"database": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "The database in which to create the schema.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The database in which to create the schema.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"comment": {
 		Type:        schema.TypeString,
@@ -58,6 +59,11 @@ var schemaSchema = map[string]*schema.Schema{
 	},
 }
 
+// schemaParameters are the attributes of schemaSchema read from SHOW PARAMETERS
+var schemaParameters = Properties{
+	{Attribute: "data_retention_days", Parameter: "data_retention_time_in_days", Type: IntProperty, ObjectParameter: true},
+}
+
 type schemaID struct {
 	DatabaseName string
 	SchemaName   string
@@ -120,7 +126,10 @@ func Schema() *schema.Resource {
 
 // CreateSchema implements schema.CreateFunc
 func CreateSchema(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	name := data.Get("name").(string)
 	database := data.Get("database").(string)
 
@@ -139,13 +148,12 @@ func CreateSchema(data *schema.ResourceData, meta interface{}) error {
 		builder.Managed()
 	}
 
-	if v, ok := data.GetOk("data_retention_days"); ok {
-		builder.WithDataRetentionDays(v.(int))
-	}
+	// 0 is a valid number of days, so GetOk would not do
+	builder.WithDataRetentionDays(data.Get("data_retention_days").(int))
 
 	q := builder.Create()
 
-	err := snowflake.Exec(db, q)
+	err := client.ExecContext(ctx, q)
 	if err != nil {
 		return errors.Wrapf(err, "error creating schema %v", name)
 	}
@@ -165,7 +173,10 @@ func CreateSchema(data *schema.ResourceData, meta interface{}) error {
 
 // ReadSchema implements schema.ReadFunc
 func ReadSchema(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
+
 	schemaID, err := schemaIDFromString(data.Id())
 	if err != nil {
 		return err
@@ -174,10 +185,7 @@ func ReadSchema(data *schema.ResourceData, meta interface{}) error {
 	dbName := schemaID.DatabaseName
 	schema := schemaID.SchemaName
 
-	q := snowflake.Schema(schema).WithDB(dbName).Show()
-	row := snowflake.QueryRow(db, q)
-
-	s, err := snowflake.ScanSchema(row)
+	s, err := snowflake.ReadSchema(ctx, client, dbName, schema)
 	if err != nil {
 		return err
 	}
@@ -197,7 +205,11 @@ func ReadSchema(data *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	err = data.Set("data_retention_days", s.RetentionTime.Int64)
+	params, err := readParameters(ctx, client, snowflake.Schema(schema).WithDB(dbName).ShowParameters(), snowflake.SchemaType)
+	if err != nil {
+		return errors.Wrapf(err, "unable to read the parameters of schema %v", data.Id())
+	}
+	err = schemaParameters.readObjectParameters(data, params)
 	if err != nil {
 		return err
 	}
@@ -235,6 +247,9 @@ func ReadSchema(data *schema.ResourceData, meta interface{}) error {
 
 // UpdateSchema implements schema.UpdateFunc
 func UpdateSchema(data *schema.ResourceData, meta interface{}) error {
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutUpdate)
+	defer cancel()
+
 	// https://www.terraform.io/docs/extend/writing-custom-providers.html#error-handling-amp-partial-state
 	data.Partial(true)
 
@@ -248,11 +263,11 @@ func UpdateSchema(data *schema.ResourceData, meta interface{}) error {
 
 	builder := snowflake.Schema(schema).WithDB(dbName)
 
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
 	if data.HasChange("comment") {
 		_, comment := data.GetChange("comment")
 		q := builder.ChangeComment(comment.(string))
-		err := snowflake.Exec(db, q)
+		err := client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error updating schema comment on %v", data.Id())
 		}
@@ -269,7 +284,7 @@ func UpdateSchema(data *schema.ResourceData, meta interface{}) error {
 			q = builder.Unmanage()
 		}
 
-		err := snowflake.Exec(db, q)
+		err := client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error changing management state on %v", data.Id())
 		}
@@ -282,7 +297,7 @@ func UpdateSchema(data *schema.ResourceData, meta interface{}) error {
 		_, days := data.GetChange("data_retention_days")
 
 		q := builder.ChangeDataRetentionDays(days.(int))
-		err := snowflake.Exec(db, q)
+		err := client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error updating data retention days on %v", data.Id())
 		}
@@ -293,7 +308,10 @@ func UpdateSchema(data *schema.ResourceData, meta interface{}) error {
 
 // DeleteSchema implements schema.DeleteFunc
 func DeleteSchema(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutDelete)
+	defer cancel()
+
 	schemaID, err := schemaIDFromString(data.Id())
 	if err != nil {
 		return err
@@ -304,7 +322,7 @@ func DeleteSchema(data *schema.ResourceData, meta interface{}) error {
 
 	q := snowflake.Schema(schema).WithDB(dbName).Drop()
 
-	err = snowflake.Exec(db, q)
+	err = client.ExecContext(ctx, q)
 	if err != nil {
 		return errors.Wrapf(err, "error deleting schema %v", data.Id())
 	}
@@ -316,7 +334,9 @@ func DeleteSchema(data *schema.ResourceData, meta interface{}) error {
 
 // SchemaExists implements schema.ExistsFunc
 func SchemaExists(data *schema.ResourceData, meta interface{}) (bool, error) {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
 	schemaID, err := schemaIDFromString(data.Id())
 	if err != nil {
 		return false, err
@@ -326,7 +346,7 @@ func SchemaExists(data *schema.ResourceData, meta interface{}) (bool, error) {
 	schema := schemaID.SchemaName
 
 	q := snowflake.Schema(schema).WithDB(dbName).Show()
-	rows, err := db.Query(q)
+	rows, err := client.QueryContext(ctx, q)
 	if err != nil {
 		return false, err
 	}
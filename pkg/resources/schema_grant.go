@@ -8,42 +8,22 @@ import (
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 )
 
-// Intentionally exclude the "ALL" alias because it is not a real privilege and
-// might not interact well with this provider.
-var validSchemaPrivileges = newPrivilegeSet(
-	privilegeAll,
-	privilegeModify,
-	privilegeMonitor,
-	privilegeOwnership,
-	privilegeUsage,
-	privilegeCreateTable,
-	privilegeCreateView,
-	privilegeCreateFileFormat,
-	privilegeCreateStage,
-	privilegeCreatePipe,
-	privilegeCreateStream,
-	privilegeCreateTask,
-	privilegeCreateSequence,
-	privilegeCreateFunction,
-	privilegeCreateProcedure,
-	privilegeCreateExternalTable,
-	privilegeCreateMaterializedView,
-	privilegeCreateTemporaryTable,
-	privilegeCreateMaskingPolicy,
-)
+var validSchemaPrivileges = grantObjectSchema.privileges()
 
 var schemaGrantSchema = map[string]*schema.Schema{
 	"schema_name": {
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "The name of the schema on which to grant privileges.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Optional:         true,
+		Description:      "The name of the schema on which to grant privileges.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"database_name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "The name of the database containing the schema on which to grant privileges.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The name of the database containing the schema on which to grant privileges.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"privilege": {
 		Type:         schema.TypeString,
@@ -59,6 +39,15 @@ var schemaGrantSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Grants privilege to these roles.",
 		ForceNew:    true,
+		Set:         hashIdentifier,
+	},
+	"database_roles": {
+		Type:        schema.TypeSet,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Optional:    true,
+		Description: "Grants privilege to these database roles, each in the form <database>.<role>.",
+		ForceNew:    true,
+		Set:         hashQualifiedIdentifier,
 	},
 	"shares": {
 		Type:        schema.TypeSet,
@@ -66,6 +55,7 @@ var schemaGrantSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Grants privilege to these shares (only valid if on_future is unset).",
 		ForceNew:    true,
+		Set:         hashIdentifier,
 	},
 	"on_future": {
 		Type:          schema.TypeBool,
@@ -91,7 +81,8 @@ func SchemaGrant() *schema.Resource {
 		Read:   ReadSchemaGrant,
 		Delete: DeleteSchemaGrant,
 
-		Schema: schemaGrantSchema,
+		Schema:        schemaGrantSchema,
+		CustomizeDiff: customizeDiffGrant(grantObjectSchema, schemaGrantSchema),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -1,7 +1,6 @@
 package resources_test
 
 import (
-	"database/sql"
 	"fmt"
 	"testing"
 	"time"
@@ -9,6 +8,7 @@ import (
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/stretchr/testify/require"
@@ -35,7 +35,7 @@ func TestSchemaGrantCreate(t *testing.T) {
 		d := schema.TestResourceDataRaw(t, resources.SchemaGrant().Schema, in)
 		r.NotNil(d)
 
-		WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 			mock.ExpectExec(
 				fmt.Sprintf(`^GRANT %s ON SCHEMA "test-db"."test-schema" TO ROLE "test-role-1" WITH GRANT OPTION$`, test_priv),
 			).WillReturnResult(sqlmock.NewResult(1, 1))
@@ -49,7 +49,7 @@ func TestSchemaGrantCreate(t *testing.T) {
 				fmt.Sprintf(`^GRANT %s ON SCHEMA "test-db"."test-schema" TO SHARE "test-share-2" WITH GRANT OPTION$`, test_priv),
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 			expectReadSchemaGrant(mock, test_priv)
-			err := resources.CreateSchemaGrant(d, db)
+			err := resources.CreateSchemaGrant(d, client)
 			r.NoError(err)
 		})
 	}
@@ -83,7 +83,7 @@ func TestFutureSchemaGrantCreate(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.SchemaGrant().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(
 			`^GRANT USAGE ON FUTURE SCHEMAS IN DATABASE "test-db" TO ROLE "test-role-1" WITH GRANT OPTION$`,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
@@ -91,7 +91,7 @@ func TestFutureSchemaGrantCreate(t *testing.T) {
 			`^GRANT USAGE ON FUTURE SCHEMAS IN DATABASE "test-db" TO ROLE "test-role-2" WITH GRANT OPTION$`,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
 		expectReadFutureSchemaGrant(mock)
-		err := resources.CreateSchemaGrant(d, db)
+		err := resources.CreateSchemaGrant(d, client)
 		r.NoError(err)
 	})
 }
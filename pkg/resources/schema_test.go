@@ -1,12 +1,12 @@
 package resources_test
 
 import (
-	"database/sql"
 	"testing"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/stretchr/testify/require"
@@ -31,13 +31,13 @@ func TestSchemaCreate(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.Schema().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(
 			`^CREATE TRANSIENT SCHEMA "test_db"."good_name" WITH MANAGED ACCESS DATA_RETENTION_TIME_IN_DAYS = 1 COMMENT = 'great comment'$`,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
 
 		expectReadSchema(mock)
-		err := resources.CreateSchema(d, db)
+		err := resources.CreateSchema(d, client)
 		r.NoError(err)
 	})
 }
@@ -46,5 +46,25 @@ func expectReadSchema(mock sqlmock.Sqlmock) {
 	rows := sqlmock.NewRows([]string{
 		"created_on", "name", "is_default", "is_current", "database_name", "owner", "comment", "options", "retention_time"},
 	).AddRow("2019-05-19 16:55:36.530 -0700", "good_name", "N", "Y", "test_db", "admin", "great comment", "TRANSIENT, MANAGED ACCESS", 1)
-	mock.ExpectQuery(`^SHOW SCHEMAS LIKE 'good_name' IN DATABASE "test_db"$`).WillReturnRows(rows)
+	mock.ExpectQuery(`^SHOW SCHEMAS IN DATABASE "test_db"$`).WillReturnRows(rows)
+
+	params := sqlmock.NewRows([]string{"key", "value", "default", "level", "description", "type"}).
+		AddRow("DATA_RETENTION_TIME_IN_DAYS", "7", "1", "SCHEMA", "desc", "NUMBER")
+	mock.ExpectQuery(`^SHOW PARAMETERS IN SCHEMA "test_db"."good_name"$`).WillReturnRows(params)
+}
+
+func TestSchemaRead(t *testing.T) {
+	r := require.New(t)
+
+	d := schema.TestResourceDataRaw(t, resources.Schema().Schema, map[string]interface{}{"name": "good_name", "database": "test_db"})
+	d.SetId("test_db|good_name")
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		expectReadSchema(mock)
+		err := resources.ReadSchema(d, client)
+		r.NoError(err)
+		r.Equal("great comment", d.Get("comment").(string))
+		// read from SHOW PARAMETERS, not the retention_time column
+		r.Equal(7, d.Get("data_retention_days").(int))
+	})
 }
@@ -1,26 +1,27 @@
 package resources
 
 import (
-	"database/sql"
+	"context"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/pkg/errors"
 
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 )
 
-var shareProperties = []string{
-	"comment",
+var shareProperties = Properties{
+	{Attribute: "comment", Type: StringProperty},
 }
 
 var shareSchema = map[string]*schema.Schema{
 	"name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "Specifies the identifier for the share; must be unique for the account in which the share is created.",
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "Specifies the identifier for the share; must be unique for the account in which the share is created.",
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"comment": {
 		Type:        schema.TypeString,
@@ -55,82 +56,94 @@ func Share() *schema.Resource {
 
 // CreateShare implements schema.CreateFunc
 func CreateShare(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	name := data.Get("name").(string)
 
 	builder := snowflake.Share(name).Create()
 	builder.SetString("COMMENT", data.Get("comment").(string))
 
-	err := snowflake.Exec(db, builder.Statement())
-	if err != nil {
-		return errors.Wrapf(err, "error creating share")
-	}
-	data.SetId(name)
-
-	// Adding accounts must be done via an ALTER query
+	// Adding accounts must be done via an ALTER query, in the same plan so
+	// that the share is dropped again if they cannot be added.
 
 	// @TODO flesh out the share type in the snowflake package since it doesn't
 	// follow the normal generic rules
-	err = setAccounts(data, meta)
+	plan := snowflake.NewPlan()
+	plan.Add(fmt.Sprintf("creating share %v", name), builder.Statement(), snowflake.Share(name).Drop())
+	cleanup := addSetAccounts(plan, name, nil, expandStringList(data.Get("accounts").([]interface{})))
+
+	err := plan.Apply(ctx, client)
 	if err != nil {
 		return err
 	}
+	data.SetId(name)
+	applyCleanup(ctx, client, cleanup, name)
 
 	return ReadShare(data, meta)
 }
 
-func setAccounts(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
-	name := data.Get("name").(string)
-	accs := expandStringList(data.Get("accounts").([]interface{}))
-
-	if len(accs) > 0 {
-		// There is a race condition where error accounts cannot be added to a
-		// share until after a database is added to the share. Since a database
-		// grant is dependent on the share itself, this is a hack to get the
-		// thing working.
-		// 1. Create new temporary DB
-		tempName := fmt.Sprintf("TEMP_%v_%d", name, time.Now().Unix())
-		tempDB := snowflake.Database(tempName)
-		err := snowflake.Exec(db, tempDB.Create().Statement())
-		if err != nil {
-			return errors.Wrapf(err, "error creating temporary DB %v", tempName)
-		}
+// addSetAccounts adds the steps that change the accounts of share name from
+// prev to accs to plan. It returns the plan that removes the temporary DB
+// the steps need, to be applied after plan with applyCleanup, so that a
+// failure to clean up does not undo the accounts that were set.
+func addSetAccounts(plan *snowflake.Plan, name string, prev, accs []string) *snowflake.Plan {
+	cleanup := snowflake.NewPlan()
+	if len(accs) == 0 {
+		return cleanup
+	}
 
-		// 2. Create temporary DB grant to the share
-		tempDBGrant := snowflake.DatabaseGrant(tempName)
-		err = snowflake.Exec(db, tempDBGrant.Share(name).Grant("USAGE", false))
-		if err != nil {
-			return errors.Wrapf(err, "error creating temporary DB grant %v", tempName)
-		}
-		// 3. Add the accounts to the share
-		q := fmt.Sprintf(`ALTER SHARE "%v" SET ACCOUNTS=%v`, name, strings.Join(accs, ","))
-		err = snowflake.Exec(db, q)
-		if err != nil {
-			return errors.Wrapf(err, "error adding accounts to share %v", name)
-		}
-		// 4. Revoke temporary DB grant to the share
-		err = snowflake.Exec(db, tempDBGrant.Share(name).Revoke("USAGE"))
-		if err != nil {
-			return errors.Wrapf(err, "error revoking temporary DB grant %v", tempName)
-		}
-		// 5. Remove the temporary DB
-		err = snowflake.Exec(db, tempDB.Drop())
-		if err != nil {
-			return errors.Wrapf(err, "error dropping temporary DB %v", tempName)
-		}
+	// There is a race condition where error accounts cannot be added to a
+	// share until after a database is added to the share. Since a database
+	// grant is dependent on the share itself, this is a hack to get the
+	// thing working. The plan drops the temporary DB again if any step fails.
+	// 1. Create new temporary DB
+	tempName := fmt.Sprintf("TEMP_%v_%d", name, time.Now().Unix())
+	tempDB := snowflake.Database(tempName)
+	plan.Add(fmt.Sprintf("creating temporary DB %v", tempName), tempDB.Create().Statement(), tempDB.Drop())
+
+	// 2. Create temporary DB grant to the share
+	tempDBGrant := snowflake.DatabaseGrant(tempName)
+	plan.Add(fmt.Sprintf("creating temporary DB grant %v", tempName), tempDBGrant.Share(name).Grant("USAGE", false), tempDBGrant.Share(name).Revoke("USAGE"))
+
+	// 3. Add the accounts to the share, restoring the previous ones if a
+	// later step fails
+	q := fmt.Sprintf(`ALTER SHARE %v SET ACCOUNTS=%v`, snowflake.Identifier{name}, strings.Join(accs, ","))
+	undo := fmt.Sprintf(`ALTER SHARE %v REMOVE ACCOUNTS=%v`, snowflake.Identifier{name}, strings.Join(accs, ","))
+	if len(prev) > 0 {
+		undo = fmt.Sprintf(`ALTER SHARE %v SET ACCOUNTS=%v`, snowflake.Identifier{name}, strings.Join(prev, ","))
 	}
+	plan.Add(fmt.Sprintf("adding accounts to share %v", name), q, undo)
 
-	return nil
+	// 4. Revoke temporary DB grant to the share
+	cleanup.Add(fmt.Sprintf("revoking temporary DB grant %v", tempName), tempDBGrant.Share(name).Revoke("USAGE"), "")
+
+	// 5. Remove the temporary DB
+	cleanup.Add(fmt.Sprintf("dropping temporary DB %v", tempName), tempDB.Drop(), "")
+	return cleanup
+}
+
+// applyCleanup applies the cleanup plan returned by addSetAccounts. The
+// accounts of share name are set by then, so a failure is only logged, as the
+// temporary DB may need to be dropped by hand.
+func applyCleanup(ctx context.Context, client *snowflake.Client, cleanup *snowflake.Plan, name string) {
+	err := cleanup.Apply(ctx, client)
+	if err != nil {
+		log.Printf("[WARN] the accounts of share %v were set, but the temporary DB could not be cleaned up: %v", name, err)
+	}
 }
 
 // ReadShare implements schema.ReadFunc
 func ReadShare(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
+
 	id := data.Id()
 
 	stmt := snowflake.Share(id).Show()
-	row := snowflake.QueryRow(db, stmt)
+	row := client.QueryRowContext(ctx, stmt)
 
 	s, err := snowflake.ScanShare(row)
 	if err != nil {
@@ -156,13 +169,22 @@ func ReadShare(data *schema.ResourceData, meta interface{}) error {
 func UpdateShare(data *schema.ResourceData, meta interface{}) error {
 	// Change the accounts first - this is a special case and won't work using the generic method
 	if data.HasChange("accounts") {
-		err := setAccounts(data, meta)
+		client := meta.(*snowflake.Client)
+		ctx, cancel := OperationContext(data, meta, schema.TimeoutUpdate)
+		defer cancel()
+
+		name := data.Get("name").(string)
+		prev, accs := data.GetChange("accounts")
+		plan := snowflake.NewPlan()
+		cleanup := addSetAccounts(plan, name, expandStringList(prev.([]interface{})), expandStringList(accs.([]interface{})))
+		err := plan.Apply(ctx, client)
 		if err != nil {
 			return err
 		}
+		applyCleanup(ctx, client, cleanup, name)
 	}
 
-	return UpdateResource("this does not seem to be used", shareProperties, shareSchema, snowflake.Share, ReadShare)(data, meta)
+	return UpdateResource("share", shareProperties, snowflake.Share, ReadShare)(data, meta)
 }
 
 // DeleteShare implements schema.DeleteFunc
@@ -172,11 +194,13 @@ func DeleteShare(data *schema.ResourceData, meta interface{}) error {
 
 // ShareExists implements schema.ExistsFunc
 func ShareExists(data *schema.ResourceData, meta interface{}) (bool, error) {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
 	id := data.Id()
 
 	stmt := snowflake.Share(id).Show()
-	rows, err := db.Query(stmt)
+	rows, err := client.QueryContext(ctx, stmt)
 	if err != nil {
 		return false, err
 	}
@@ -1,15 +1,17 @@
 package resources_test
 
 import (
-	"database/sql"
+	"errors"
 	"testing"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/stretchr/testify/require"
 
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
 )
 
@@ -30,7 +32,7 @@ func TestShareCreate(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.Share().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`^CREATE SHARE "test-share" COMMENT='great comment'$`).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectExec(`^CREATE DATABASE "TEMP_test-share_\d*"$`).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectExec(`^GRANT USAGE ON DATABASE "TEMP_test-share_\d*" TO SHARE "test-share"$`).WillReturnResult(sqlmock.NewResult(1, 1))
@@ -38,7 +40,84 @@ func TestShareCreate(t *testing.T) {
 		mock.ExpectExec(`^REVOKE USAGE ON DATABASE "TEMP_test-share_\d*" FROM SHARE "test-share"$`).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectExec(`^DROP DATABASE "TEMP_test-share_\d*"$`).WillReturnResult(sqlmock.NewResult(1, 1))
 		expectReadShare(mock)
-		err := resources.CreateShare(d, db)
+		err := resources.CreateShare(d, client)
+		r.NoError(err)
+	})
+}
+
+func TestShareCreateUndoesOnFailure(t *testing.T) {
+	r := require.New(t)
+
+	in := map[string]interface{}{
+		"name":     "test-share",
+		"accounts": []interface{}{"bob123"},
+	}
+	d := schema.TestResourceDataRaw(t, resources.Share().Schema, in)
+	r.NotNil(d)
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`^CREATE SHARE "test-share"`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`^CREATE DATABASE "TEMP_test-share_\d*"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`^GRANT USAGE ON DATABASE "TEMP_test-share_\d*" TO SHARE "test-share"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`^ALTER SHARE "test-share" SET ACCOUNTS=bob123$`).WillReturnError(errors.New("account bob123 does not exist"))
+		// the temporary DB and the share are not left behind
+		mock.ExpectExec(`^REVOKE USAGE ON DATABASE "TEMP_test-share_\d*" FROM SHARE "test-share"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`^DROP DATABASE "TEMP_test-share_\d*"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`^DROP SHARE "test-share"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		err := resources.CreateShare(d, client)
+		r.EqualError(err, "error adding accounts to share test-share: account bob123 does not exist")
+		r.Equal("", d.Id())
+	})
+}
+
+func TestShareCreateKeepsShareOnCleanupFailure(t *testing.T) {
+	r := require.New(t)
+
+	in := map[string]interface{}{
+		"name":     "test-share",
+		"accounts": []interface{}{"bob123"},
+	}
+	d := schema.TestResourceDataRaw(t, resources.Share().Schema, in)
+	r.NotNil(d)
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`^CREATE SHARE "test-share"`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`^CREATE DATABASE "TEMP_test-share_\d*"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`^GRANT USAGE ON DATABASE "TEMP_test-share_\d*" TO SHARE "test-share"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`^ALTER SHARE "test-share" SET ACCOUNTS=bob123$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		// cleaning up the temporary DB does not drop the share
+		mock.ExpectExec(`^REVOKE USAGE ON DATABASE "TEMP_test-share_\d*" FROM SHARE "test-share"$`).WillReturnError(errors.New("connection lost"))
+		expectReadShare(mock)
+		err := resources.CreateShare(d, client)
+		r.NoError(err)
+		r.Equal("test-share", d.Id())
+	})
+}
+
+func TestShareUpdateAccounts(t *testing.T) {
+	r := require.New(t)
+
+	res := resources.Share()
+	state := &terraform.InstanceState{
+		ID: "test-share",
+		Attributes: map[string]string{
+			"id": "test-share", "name": "test-share", "comment": "great comment",
+			"accounts.#": "1", "accounts.0": "bob123",
+		},
+	}
+	cfg := map[string]interface{}{"name": "test-share", "comment": "other comment", "accounts": []interface{}{"sue456"}}
+	diff, err := res.Diff(state, terraform.NewResourceConfigRaw(cfg), nil)
+	r.NoError(err)
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`^CREATE DATABASE "TEMP_test-share_\d*"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`^GRANT USAGE ON DATABASE "TEMP_test-share_\d*" TO SHARE "test-share"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`^ALTER SHARE "test-share" SET ACCOUNTS=sue456$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`^REVOKE USAGE ON DATABASE "TEMP_test-share_\d*" FROM SHARE "test-share"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`^DROP DATABASE "TEMP_test-share_\d*"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`^ALTER SHARE "test-share" SET COMMENT='other comment'$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectReadShare(mock)
+		_, err := res.Apply(state, diff, client)
 		r.NoError(err)
 	})
 }
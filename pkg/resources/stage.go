@@ -2,7 +2,6 @@ package resources
 
 import (
 	"bytes"
-	"database/sql"
 	"encoding/csv"
 	"fmt"
 	"strings"
@@ -18,22 +17,25 @@ const (
 
 var stageSchema = map[string]*schema.Schema{
 	"name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "Specifies the identifier for the stage; must be unique for the database and schema in which the stage is created.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "Specifies the identifier for the stage; must be unique for the database and schema in which the stage is created.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"database": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "The database in which to create the stage.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The database in which to create the stage.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"schema": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "The schema in which to create the stage.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The schema in which to create the stage.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"url": {
 		Type:        schema.TypeString,
@@ -47,9 +49,10 @@ var stageSchema = map[string]*schema.Schema{
 		Sensitive:   true,
 	},
 	"storage_integration": {
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Specifies the name of the storage integration used to delegate authentication responsibility for external cloud storage to a Snowflake identity and access management (IAM) entity.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		Description:      "Specifies the name of the storage integration used to delegate authentication responsibility for external cloud storage to a Snowflake identity and access management (IAM) entity.",
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"file_format": {
 		Type:        schema.TypeString,
@@ -147,7 +150,10 @@ func Stage() *schema.Resource {
 
 // CreateStage implements schema.CreateFunc
 func CreateStage(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	name := data.Get("name").(string)
 	database := data.Get("database").(string)
 	schema := data.Get("schema").(string)
@@ -185,7 +191,7 @@ func CreateStage(data *schema.ResourceData, meta interface{}) error {
 
 	q := builder.Create()
 
-	err := snowflake.Exec(db, q)
+	err := client.ExecContext(ctx, q)
 	if err != nil {
 		return errors.Wrapf(err, "error creating stage %v", name)
 	}
@@ -207,7 +213,10 @@ func CreateStage(data *schema.ResourceData, meta interface{}) error {
 // ReadStage implements schema.ReadFunc
 // credentials and encryption are omitted, they cannot be read via SHOW or DESCRIBE
 func ReadStage(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
+
 	stageID, err := stageIDFromString(data.Id())
 	if err != nil {
 		return err
@@ -218,15 +227,12 @@ func ReadStage(data *schema.ResourceData, meta interface{}) error {
 	stage := stageID.StageName
 
 	q := snowflake.Stage(stage, dbName, schema).Describe()
-	stageDesc, err := snowflake.DescStage(db, q)
+	stageDesc, err := snowflake.DescStage(ctx, client, q)
 	if err != nil {
 		return err
 	}
 
-	sq := snowflake.Stage(stage, dbName, schema).Show()
-	row := snowflake.QueryRow(db, sq)
-
-	s, err := snowflake.ScanStageShow(row)
+	s, err := snowflake.ReadStage(ctx, client, dbName, schema, stage)
 	if err != nil {
 		return err
 	}
@@ -286,6 +292,9 @@ func ReadStage(data *schema.ResourceData, meta interface{}) error {
 
 // UpdateStage implements schema.UpdateFunc
 func UpdateStage(data *schema.ResourceData, meta interface{}) error {
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutUpdate)
+	defer cancel()
+
 	// https://www.terraform.io/docs/extend/writing-custom-providers.html#error-handling-amp-partial-state
 	data.Partial(true)
 
@@ -300,11 +309,11 @@ func UpdateStage(data *schema.ResourceData, meta interface{}) error {
 
 	builder := snowflake.Stage(stage, dbName, schema)
 
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
 	if data.HasChange("url") {
 		_, url := data.GetChange("url")
 		q := builder.ChangeURL(url.(string))
-		err := snowflake.Exec(db, q)
+		err := client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error updating stage url on %v", data.Id())
 		}
@@ -315,7 +324,7 @@ func UpdateStage(data *schema.ResourceData, meta interface{}) error {
 	if data.HasChange("credentials") {
 		_, credentials := data.GetChange("credentials")
 		q := builder.ChangeCredentials(credentials.(string))
-		err := snowflake.Exec(db, q)
+		err := client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error updating stage credentials on %v", data.Id())
 		}
@@ -326,7 +335,7 @@ func UpdateStage(data *schema.ResourceData, meta interface{}) error {
 	if data.HasChange("storage_integration") {
 		_, si := data.GetChange("storage_integration")
 		q := builder.ChangeStorageIntegration(si.(string))
-		err := snowflake.Exec(db, q)
+		err := client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error updating stage storage integration on %v", data.Id())
 		}
@@ -337,7 +346,7 @@ func UpdateStage(data *schema.ResourceData, meta interface{}) error {
 	if data.HasChange("encryption") {
 		_, encryption := data.GetChange("encryption")
 		q := builder.ChangeEncryption(encryption.(string))
-		err := snowflake.Exec(db, q)
+		err := client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error updating stage encryption on %v", data.Id())
 		}
@@ -347,7 +356,7 @@ func UpdateStage(data *schema.ResourceData, meta interface{}) error {
 	if data.HasChange("file_format") {
 		_, fileFormat := data.GetChange("file_format")
 		q := builder.ChangeFileFormat(fileFormat.(string))
-		err := snowflake.Exec(db, q)
+		err := client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error updating stage file formaat on %v", data.Id())
 		}
@@ -357,7 +366,7 @@ func UpdateStage(data *schema.ResourceData, meta interface{}) error {
 	if data.HasChange("copy_options") {
 		_, copyOptions := data.GetChange("copy_options")
 		q := builder.ChangeCopyOptions(copyOptions.(string))
-		err := snowflake.Exec(db, q)
+		err := client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error updating stage copy options on %v", data.Id())
 		}
@@ -367,7 +376,7 @@ func UpdateStage(data *schema.ResourceData, meta interface{}) error {
 	if data.HasChange("comment") {
 		_, comment := data.GetChange("comment")
 		q := builder.ChangeComment(comment.(string))
-		err := snowflake.Exec(db, q)
+		err := client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error updating stage comment on %v", data.Id())
 		}
@@ -380,7 +389,10 @@ func UpdateStage(data *schema.ResourceData, meta interface{}) error {
 
 // DeleteStage implements schema.DeleteFunc
 func DeleteStage(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutDelete)
+	defer cancel()
+
 	stageID, err := stageIDFromString(data.Id())
 	if err != nil {
 		return err
@@ -392,7 +404,7 @@ func DeleteStage(data *schema.ResourceData, meta interface{}) error {
 
 	q := snowflake.Stage(stage, dbName, schema).Drop()
 
-	err = snowflake.Exec(db, q)
+	err = client.ExecContext(ctx, q)
 	if err != nil {
 		return errors.Wrapf(err, "error deleting stage %v", data.Id())
 	}
@@ -404,7 +416,9 @@ func DeleteStage(data *schema.ResourceData, meta interface{}) error {
 
 // StageExists implements schema.ExistsFunc
 func StageExists(data *schema.ResourceData, meta interface{}) (bool, error) {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
 	stageID, err := stageIDFromString(data.Id())
 	if err != nil {
 		return false, err
@@ -415,7 +429,7 @@ func StageExists(data *schema.ResourceData, meta interface{}) (bool, error) {
 	stage := stageID.StageName
 
 	q := snowflake.Stage(stage, dbName, schema).Describe()
-	rows, err := db.Query(q)
+	rows, err := client.QueryContext(ctx, q)
 	if err != nil {
 		return false, err
 	}
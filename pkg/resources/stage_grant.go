@@ -7,33 +7,29 @@ import (
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 )
 
-var ValidStagePrivileges = newPrivilegeSet(
-	privilegeAll,
-	privilegeOwnership,
-	privilegeUsage,
-	// These privileges are only valid for internal stages
-	privilegeRead,
-	privilegeWrite,
-)
+var ValidStagePrivileges = grantObjectStage.privileges()
 
 var stageGrantSchema = map[string]*schema.Schema{
 	"stage_name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "The name of the stage on which to grant privileges.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The name of the stage on which to grant privileges.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"schema_name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "The name of the schema containing the current stage on which to grant privileges.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The name of the schema containing the current stage on which to grant privileges.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"database_name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "The name of the database containing the current stage on which to grant privileges.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The name of the database containing the current stage on which to grant privileges.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"privilege": {
 		Type:         schema.TypeString,
@@ -49,6 +45,15 @@ var stageGrantSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Grants privilege to these roles.",
 		ForceNew:    true,
+		Set:         hashIdentifier,
+	},
+	"database_roles": {
+		Type:        schema.TypeSet,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Optional:    true,
+		Description: "Grants privilege to these database roles, each in the form <database>.<role>.",
+		ForceNew:    true,
+		Set:         hashQualifiedIdentifier,
 	},
 	"shares": {
 		Type:        schema.TypeSet,
@@ -56,6 +61,7 @@ var stageGrantSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Grants privilege to these shares.",
 		ForceNew:    true,
+		Set:         hashIdentifier,
 	},
 	"with_grant_option": {
 		Type:        schema.TypeBool,
@@ -73,7 +79,8 @@ func StageGrant() *schema.Resource {
 		Read:   ReadStageGrant,
 		Delete: DeleteStageGrant,
 
-		Schema: stageGrantSchema,
+		Schema:        stageGrantSchema,
+		CustomizeDiff: customizeDiffGrant(grantObjectStage, stageGrantSchema),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -1,7 +1,6 @@
 package resources
 
 import (
-	"database/sql"
 	"fmt"
 	"log"
 	"strings"
@@ -14,9 +13,10 @@ import (
 var storageIntegrationSchema = map[string]*schema.Schema{
 	// The first part of the schema is shared between all integration vendors
 	"name": {
-		Type:     schema.TypeString,
-		Required: true,
-		ForceNew: true,
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"comment": {
 		Type:     schema.TypeString,
@@ -97,7 +97,10 @@ func StorageIntegration() *schema.Resource {
 
 // CreateStorageIntegration implements schema.CreateFunc
 func CreateStorageIntegration(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	name := data.Get("name").(string)
 
 	stmt := snowflake.StorageIntegration(name).Create()
@@ -123,7 +126,7 @@ func CreateStorageIntegration(data *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
-	err = snowflake.Exec(db, stmt.Statement())
+	err = client.ExecContext(ctx, stmt.Statement())
 	if err != nil {
 		return fmt.Errorf("error creating storage integration: %w", err)
 	}
@@ -135,11 +138,14 @@ func CreateStorageIntegration(data *schema.ResourceData, meta interface{}) error
 
 // ReadStorageIntegration implements schema.ReadFunc
 func ReadStorageIntegration(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
+
 	id := data.Id()
 
 	stmt := snowflake.StorageIntegration(data.Id()).Show()
-	row := snowflake.QueryRow(db, stmt)
+	row := client.QueryRowContext(ctx, stmt)
 
 	// Some properties can come from the SHOW INTEGRATION call
 
@@ -174,7 +180,7 @@ func ReadStorageIntegration(data *schema.ResourceData, meta interface{}) error {
 	var k, pType string
 	var v, d interface{}
 	stmt = snowflake.StorageIntegration(data.Id()).Describe()
-	rows, err := db.Query(stmt)
+	rows, err := client.QueryContext(ctx, stmt)
 	if err != nil {
 		return fmt.Errorf("Could not describe storage integration: %w", err)
 	}
@@ -222,68 +228,54 @@ func ReadStorageIntegration(data *schema.ResourceData, meta interface{}) error {
 
 // UpdateStorageIntegration implements schema.UpdateFunc
 func UpdateStorageIntegration(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutUpdate)
+	defer cancel()
+
 	id := data.Id()
 
 	stmt := snowflake.StorageIntegration(id).Alter()
 
-	// This is required in case the only change is to UNSET STORAGE_ALLOWED_LOCATIONS.
-	// Not sure if there is a more elegant way of determining this
-	var runSetStatement bool
-
 	if data.HasChange("comment") {
-		runSetStatement = true
 		stmt.SetString("COMMENT", data.Get("comment").(string))
 	}
 
 	if data.HasChange("type") {
-		runSetStatement = true
 		stmt.SetString("TYPE", data.Get("type").(string))
 	}
 
 	if data.HasChange("enabled") {
-		runSetStatement = true
 		stmt.SetBool(`ENABLED`, data.Get("enabled").(bool))
 	}
 
 	if data.HasChange("storage_allowed_locations") {
-		runSetStatement = true
 		stmt.SetStringList("STORAGE_ALLOWED_LOCATIONS", expandStringList(data.Get("storage_allowed_locations").([]interface{})))
 	}
 
 	// We need to UNSET this if we remove all storage blocked locations. I don't think
 	// this is documented by Snowflake, but this is how it works.
-	//
-	// @TODO move the SQL back to the snowflake package
 	if data.HasChange("storage_blocked_locations") {
 		v := data.Get("storage_blocked_locations").([]interface{})
 		if len(v) == 0 {
-			err := snowflake.Exec(db, fmt.Sprintf(`ALTER STORAGE INTEGRATION %v UNSET STORAGE_BLOCKED_LOCATIONS`, data.Id()))
-			if err != nil {
-				return fmt.Errorf("error unsetting storage_blocked_locations: %w", err)
-			}
+			stmt.Unset("STORAGE_BLOCKED_LOCATIONS")
 		} else {
-			runSetStatement = true
 			stmt.SetStringList("STORAGE_BLOCKED_LOCATIONS", expandStringList(v))
 		}
 	}
 
 	if data.HasChange("storage_provider") {
-		runSetStatement = true
 		setStorageProviderSettings(data, stmt)
 	} else {
 		if data.HasChange("storage_aws_role_arn") {
-			runSetStatement = true
 			stmt.SetString("STORAGE_AWS_ROLE_ARN", data.Get("storage_aws_role_arn").(string))
 		}
 		if data.HasChange("azure_tenant_id") {
-			runSetStatement = true
 			stmt.SetString("AZURE_TENANT_ID", data.Get("azure_tenant_id").(string))
 		}
 	}
 
-	if runSetStatement {
-		if err := snowflake.Exec(db, stmt.Statement()); err != nil {
+	for _, q := range stmt.Statements() {
+		if err := client.ExecContext(ctx, q); err != nil {
 			return fmt.Errorf("error updating storage integration: %w", err)
 		}
 	}
@@ -298,11 +290,13 @@ func DeleteStorageIntegration(data *schema.ResourceData, meta interface{}) error
 
 // StorageIntegrationExists implements schema.ExistsFunc
 func StorageIntegrationExists(data *schema.ResourceData, meta interface{}) (bool, error) {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
 	id := data.Id()
 
 	stmt := snowflake.StorageIntegration(id).Show()
-	rows, err := db.Query(stmt)
+	rows, err := client.QueryContext(ctx, stmt)
 	if err != nil {
 		return false, err
 	}
@@ -1,12 +1,12 @@
 package resources_test
 
 import (
-	"database/sql"
 	"testing"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/stretchr/testify/require"
@@ -31,13 +31,13 @@ func TestStorageIntegrationCreate(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.StorageIntegration().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(
 			`^CREATE STORAGE INTEGRATION "test_storage_integration" COMMENT='great comment' STORAGE_AWS_ROLE_ARN='we-should-probably-validate-this-string' STORAGE_PROVIDER='S3' TYPE='EXTERNAL_STAGE' STORAGE_ALLOWED_LOCATIONS=\('s3://great-bucket/great-path/'\) ENABLED=true$`,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
 		expectReadStorageIntegration(mock)
 
-		err := resources.CreateStorageIntegration(d, db)
+		err := resources.CreateStorageIntegration(d, client)
 		r.NoError(err)
 	})
 }
@@ -47,10 +47,10 @@ func TestStorageIntegrationRead(t *testing.T) {
 
 	d := storageIntegration(t, "test_storage_integration", map[string]interface{}{"name": "test_storage_integration"})
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		expectReadStorageIntegration(mock)
 
-		err := resources.ReadStorageIntegration(d, db)
+		err := resources.ReadStorageIntegration(d, client)
 		r.NoError(err)
 	})
 }
@@ -60,9 +60,9 @@ func TestStorageIntegrationDelete(t *testing.T) {
 
 	d := storageIntegration(t, "drop_it", map[string]interface{}{"name": "drop_it"})
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`DROP STORAGE INTEGRATION "drop_it"`).WillReturnResult(sqlmock.NewResult(1, 1))
-		err := resources.DeleteStorageIntegration(d, db)
+		err := resources.DeleteStorageIntegration(d, client)
 		r.NoError(err)
 	})
 }
@@ -71,7 +71,7 @@ func expectReadStorageIntegration(mock sqlmock.Sqlmock) {
 	showRows := sqlmock.NewRows([]string{
 		"name", "type", "category", "enabled", "created_on"},
 	).AddRow("test_storage_integration", "EXTERNAL_STAGE", "STORAGE", true, "now")
-	mock.ExpectQuery(`^SHOW STORAGE INTEGRATIONS LIKE 'test_storage_integration'$`).WillReturnRows(showRows)
+	mock.ExpectQuery(`^SHOW STORAGE INTEGRATIONS LIKE 'test\\\\_storage\\\\_integration'$`).WillReturnRows(showRows)
 
 	descRows := sqlmock.NewRows([]string{
 		"property", "property_type", "property_value", "property_default",
@@ -8,33 +8,29 @@ import (
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 )
 
-var validTablePrivileges = newPrivilegeSet(
-	privilegeSelect,
-	privilegeInsert,
-	privilegeUpdate,
-	privilegeDelete,
-	privilegeTruncate,
-	privilegeReferences,
-)
+var validTablePrivileges = grantObjectTable.privileges()
 
 var tableGrantSchema = map[string]*schema.Schema{
 	"table_name": {
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "The name of the table on which to grant privileges immediately (only valid if on_future is unset).",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Optional:         true,
+		Description:      "The name of the table on which to grant privileges immediately (only valid if on_future is unset).",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"schema_name": {
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "The name of the schema containing the current or future tables on which to grant privileges.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Optional:         true,
+		Description:      "The name of the schema containing the current or future tables on which to grant privileges.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"database_name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "The name of the database containing the current or future tables on which to grant privileges.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The name of the database containing the current or future tables on which to grant privileges.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"privilege": {
 		Type:         schema.TypeString,
@@ -50,6 +46,15 @@ var tableGrantSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Grants privilege to these roles.",
 		ForceNew:    true,
+		Set:         hashIdentifier,
+	},
+	"database_roles": {
+		Type:        schema.TypeSet,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Optional:    true,
+		Description: "Grants privilege to these database roles, each in the form <database>.<role>.",
+		ForceNew:    true,
+		Set:         hashQualifiedIdentifier,
 	},
 	"shares": {
 		Type:        schema.TypeSet,
@@ -57,6 +62,7 @@ var tableGrantSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Grants privilege to these shares (only valid if on_future is unset).",
 		ForceNew:    true,
+		Set:         hashIdentifier,
 	},
 	"on_future": {
 		Type:          schema.TypeBool,
@@ -82,7 +88,8 @@ func TableGrant() *schema.Resource {
 		Read:   ReadTableGrant,
 		Delete: DeleteTableGrant,
 
-		Schema: tableGrantSchema,
+		Schema:        tableGrantSchema,
+		CustomizeDiff: customizeDiffGrant(grantObjectTable, tableGrantSchema),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
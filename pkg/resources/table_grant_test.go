@@ -1,7 +1,6 @@
 package resources_test
 
 import (
-	"database/sql"
 	"testing"
 	"time"
 
@@ -12,6 +11,7 @@ import (
 
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
 )
 
@@ -36,13 +36,13 @@ func TestTableGrantCreate(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.TableGrant().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`^GRANT SELECT ON TABLE "test-db"."PUBLIC"."test-table" TO ROLE "test-role-1" WITH GRANT OPTION$`).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectExec(`^GRANT SELECT ON TABLE "test-db"."PUBLIC"."test-table" TO ROLE "test-role-2" WITH GRANT OPTION$`).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectExec(`^GRANT SELECT ON TABLE "test-db"."PUBLIC"."test-table" TO SHARE "test-share-1" WITH GRANT OPTION$`).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectExec(`^GRANT SELECT ON TABLE "test-db"."PUBLIC"."test-table" TO SHARE "test-share-2" WITH GRANT OPTION$`).WillReturnResult(sqlmock.NewResult(1, 1))
 		expectReadTableGrant(mock)
-		err := resources.CreateTableGrant(d, db)
+		err := resources.CreateTableGrant(d, client)
 		r.NoError(err)
 	})
 }
@@ -62,6 +62,36 @@ func expectReadTableGrant(mock sqlmock.Sqlmock) {
 	mock.ExpectQuery(`^SHOW GRANTS ON TABLE "test-db"."PUBLIC"."test-table"$`).WillReturnRows(rows)
 }
 
+func TestTableGrantDatabaseRoleCreate(t *testing.T) {
+	r := require.New(t)
+
+	in := map[string]interface{}{
+		"table_name":     "test-table",
+		"schema_name":    "PUBLIC",
+		"database_name":  "test-db",
+		"privilege":      "SELECT",
+		"database_roles": []interface{}{"test-db.test-db-role"},
+	}
+	d := schema.TestResourceDataRaw(t, resources.TableGrant().Schema, in)
+	r.NotNil(d)
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`^GRANT SELECT ON TABLE "test-db"."PUBLIC"."test-table" TO DATABASE ROLE "test-db"."test-db-role"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		rows := sqlmock.NewRows([]string{
+			"created_on", "privilege", "granted_on", "name", "granted_to", "grantee_name", "grant_option", "granted_by",
+		}).AddRow(
+			time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), "SELECT", "TABLE", "test-table", "DATABASE_ROLE", "test-db.test-db-role", false, "bob",
+		).AddRow(
+			time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), "SELECT", "TABLE", "test-table", "USER", "test-user", false, "bob",
+		)
+		mock.ExpectQuery(`^SHOW GRANTS ON TABLE "test-db"."PUBLIC"."test-table"$`).WillReturnRows(rows)
+		err := resources.CreateTableGrant(d, client)
+		r.NoError(err)
+		r.Equal([]interface{}{"test-db.test-db-role"}, d.Get("database_roles").(*schema.Set).List())
+		r.Len(d.Get("roles").(*schema.Set).List(), 0)
+	})
+}
+
 func TestFutureTableGrantCreate(t *testing.T) {
 	r := require.New(t)
 
@@ -76,7 +106,7 @@ func TestFutureTableGrantCreate(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.TableGrant().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(
 			`^GRANT SELECT ON FUTURE TABLES IN SCHEMA "test-db"."PUBLIC" TO ROLE "test-role-1" WITH GRANT OPTION$`,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
@@ -84,7 +114,7 @@ func TestFutureTableGrantCreate(t *testing.T) {
 			`^GRANT SELECT ON FUTURE TABLES IN SCHEMA "test-db"."PUBLIC" TO ROLE "test-role-2" WITH GRANT OPTION$`,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
 		expectReadFutureTableGrant(mock)
-		err := resources.CreateTableGrant(d, db)
+		err := resources.CreateTableGrant(d, client)
 		r.NoError(err)
 	})
 
@@ -100,7 +130,7 @@ func TestFutureTableGrantCreate(t *testing.T) {
 	d = schema.TestResourceDataRaw(t, resources.TableGrant().Schema, in)
 	b.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(
 			`^GRANT SELECT ON FUTURE TABLES IN DATABASE "test-db" TO ROLE "test-role-1"$`,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
@@ -108,11 +138,30 @@ func TestFutureTableGrantCreate(t *testing.T) {
 			`^GRANT SELECT ON FUTURE TABLES IN DATABASE "test-db" TO ROLE "test-role-2"$`,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
 		expectReadFutureTableDatabaseGrant(mock)
-		err := resources.CreateTableGrant(d, db)
+		err := resources.CreateTableGrant(d, client)
 		b.NoError(err)
 	})
 }
 
+func TestFutureTableGrantCreateShare(t *testing.T) {
+	r := require.New(t)
+
+	in := map[string]interface{}{
+		"on_future":     true,
+		"schema_name":   "PUBLIC",
+		"database_name": "test-db",
+		"privilege":     "SELECT",
+		"shares":        []interface{}{"test-share"},
+	}
+	d := schema.TestResourceDataRaw(t, resources.TableGrant().Schema, in)
+	r.NotNil(d)
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		err := resources.CreateTableGrant(d, client)
+		r.EqualError(err, "privileges on future TABLES cannot be granted to SHARE test-share")
+	})
+}
+
 func expectReadFutureTableGrant(mock sqlmock.Sqlmock) {
 	rows := sqlmock.NewRows([]string{
 		"created_on", "privilege", "grant_on", "name", "grant_to", "grantee_name", "grant_option",
@@ -2,7 +2,7 @@ package resources
 
 import (
 	"bytes"
-	"database/sql"
+	"context"
 	"encoding/csv"
 	"fmt"
 	"log"
@@ -26,28 +26,32 @@ var taskSchema = map[string]*schema.Schema{
 		Description: "Specifies if the task should be started (enabled) after creation or should remain suspended (default).",
 	},
 	"name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "Specifies the identifier for the task; must be unique for the database and schema in which the task is created.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "Specifies the identifier for the task; must be unique for the database and schema in which the task is created.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"database": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "The database in which to create the task.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The database in which to create the task.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"schema": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "The schema in which to create the task.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The schema in which to create the task.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"warehouse": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "The warehouse the task will use.",
-		ForceNew:    false,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The warehouse the task will use.",
+		ForceNew:         false,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"schedule": {
 		Type:        schema.TypeString,
@@ -72,9 +76,10 @@ var taskSchema = map[string]*schema.Schema{
 		Description: "Specifies a comment for the task.",
 	},
 	"after": {
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Specifies the predecessor task in the same database and schema of the current task. When a run of the predecessor task finishes successfully, it triggers this task (after a brief lag).",
+		Type:             schema.TypeString,
+		Optional:         true,
+		Description:      "Specifies the predecessor task in the same database and schema of the current task. When a run of the predecessor task finishes successfully, it triggers this task (after a brief lag).",
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"when": {
 		Type:        schema.TypeString,
@@ -82,13 +87,19 @@ var taskSchema = map[string]*schema.Schema{
 		Description: "Specifies a Boolean SQL expression; multiple conditions joined with AND/OR are supported.",
 	},
 	"sql_statement": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "Any single SQL statement, or a call to a stored procedure, executed when the task runs.",
-		ForceNew:    false,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "Any single SQL statement, or a call to a stored procedure, executed when the task runs.",
+		ForceNew:         false,
+		DiffSuppressFunc: DiffSuppressStatement,
 	},
 }
 
+// taskParameters are the attributes of taskSchema read from SHOW PARAMETERS
+var taskParameters = Properties{
+	{Attribute: "user_task_timeout_ms", Type: IntProperty, ObjectParameter: true},
+}
+
 type taskID struct {
 	DatabaseName string
 	SchemaName   string
@@ -122,10 +133,10 @@ func difference(a, b map[string]interface{}) map[string]interface{} {
 }
 
 // getActiveRootTask tries to retrieve the root of current task or returns the current (standalone) task
-func getActiveRootTask(data *schema.ResourceData, meta interface{}) (*snowflake.TaskBuilder, error) {
+func getActiveRootTask(ctx context.Context, data *schema.ResourceData, meta interface{}) (*snowflake.TaskBuilder, error) {
 	log.Println("[DEBUG] retrieving root task")
 
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
 	database := data.Get("database").(string)
 	dbSchema := data.Get("schema").(string)
 	name := data.Get("name").(string)
@@ -142,13 +153,14 @@ func getActiveRootTask(data *schema.ResourceData, meta interface{}) (*snowflake.
 	}
 
 	for {
-		builder := snowflake.Task(name, database, dbSchema)
-		q := builder.Show()
-		row := snowflake.QueryRow(db, q)
-		task, err := snowflake.ScanTask(row)
-
-		if err != nil && name != data.Get("name").(string) {
-			return nil, errors.Wrapf(err, "failed to locate the root node of: %v", name)
+		// The tasks of the schema are shown once for the whole chain.
+		task, err := snowflake.ReadTask(ctx, client, database, dbSchema, name)
+		if err != nil {
+			if name != data.Get("name").(string) {
+				return nil, errors.Wrapf(err, "failed to locate the root node of: %v", name)
+			}
+			// The task itself does not exist yet.
+			return nil, nil
 		}
 
 		if task.Predecessors == nil {
@@ -165,18 +177,18 @@ func getActiveRootTask(data *schema.ResourceData, meta interface{}) (*snowflake.
 }
 
 // getActiveRootTaskAndSuspend retrieves the root task and suspends it
-func getActiveRootTaskAndSuspend(data *schema.ResourceData, meta interface{}) (*snowflake.TaskBuilder, error) {
-	db := meta.(*sql.DB)
+func getActiveRootTaskAndSuspend(ctx context.Context, data *schema.ResourceData, meta interface{}) (*snowflake.TaskBuilder, error) {
+	client := meta.(*snowflake.Client)
 	name := data.Get("name").(string)
 
-	root, err := getActiveRootTask(data, meta)
+	root, err := getActiveRootTask(ctx, data, meta)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error retrieving root task %v", name)
 	}
 
 	if root != nil {
 		qr := root.Suspend()
-		err = snowflake.Exec(db, qr)
+		err = client.ExecContext(ctx, qr)
 		if err != nil {
 			return nil, errors.Wrapf(err, "error suspending root task %v", name)
 		}
@@ -185,7 +197,11 @@ func getActiveRootTaskAndSuspend(data *schema.ResourceData, meta interface{}) (*
 	return root, nil
 }
 
-func resumeTask(root *snowflake.TaskBuilder, meta interface{}) {
+// resumeTask resumes the root task. It does not derive its context from the
+// operation's or the provider's stop context, so that a cancelled or timed out
+// operation does not leave the root suspended, but it is still bounded by the
+// update timeout.
+func resumeTask(root *snowflake.TaskBuilder, data *schema.ResourceData, meta interface{}) {
 	if root == nil {
 		return
 	}
@@ -194,9 +210,13 @@ func resumeTask(root *snowflake.TaskBuilder, meta interface{}) {
 		return
 	}
 
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx := snowflake.WithAuditResource(context.Background(), client.Resource, data.Id())
+	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
 	qr := root.Resume()
-	err := snowflake.Exec(db, qr)
+	err := client.ExecContext(ctx, qr)
 	if err != nil {
 		log.Fatal(errors.Wrapf(err, "error resuming root task %v", root.QualifiedName()))
 	}
@@ -245,7 +265,10 @@ func Task() *schema.Resource {
 
 // ReadTask implements schema.ReadFunc
 func ReadTask(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
+
 	taskID, err := taskIDFromString(data.Id())
 	if err != nil {
 		return err
@@ -256,9 +279,7 @@ func ReadTask(data *schema.ResourceData, meta interface{}) error {
 	name := taskID.TaskName
 
 	builder := snowflake.Task(name, database, schema)
-	q := builder.Show()
-	row := snowflake.QueryRow(db, q)
-	t, err := snowflake.ScanTask(row)
+	t, err := snowflake.ReadTask(ctx, client, database, schema, name)
 	if err != nil {
 		return err
 	}
@@ -315,28 +336,26 @@ func ReadTask(data *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	q = builder.ShowParameters()
-	paramRows, err := snowflake.Query(db, q)
+	params, err := readParameters(ctx, client, builder.ShowParameters(), snowflake.TaskType)
 	if err != nil {
 		return err
 	}
-	params, err := snowflake.ScanTaskParameters(paramRows)
+	// The timeout is a parameter of the task, but it has an attribute of its
+	// own. It reads as 0 once it is no longer set on the task.
+	err = taskParameters.readObjectParameters(data, params)
 	if err != nil {
 		return err
 	}
-
-	if len(params) > 0 {
-		paramMap := map[string]interface{}{}
-		for _, param := range params {
-			log.Printf("[TRACE] %+v\n", param)
-			if param.Value == param.DefaultValue {
-				continue
-			}
-
-			paramMap[param.Key] = param.Value
+	paramMap := map[string]interface{}{}
+	for k, v := range params {
+		if k == "USER_TASK_TIMEOUT_MS" {
+			continue
 		}
-
-		data.Set("session_parameters", paramMap)
+		paramMap[k] = v
+	}
+	err = data.Set("session_parameters", paramMap)
+	if err != nil {
+		return err
 	}
 
 	return nil
@@ -346,7 +365,10 @@ func ReadTask(data *schema.ResourceData, meta interface{}) error {
 func CreateTask(data *schema.ResourceData, meta interface{}) error {
 
 	var err error
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	database := data.Get("database").(string)
 	dbSchema := data.Get("schema").(string)
 	name := data.Get("name").(string)
@@ -376,11 +398,11 @@ func CreateTask(data *schema.ResourceData, meta interface{}) error {
 	}
 
 	if v, ok := data.GetOk("after"); ok {
-		root, err := getActiveRootTaskAndSuspend(data, meta)
+		root, err := getActiveRootTaskAndSuspend(ctx, data, meta)
 		if err != nil {
 			return err
 		}
-		defer resumeTask(root, meta)
+		defer resumeTask(root, data, meta)
 
 		builder.WithDependency(v.(string))
 	}
@@ -390,14 +412,14 @@ func CreateTask(data *schema.ResourceData, meta interface{}) error {
 	}
 
 	q := builder.Create()
-	err = snowflake.Exec(db, q)
+	err = client.ExecContext(ctx, q)
 	if err != nil {
 		return errors.Wrapf(err, "error creating task %v", name)
 	}
 
 	if enabled {
 		q = builder.Resume()
-		err = snowflake.Exec(db, q)
+		err = client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error starting task %v", name)
 		}
@@ -427,22 +449,25 @@ func UpdateTask(data *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutUpdate)
+	defer cancel()
+
 	database := taskID.DatabaseName
 	dbSchema := taskID.SchemaName
 	name := taskID.TaskName
 	builder := snowflake.Task(name, database, dbSchema)
 
-	root, err := getActiveRootTaskAndSuspend(data, meta)
+	root, err := getActiveRootTaskAndSuspend(ctx, data, meta)
 	if err != nil {
 		return err
 	}
-	defer resumeTask(root, meta)
+	defer resumeTask(root, data, meta)
 
 	if data.HasChange("warehouse") {
 		_, new := data.GetChange("warehouse")
 		q := builder.ChangeWarehouse(new.(string))
-		err := snowflake.Exec(db, q)
+		err := client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error updating warehouse on task %v", data.Id())
 		}
@@ -457,7 +482,7 @@ func UpdateTask(data *schema.ResourceData, meta interface{}) error {
 		} else {
 			q = builder.ChangeSchedule(new.(string))
 		}
-		err := snowflake.Exec(db, q)
+		err := client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error updating schedule on task %v", data.Id())
 		}
@@ -472,7 +497,7 @@ func UpdateTask(data *schema.ResourceData, meta interface{}) error {
 		} else {
 			q = builder.ChangeTimeout(new.(int))
 		}
-		err := snowflake.Exec(db, q)
+		err := client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error updating user task timeout on task %v", data.Id())
 		}
@@ -487,7 +512,7 @@ func UpdateTask(data *schema.ResourceData, meta interface{}) error {
 		} else {
 			q = builder.ChangeComment(new.(string))
 		}
-		err := snowflake.Exec(db, q)
+		err := client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error updating comment on task %v", data.Id())
 		}
@@ -505,16 +530,16 @@ func UpdateTask(data *schema.ResourceData, meta interface{}) error {
 
 		if enabled {
 			q = builder.Suspend()
-			err = snowflake.Exec(db, q)
+			err = client.ExecContext(ctx, q)
 			if err != nil {
 				return errors.Wrapf(err, "error suspending task %v", data.Id())
 			}
-			defer resumeTask(builder, meta)
+			defer resumeTask(builder, data, meta)
 		}
 
 		if old != "" {
 			q = builder.RemoveDependency(old.(string))
-			err = snowflake.Exec(db, q)
+			err = client.ExecContext(ctx, q)
 			if err != nil {
 				return errors.Wrapf(err, "error removing old after dependency from task %v", data.Id())
 			}
@@ -522,7 +547,7 @@ func UpdateTask(data *schema.ResourceData, meta interface{}) error {
 
 		if new != "" {
 			q = builder.AddDependency(new.(string))
-			err := snowflake.Exec(db, q)
+			err := client.ExecContext(ctx, q)
 			if err != nil {
 				return errors.Wrapf(err, "error adding after dependency on task %v", data.Id())
 			}
@@ -549,7 +574,7 @@ func UpdateTask(data *schema.ResourceData, meta interface{}) error {
 
 		if len(remove) > 0 {
 			q = builder.RemoveSessionParameters(remove)
-			err := snowflake.Exec(db, q)
+			err := client.ExecContext(ctx, q)
 			if err != nil {
 				return errors.Wrapf(err, "error removing session_parameters on task %v", data.Id())
 			}
@@ -557,7 +582,7 @@ func UpdateTask(data *schema.ResourceData, meta interface{}) error {
 
 		if len(add) > 0 {
 			q = builder.AddSessionParameters(add)
-			err := snowflake.Exec(db, q)
+			err := client.ExecContext(ctx, q)
 			if err != nil {
 				return errors.Wrapf(err, "error adding session_parameters to task %v", data.Id())
 			}
@@ -569,7 +594,7 @@ func UpdateTask(data *schema.ResourceData, meta interface{}) error {
 	if data.HasChange("when") {
 		_, new := data.GetChange("when")
 		q := builder.ChangeCondition(new.(string))
-		err := snowflake.Exec(db, q)
+		err := client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error updating when condition on task %v", data.Id())
 		}
@@ -579,7 +604,7 @@ func UpdateTask(data *schema.ResourceData, meta interface{}) error {
 	if data.HasChange("sql_statement") {
 		_, new := data.GetChange("sql_statement")
 		q := builder.ChangeSqlStatement(new.(string))
-		err := snowflake.Exec(db, q)
+		err := client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error updating sql statement on task %v", data.Id())
 		}
@@ -602,7 +627,7 @@ func UpdateTask(data *schema.ResourceData, meta interface{}) error {
 			}
 		}
 
-		err := snowflake.Exec(db, q)
+		err := client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error updating task state %v", data.Id())
 		}
@@ -615,7 +640,10 @@ func UpdateTask(data *schema.ResourceData, meta interface{}) error {
 
 // DeleteTask implements schema.DeleteFunc
 func DeleteTask(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutDelete)
+	defer cancel()
+
 	taskID, err := taskIDFromString(data.Id())
 	if err != nil {
 		return err
@@ -625,18 +653,18 @@ func DeleteTask(data *schema.ResourceData, meta interface{}) error {
 	schema := taskID.SchemaName
 	name := taskID.TaskName
 
-	root, err := getActiveRootTaskAndSuspend(data, meta)
+	root, err := getActiveRootTaskAndSuspend(ctx, data, meta)
 	if err != nil {
 		return err
 	}
 
 	// only resume the root when not a standalone task
 	if root != nil && name != root.Name() {
-		defer resumeTask(root, meta)
+		defer resumeTask(root, data, meta)
 	}
 
 	q := snowflake.Task(name, database, schema).Drop()
-	err = snowflake.Exec(db, q)
+	err = client.ExecContext(ctx, q)
 	if err != nil {
 		return errors.Wrapf(err, "error deleting task %v", data.Id())
 	}
@@ -648,7 +676,9 @@ func DeleteTask(data *schema.ResourceData, meta interface{}) error {
 
 // TaskExists implements schema.ExistsFunc
 func TaskExists(data *schema.ResourceData, meta interface{}) (bool, error) {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
 	taskID, err := taskIDFromString(data.Id())
 	if err != nil {
 		return false, err
@@ -659,7 +689,7 @@ func TaskExists(data *schema.ResourceData, meta interface{}) (bool, error) {
 	name := taskID.TaskName
 
 	q := snowflake.Task(name, database, schema).Show()
-	rows, err := db.Query(q)
+	rows, err := client.QueryContext(ctx, q)
 	if err != nil {
 		return false, err
 	}
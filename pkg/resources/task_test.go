@@ -1,12 +1,12 @@
 package resources_test
 
 import (
-	"database/sql"
 	"testing"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/stretchr/testify/require"
@@ -34,7 +34,7 @@ func TestTaskCreate(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.Task().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(
 			`^CREATE TASK "test_db"."test_schema"."test_task" WAREHOUSE = "much_warehouse" COMMENT = 'wow comment' AS select hi from hello$`,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
@@ -45,8 +45,10 @@ func TestTaskCreate(t *testing.T) {
 
 		expectReadTask(mock)
 		expectReadTaskParams(mock)
-		err := resources.CreateTask(d, db)
+		err := resources.CreateTask(d, client)
 		r.NoError(err)
+		r.Equal(map[string]interface{}{"TIMESTAMP_INPUT_FORMAT": "YYYY-MM-DD HH24"}, d.Get("session_parameters"))
+		r.Equal(60000, d.Get("user_task_timeout_ms"))
 	})
 }
 
@@ -54,12 +56,42 @@ func expectReadTask(mock sqlmock.Sqlmock) {
 	rows := sqlmock.NewRows([]string{
 		"created_on", "name", "database_name", "schema_name", "owner", "comment", "warehouse", "schedule", "predecessors", "state", "definition", "condition"},
 	).AddRow("2020-05-14 17:20:50.088 +0000", "test_task", "test_db", "test_schema", "ACCOUNTADMIN", "wow comment", "", "", "", "started", "select hi from hello", "")
-	mock.ExpectQuery(`^SHOW TASKS LIKE 'test_task' IN DATABASE "test_db"$`).WillReturnRows(rows)
+	mock.ExpectQuery(`^SHOW TASKS IN SCHEMA "test_db"."test_schema"$`).WillReturnRows(rows)
 }
 
 func expectReadTaskParams(mock sqlmock.Sqlmock) {
 	rows := sqlmock.NewRows([]string{
 		"key", "value", "default", "level", "description", "type"},
-	).AddRow("ABORT_DETACHED_QUERY", "false", "false", "", "wow desc", "BOOLEAN")
+	).AddRow("ABORT_DETACHED_QUERY", "false", "false", "", "wow desc", "BOOLEAN").
+		AddRow("TIMEZONE", "UTC", "America/Los_Angeles", "ACCOUNT", "wow desc", "STRING").
+		AddRow("TIMESTAMP_INPUT_FORMAT", "YYYY-MM-DD HH24", "AUTO", "TASK", "wow desc", "STRING").
+		AddRow("USER_TASK_TIMEOUT_MS", "60000", "3600000", "TASK", "wow desc", "NUMBER")
 	mock.ExpectQuery(`^SHOW PARAMETERS IN TASK "test_db"."test_schema"."test_task"$`).WillReturnRows(rows)
 }
+
+func TestTaskReadResetsTimeout(t *testing.T) {
+	r := require.New(t)
+
+	in := map[string]interface{}{
+		"name":                 "test_task",
+		"database":             "test_db",
+		"schema":               "test_schema",
+		"sql_statement":        "select hi from hello",
+		"user_task_timeout_ms": 60000,
+	}
+	d := schema.TestResourceDataRaw(t, resources.Task().Schema, in)
+	d.SetId("test_db|test_schema|test_task")
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		expectReadTask(mock)
+		// the timeout was unset outside of Terraform and is inherited again
+		rows := sqlmock.NewRows([]string{"key", "value", "default", "level", "description", "type"}).
+			AddRow("USER_TASK_TIMEOUT_MS", "3600000", "3600000", "", "wow desc", "NUMBER")
+		mock.ExpectQuery(`^SHOW PARAMETERS IN TASK "test_db"."test_schema"."test_task"$`).WillReturnRows(rows)
+
+		err := resources.ReadTask(d, client)
+		r.NoError(err)
+		r.Equal(0, d.Get("user_task_timeout_ms"))
+		r.Empty(d.Get("session_parameters"))
+	})
+}
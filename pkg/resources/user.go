@@ -1,28 +1,47 @@
 package resources
 
 import (
-	"database/sql"
 	"strings"
 
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pkg/errors"
 )
 
-var userProperties = []string{
-	"comment",
-	"login_name",
-	"password",
-	"disabled",
-	"default_namespace",
-	"default_role",
-	"default_warehouse",
-	"rsa_public_key",
-	"rsa_public_key_2",
-	"must_change_password",
-	"email",
-	"display_name",
-	"first_name",
-	"last_name",
+// DEFAULT_ROLE, DEFAULT_WAREHOUSE and DEFAULT_NAMESPACE are strings rather
+// than identifiers: Snowflake only resolves them at login, so the objects need
+// not exist.
+var userProperties = Properties{
+	{Attribute: "comment", Type: StringProperty},
+	{Attribute: "login_name", Type: StringProperty},
+	{Attribute: "password", Type: StringProperty},
+	{Attribute: "disabled", Type: BoolProperty},
+	{Attribute: "default_namespace", Type: StringProperty},
+	{Attribute: "default_role", Type: StringProperty},
+	{Attribute: "default_warehouse", Type: StringProperty},
+	{Attribute: "rsa_public_key", Type: StringProperty, Normalize: snowflake.NormalizeRSAPublicKey},
+	{Attribute: "rsa_public_key_2", Type: StringProperty, Normalize: snowflake.NormalizeRSAPublicKey},
+	{Attribute: "must_change_password", Type: BoolProperty},
+	{Attribute: "email", Type: StringProperty},
+	{Attribute: "display_name", Type: StringProperty},
+	{Attribute: "first_name", Type: StringProperty},
+	{Attribute: "last_name", Type: StringProperty},
+}
+
+// userParameterProperties are the attributes of the parameters block. A
+// parameter removed from the block is unset, so that the user inherits the
+// account's value again.
+var userParameterProperties = Properties{
+	{Attribute: "parameters.0.timezone", Parameter: "timezone", Type: StringProperty, ObjectParameter: true, Unset: UnsetAlways},
+	{Attribute: "parameters.0.statement_timeout_in_seconds", Parameter: "statement_timeout_in_seconds", Type: IntProperty, ObjectParameter: true, Unset: UnsetAlways},
+	{Attribute: "parameters.0.query_tag", Parameter: "query_tag", Type: StringProperty, ObjectParameter: true, Unset: UnsetAlways},
+	{Attribute: "parameters.0.network_policy", Parameter: "network_policy", Type: IdentifierProperty, ObjectParameter: true, Unset: UnsetAlways},
+	// These are properties rather than parameters, and Snowflake reports the
+	// time left rather than the value set, so they are not read back.
+	{Attribute: "parameters.0.mins_to_unlock", Parameter: "mins_to_unlock", Type: IntProperty, Unset: UnsetAlways},
+	{Attribute: "parameters.0.days_to_expiry", Parameter: "days_to_expiry", Type: IntProperty, Unset: UnsetAlways},
+	{Attribute: "parameters.0.mins_to_bypass_mfa", Parameter: "mins_to_bypass_mfa", Type: IntProperty, Unset: UnsetAlways},
 }
 
 var diffCaseInsensitive = func(k, old, new string, d *schema.ResourceData) bool {
@@ -31,9 +50,11 @@ var diffCaseInsensitive = func(k, old, new string, d *schema.ResourceData) bool
 
 var userSchema = map[string]*schema.Schema{
 	"name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "Name of the user. Note that if you do not supply login_name this will be used as login_name. [doc](https://docs.snowflake.net/manuals/sql-reference/sql/create-user.html#required-parameters)"},
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "Name of the user. Note that if you do not supply login_name this will be used as login_name. [doc](https://docs.snowflake.net/manuals/sql-reference/sql/create-user.html#required-parameters)",
+		DiffSuppressFunc: diffIdentifier,
+	},
 	"login_name": {
 		Type:        schema.TypeString,
 		Optional:    true,
@@ -60,9 +81,10 @@ var userSchema = map[string]*schema.Schema{
 		Computed: true,
 	},
 	"default_warehouse": {
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Specifies the virtual warehouse that is active by default for the user’s session upon login.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		Description:      "Specifies the virtual warehouse that is active by default for the user’s session upon login.",
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"default_namespace": {
 		Type:             schema.TypeString,
@@ -71,20 +93,41 @@ var userSchema = map[string]*schema.Schema{
 		Description:      "Specifies the namespace (database only or database and schema) that is active by default for the user’s session upon login.",
 	},
 	"default_role": {
-		Type:        schema.TypeString,
-		Optional:    true,
-		Computed:    true,
-		Description: "Specifies the role that is active by default for the user’s session upon login.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		Description:      "Specifies the role that is active by default for the user’s session upon login.",
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"rsa_public_key": {
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Specifies the user’s RSA public key; used for key-pair authentication. Must be on 1 line without header and trailer.",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "Specifies the user’s RSA public key; used for key-pair authentication. It may be a full PEM, which is stored without header, trailer and line breaks.",
+		ValidateFunc: validateRSAPublicKey,
+		StateFunc:    normalizeRSAPublicKey,
 	},
 	"rsa_public_key_2": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "Specifies the user’s second RSA public key; used to rotate the public and private keys for key-pair authentication based on an expiration schedule set by your organization. It may be a full PEM, which is stored without header, trailer and line breaks. It cannot be set under rotate_rsa_public_key.",
+		ValidateFunc: validateRSAPublicKey,
+		StateFunc:    normalizeRSAPublicKey,
+	},
+	"rsa_public_key_fp": {
 		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Fingerprint of the user’s RSA public key, as shown by DESCRIBE USER.",
+	},
+	"rsa_public_key_2_fp": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Fingerprint of the user’s second RSA public key, as shown by DESCRIBE USER.",
+	},
+	"rotate_rsa_public_key": {
+		Type:        schema.TypeBool,
 		Optional:    true,
-		Description: "Specifies the user’s second RSA public key; used to rotate the public and private keys for key-pair authentication based on an expiration schedule set by your organization. Must be on 1 line without header and trailer.",
+		Default:     false,
+		Description: "Rotates rsa_public_key without downtime: a new key is first set as RSA_PUBLIC_KEY_2, so that both keys work, and the next apply promotes it to RSA_PUBLIC_KEY and unsets RSA_PUBLIC_KEY_2. The second slot is then managed by the provider, so rsa_public_key_2 cannot be set. Once rotation is turned off, a key left in the second slot is replaced by rsa_public_key_2, or unset.",
 	},
 	"has_rsa_public_key": {
 		Type:        schema.TypeBool,
@@ -117,6 +160,61 @@ var userSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Last name of the user.",
 	},
+	"parameters": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Parameters set on the user: timezone, statement_timeout_in_seconds, query_tag, network_policy, mins_to_unlock, days_to_expiry and mins_to_bypass_mfa. Those set outside of Terraform are read back from SHOW PARAMETERS IN USER, so that drift is detected.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"timezone": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Specifies the time zone of the user's sessions, e.g. America/Los_Angeles.",
+				},
+				"statement_timeout_in_seconds": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      intUnset,
+					ValidateFunc: validation.IntBetween(0, 604800),
+					Description:  "Specifies the time, in seconds, after which a running SQL statement of the user is canceled by the system.",
+				},
+				"query_tag": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringLenBetween(0, 2000),
+					Description:  "Specifies the tag of the queries of the user's sessions.",
+				},
+				"network_policy": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					DiffSuppressFunc: diffIdentifier,
+					Description:      "Specifies the network policy that is active for the user, instead of the account's.",
+				},
+				"mins_to_unlock": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      intUnset,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "Specifies the number of minutes until the temporary lock on the user's login is cleared. It is not read back, as Snowflake reports the minutes left.",
+				},
+				"days_to_expiry": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      intUnset,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "Specifies the number of days after which the user is disabled. It is not read back, as Snowflake reports the days left.",
+				},
+				"mins_to_bypass_mfa": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      intUnset,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "Specifies the number of minutes for which the user can bypass MFA. It is not read back, as Snowflake reports the minutes left.",
+				},
+			},
+		},
+	},
 
 	//    DISPLAY_NAME = <string>
 	//    FIRST_NAME = <string>
@@ -143,6 +241,8 @@ func User() *schema.Resource {
 		Delete: DeleteUser,
 		Exists: UserExists,
 
+		CustomizeDiff: customizeDiffUser,
+
 		Schema: userSchema,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -153,15 +253,17 @@ func User() *schema.Resource {
 // func DeleteResource(t string, builder func(string) *snowflake.Builder) func(*schema.ResourceData, interface{}) error {
 
 func CreateUser(data *schema.ResourceData, meta interface{}) error {
-	return CreateResource("user", userProperties, userSchema, snowflake.User, ReadUser)(data, meta)
+	return CreateResource("user", append(userProperties, userParameterProperties...), snowflake.User, ReadUser)(data, meta)
 }
 
 func UserExists(data *schema.ResourceData, meta interface{}) (bool, error) {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
 	id := data.Id()
 
 	stmt := snowflake.User(id).Show()
-	rows, err := db.Query(stmt)
+	rows, err := client.QueryContext(ctx, stmt)
 	if err != nil {
 		return false, err
 	}
@@ -174,11 +276,14 @@ func UserExists(data *schema.ResourceData, meta interface{}) (bool, error) {
 }
 
 func ReadUser(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
+
 	id := data.Id()
 
 	stmt := snowflake.User(id).Show()
-	row := snowflake.QueryRow(db, stmt)
+	row := client.QueryRowContext(ctx, stmt)
 
 	u, err := snowflake.ScanUser(row)
 	if err != nil {
@@ -240,12 +345,258 @@ func ReadUser(data *schema.ResourceData, meta interface{}) error {
 	}
 
 	err = data.Set("last_name", u.LastName.String)
+	if err != nil {
+		return err
+	}
 
-	return err
+	rows, err := client.QueryContext(ctx, snowflake.User(id).Describe())
+	if err != nil {
+		return err
+	}
+	desc, err := snowflake.ScanUserDescription(rows)
+	if err != nil {
+		return err
+	}
+	err = data.Set("rsa_public_key_fp", desc["RSA_PUBLIC_KEY_FP"])
+	if err != nil {
+		return err
+	}
+	err = data.Set("rsa_public_key_2_fp", desc["RSA_PUBLIC_KEY_2_FP"])
+	if err != nil {
+		return err
+	}
+
+	params, err := readParameters(ctx, client, snowflake.User(id).ShowParameters(), snowflake.UserType)
+	if err != nil {
+		return err
+	}
+	return readUserParameters(data, params)
+}
+
+// readUserParameters sets the parameters block from params, the parameters
+// set on the user. The block is left out when none of them is set and data
+// has no block either, so that a configuration without it has no diff. A block
+// that is configured, even empty or with only defaults, is kept.
+func readUserParameters(data *schema.ResourceData, params map[string]string) error {
+	block := map[string]interface{}{}
+	empty := true
+	for _, p := range userParameterProperties {
+		name := p.parameter()
+		if !p.ObjectParameter {
+			v, ok := p.get(data)
+			block[name] = v
+			empty = empty && !ok
+			continue
+		}
+		value, ok, err := p.parameterValue(params)
+		if err != nil {
+			return err
+		}
+		block[name] = value
+		empty = empty && !ok
+	}
+
+	if empty && data.Get("parameters.#").(int) == 0 {
+		return data.Set("parameters", []interface{}{})
+	}
+	return data.Set("parameters", []interface{}{block})
 }
 
 func UpdateUser(data *schema.ResourceData, meta interface{}) error {
-	return UpdateResource("user", userProperties, userSchema, snowflake.User, ReadUser)(data, meta)
+	props := append(userProperties, userParameterProperties...)
+	if !data.Get("rotate_rsa_public_key").(bool) {
+		read := func(data *schema.ResourceData, meta interface{}) error {
+			err := syncRSAPublicKey2(data, meta)
+			if err != nil {
+				return err
+			}
+			return ReadUser(data, meta)
+		}
+		return UpdateResource("user", props, snowflake.User, read)(data, meta)
+	}
+
+	// The keys are rotated once the user has its new name, i.e. right
+	// before it is read back.
+	read := func(data *schema.ResourceData, meta interface{}) error {
+		err := rotateRSAPublicKey(data, meta)
+		if err != nil {
+			return err
+		}
+		return ReadUser(data, meta)
+	}
+	return UpdateResource("user", props.without("rsa_public_key", "rsa_public_key_2"), snowflake.User, read)(data, meta)
+}
+
+// rotateRSAPublicKey moves the user one step closer to having rsa_public_key
+// as its only key: a new key is staged as RSA_PUBLIC_KEY_2, and a staged key
+// is promoted to RSA_PUBLIC_KEY.
+func rotateRSAPublicKey(data *schema.ResourceData, meta interface{}) error {
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutUpdate)
+	defer cancel()
+
+	name := data.Get("name").(string)
+	key := snowflake.NormalizeRSAPublicKey(data.Get("rsa_public_key").(string))
+
+	rows, err := client.QueryContext(ctx, snowflake.User(name).Describe())
+	if err != nil {
+		return err
+	}
+	desc, err := snowflake.ScanUserDescription(rows)
+	if err != nil {
+		return err
+	}
+	current, staged := desc["RSA_PUBLIC_KEY_FP"], desc["RSA_PUBLIC_KEY_2_FP"]
+
+	fp := ""
+	if key != "" {
+		fp, err = snowflake.RSAPublicKeyFingerprint(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	qb := snowflake.User(name).Alter()
+	switch {
+	case current == fp:
+		// Done, but for a key left in the second slot
+		if staged != "" {
+			qb.Unset("rsa_public_key_2")
+		}
+	case key == "":
+		qb.Unset("rsa_public_key")
+		if staged != "" {
+			qb.Unset("rsa_public_key_2")
+		}
+	case current == "":
+		// There is nothing to rotate from
+		qb.SetString("rsa_public_key", key)
+	case staged == fp:
+		qb.SetString("rsa_public_key", key)
+		qb.Unset("rsa_public_key_2")
+	default:
+		qb.SetString("rsa_public_key_2", key)
+	}
+
+	for _, stmt := range qb.Statements() {
+		err = client.ExecContext(ctx, stmt)
+		if err != nil {
+			return errors.Wrapf(err, "error rotating the public key of user %v", name)
+		}
+	}
+	return nil
+}
+
+// syncRSAPublicKey2 sets the second slot to rsa_public_key_2, or unsets it,
+// when it holds another key though the attribute is unchanged, e.g. a key
+// left staged by rotate_rsa_public_key
+func syncRSAPublicKey2(data *schema.ResourceData, meta interface{}) error {
+	if data.HasChange("rsa_public_key_2") {
+		return nil
+	}
+	key := snowflake.NormalizeRSAPublicKey(data.Get("rsa_public_key_2").(string))
+	fp, err := rsaPublicKeyFingerprint(key)
+	if err != nil {
+		return err
+	}
+	staged, _ := data.GetChange("rsa_public_key_2_fp")
+	if staged.(string) == fp {
+		return nil
+	}
+
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutUpdate)
+	defer cancel()
+
+	name := data.Get("name").(string)
+	qb := snowflake.User(name).Alter()
+	if key == "" {
+		qb.Unset("rsa_public_key_2")
+	} else {
+		qb.SetString("rsa_public_key_2", key)
+	}
+	for _, stmt := range qb.Statements() {
+		err = client.ExecContext(ctx, stmt)
+		if err != nil {
+			return errors.Wrapf(err, "error setting the second public key of user %v", name)
+		}
+	}
+	return nil
+}
+
+// customizeDiffUser plans the fingerprints of changed keys as unknown. Under
+// rotate_rsa_public_key, it also plans the promotion of a staged key, which
+// has no diff of its own as the configuration is unchanged. Without it, it
+// plans the replacement of a key in the second slot that is not
+// rsa_public_key_2, e.g. one left staged when rotation was turned off.
+func customizeDiffUser(d *schema.ResourceDiff, meta interface{}) error {
+	rotate := d.Get("rotate_rsa_public_key").(bool)
+	if rotate && d.Get("rsa_public_key_2").(string) != "" {
+		return errors.New("rsa_public_key_2 cannot be set under rotate_rsa_public_key, which manages the second slot")
+	}
+
+	if rsaPublicKeyChanged(d, "rsa_public_key") || rsaPublicKeyChanged(d, "rsa_public_key_2") || d.HasChange("rotate_rsa_public_key") {
+		return setNewComputedFingerprints(d)
+	}
+	if d.Id() == "" {
+		return nil
+	}
+
+	if !rotate {
+		if !d.NewValueKnown("rsa_public_key_2") {
+			return nil
+		}
+		fp2, err := rsaPublicKeyFingerprint(d.Get("rsa_public_key_2").(string))
+		if err == nil && d.Get("rsa_public_key_2_fp").(string) != fp2 {
+			return setNewComputedFingerprints(d)
+		}
+		return nil
+	}
+
+	if !d.NewValueKnown("rsa_public_key") {
+		return nil
+	}
+	fp, err := rsaPublicKeyFingerprint(d.Get("rsa_public_key").(string))
+	if err != nil {
+		return nil
+	}
+	if d.Get("rsa_public_key_fp").(string) != fp || d.Get("rsa_public_key_2_fp").(string) != "" {
+		return setNewComputedFingerprints(d)
+	}
+	return nil
+}
+
+// rsaPublicKeyFingerprint returns the fingerprint of key, or an empty one if
+// there is no key
+func rsaPublicKeyFingerprint(key string) (string, error) {
+	key = snowflake.NormalizeRSAPublicKey(key)
+	if key == "" {
+		return "", nil
+	}
+	return snowflake.RSAPublicKeyFingerprint(key)
+}
+
+// rsaPublicKeyChanged reports whether the key k changes other than by being
+// normalized. The new value of the diff is the configured one, e.g. a PEM.
+func rsaPublicKeyChanged(d *schema.ResourceDiff, k string) bool {
+	o, n := d.GetChange(k)
+	return snowflake.NormalizeRSAPublicKey(o.(string)) != snowflake.NormalizeRSAPublicKey(n.(string))
+}
+
+func setNewComputedFingerprints(d *schema.ResourceDiff) error {
+	err := d.SetNewComputed("rsa_public_key_fp")
+	if err != nil {
+		return err
+	}
+	return d.SetNewComputed("rsa_public_key_2_fp")
+}
+
+func normalizeRSAPublicKey(v interface{}) string {
+	return snowflake.NormalizeRSAPublicKey(v.(string))
+}
+
+func validateRSAPublicKey(v interface{}, k string) ([]string, []error) {
+	return snowflake.ValidateRSAPublicKey(v)
 }
 
 func DeleteUser(data *schema.ResourceData, meta interface{}) error {
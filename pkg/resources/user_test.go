@@ -1,14 +1,20 @@
 package resources_test
 
 import (
-	"database/sql"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"regexp"
 	"testing"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/stretchr/testify/require"
 )
 
@@ -41,22 +47,47 @@ func TestUserCreate(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.User().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`^CREATE USER "good_name" COMMENT='great comment' DEFAULT_NAMESPACE='mynamespace' DEFAULT_ROLE='bestrole' DEFAULT_WAREHOUSE='mywarehouse' DISPLAY_NAME='Display Name' EMAIL='fake@email.com' FIRST_NAME='Marcin' LAST_NAME='Zukowski' LOGIN_NAME='gname' PASSWORD='awesomepassword' RSA_PUBLIC_KEY='asdf' RSA_PUBLIC_KEY_2='asdf2' DISABLED=true MUST_CHANGE_PASSWORD=true$`).WillReturnResult(sqlmock.NewResult(1, 1))
 		expectReadUser(mock)
-		err := resources.CreateUser(d, db)
+		err := resources.CreateUser(d, client)
 		r.NoError(err)
 	})
 }
 
 func expectReadUser(mock sqlmock.Sqlmock) {
+	expectReadUserWithKeys(mock, "null", "null")
+}
+
+// expectReadUserWithKeys expects a read of a user with keys of fingerprints
+// fp and fp2
+func expectReadUserWithKeys(mock sqlmock.Sqlmock, fp, fp2 string) {
+	expectShowUser(mock)
+	expectDescribeUser(mock, fp, fp2)
+
+	params := sqlmock.NewRows([]string{"key", "value", "default", "level", "description", "type"}).
+		AddRow("TIMEZONE", "UTC", "America/Los_Angeles", "USER", "desc", "STRING").
+		AddRow("STATEMENT_TIMEOUT_IN_SECONDS", "3600", "172800", "ACCOUNT", "desc", "NUMBER").
+		AddRow("QUERY_TAG", "", "", "", "desc", "STRING")
+	mock.ExpectQuery(`^SHOW PARAMETERS IN USER "good_name"$`).WillReturnRows(params)
+}
+
+func expectDescribeUser(mock sqlmock.Sqlmock, fp, fp2 string) {
+	rows := sqlmock.NewRows([]string{"property", "value", "default", "description"}).
+		AddRow("NAME", "good_name", "null", "Name").
+		AddRow("RSA_PUBLIC_KEY_FP", fp, "null", "Fingerprint of user's RSA public key.").
+		AddRow("RSA_PUBLIC_KEY_2_FP", fp2, "null", "Fingerprint of user's second RSA public key.")
+	mock.ExpectQuery(`^DESCRIBE USER "good_name"$`).WillReturnRows(rows)
+}
+
+func expectShowUser(mock sqlmock.Sqlmock) {
 	rows := sqlmock.NewRows([]string{
 		"name", "created_on", "login_name", "display_name", "first_name", "last_name", "email", "mins_to_unlock",
 		"days_to_expiry", "comment", "disabled", "must_change_password", "snowflake_lock", "default_warehouse",
 		"default_namespace", "default_role", "ext_authn_duo", "ext_authn_uid", "mins_to_bypass_mfa", "owner",
 		"last_success_login", "expires_at_time", "locked_until_time", "has_password", "has_rsa_public_key"},
 	).AddRow("good_name", "created_on", "myloginname", "display_name", "first_name", "last_name", "email", "mins_to_unlock", "days_to_expiry", "mock comment", false, true, "snowflake_lock", "default_warehouse", "default_namespace", "default_role", "ext_authn_duo", "ext_authn_uid", "mins_to_bypass_mfa", "owner", "last_success_login", "expires_at_time", "locked_until_time", "has_password", false)
-	mock.ExpectQuery(`^SHOW USERS LIKE 'good_name'$`).WillReturnRows(rows)
+	mock.ExpectQuery(`^SHOW USERS LIKE 'good\\\\_name'$`).WillReturnRows(rows)
 }
 
 func TestUserRead(t *testing.T) {
@@ -64,13 +95,145 @@ func TestUserRead(t *testing.T) {
 
 	d := user(t, "good_name", map[string]interface{}{"name": "good_name"})
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		expectReadUser(mock)
-		err := resources.ReadUser(d, db)
+		err := resources.ReadUser(d, client)
 		r.NoError(err)
 		r.Equal("mock comment", d.Get("comment").(string))
 		r.Equal("myloginname", d.Get("login_name").(string))
 		r.Equal(false, d.Get("disabled").(bool))
+		r.Equal("UTC", d.Get("parameters.0.timezone").(string))
+		// inherited from the account
+		r.Equal(-1, d.Get("parameters.0.statement_timeout_in_seconds").(int))
+	})
+}
+
+func TestUserCreateWithParameters(t *testing.T) {
+	r := require.New(t)
+
+	in := map[string]interface{}{
+		"name": "good_name",
+		"parameters": []interface{}{map[string]interface{}{
+			"timezone":       "UTC",
+			"network_policy": "office_only",
+			"days_to_expiry": 30,
+		}},
+	}
+	d := schema.TestResourceDataRaw(t, resources.User().Schema, in)
+	r.NotNil(d)
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`^CREATE USER "good_name" TIMEZONE='UTC' NETWORK_POLICY="office_only" DAYS_TO_EXPIRY=30$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectReadUser(mock)
+		err := resources.CreateUser(d, client)
+		r.NoError(err)
+		r.Equal(30, d.Get("parameters.0.days_to_expiry").(int))
+		r.Equal("", d.Get("parameters.0.network_policy").(string))
+	})
+}
+
+// TestUserEmptyParameters checks that an empty parameters block, or one with
+// only defaults, does not plan to add the block again after it is applied
+func TestUserEmptyParameters(t *testing.T) {
+	for name, block := range map[string]map[string]interface{}{
+		"empty":         {},
+		"only defaults": {"mins_to_unlock": -1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+
+			res := resources.User()
+			cfg := map[string]interface{}{
+				"name":       "good_name",
+				"parameters": []interface{}{block},
+			}
+			d := schema.TestResourceDataRaw(t, res.Schema, cfg)
+
+			WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`^CREATE USER "good_name"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+				expectShowUser(mock)
+				expectDescribeUser(mock, "null", "null")
+				params := sqlmock.NewRows([]string{"key", "value", "default", "level", "description", "type"}).
+					AddRow("TIMEZONE", "America/Los_Angeles", "America/Los_Angeles", "", "desc", "STRING")
+				mock.ExpectQuery(`^SHOW PARAMETERS IN USER "good_name"$`).WillReturnRows(params)
+				r.NoError(resources.CreateUser(d, client))
+			})
+			r.Equal(1, d.Get("parameters.#").(int))
+
+			diff, err := res.Diff(d.State(), terraform.NewResourceConfigRaw(cfg), nil)
+			r.NoError(err)
+			if diff != nil {
+				r.NotContains(diff.Attributes, "parameters.#")
+			}
+		})
+	}
+}
+
+func TestUserUpdateUnsetsRemovedParameter(t *testing.T) {
+	r := require.New(t)
+
+	res := resources.User()
+	state := &terraform.InstanceState{
+		ID: "good_name",
+		Attributes: map[string]string{
+			"id":                    "good_name",
+			"name":                  "good_name",
+			"parameters.#":          "1",
+			"parameters.0.timezone": "UTC",
+			"parameters.0.statement_timeout_in_seconds": "60",
+			"parameters.0.mins_to_unlock":               "-1",
+			"parameters.0.days_to_expiry":               "-1",
+			"parameters.0.mins_to_bypass_mfa":           "-1",
+		},
+	}
+	cfg := map[string]interface{}{
+		"name":       "good_name",
+		"parameters": []interface{}{map[string]interface{}{"timezone": "UTC"}},
+	}
+	diff, err := res.Diff(state, terraform.NewResourceConfigRaw(cfg), nil)
+	r.NoError(err)
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`^ALTER USER "good_name" UNSET STATEMENT_TIMEOUT_IN_SECONDS$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectReadUser(mock)
+		_, err := res.Apply(state, diff, client)
+		r.NoError(err)
+	})
+}
+
+func TestUserUpdateSetsExplicitZero(t *testing.T) {
+	r := require.New(t)
+
+	res := resources.User()
+	state := &terraform.InstanceState{
+		ID: "good_name",
+		Attributes: map[string]string{
+			"id":                    "good_name",
+			"name":                  "good_name",
+			"parameters.#":          "1",
+			"parameters.0.timezone": "UTC",
+			"parameters.0.statement_timeout_in_seconds": "60",
+			"parameters.0.mins_to_unlock":               "-1",
+			"parameters.0.days_to_expiry":               "-1",
+			"parameters.0.mins_to_bypass_mfa":           "-1",
+		},
+	}
+	cfg := map[string]interface{}{
+		"name": "good_name",
+		"parameters": []interface{}{map[string]interface{}{
+			"timezone":                     "UTC",
+			"statement_timeout_in_seconds": 0,
+			"mins_to_bypass_mfa":           0,
+		}},
+	}
+	diff, err := res.Diff(state, terraform.NewResourceConfigRaw(cfg), nil)
+	r.NoError(err)
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`^ALTER USER "good_name" SET MINS_TO_BYPASS_MFA=0 STATEMENT_TIMEOUT_IN_SECONDS=0$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectReadUser(mock)
+		_, err := res.Apply(state, diff, client)
+		r.NoError(err)
 	})
 }
 
@@ -79,9 +242,9 @@ func TestUserExists(t *testing.T) {
 
 	d := user(t, "good_name", map[string]interface{}{"name": "good_name"})
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
-		expectReadUser(mock)
-		b, err := resources.UserExists(d, db)
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		expectShowUser(mock)
+		b, err := resources.UserExists(d, client)
 		r.NoError(err)
 		r.True(b)
 	})
@@ -92,9 +255,179 @@ func TestUserDelete(t *testing.T) {
 
 	d := user(t, "drop_it", map[string]interface{}{"name": "drop_it"})
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`^DROP USER "drop_it"$`).WillReturnResult(sqlmock.NewResult(1, 1))
-		err := resources.DeleteUser(d, db)
+		err := resources.DeleteUser(d, client)
 		r.NoError(err)
 	})
 }
+
+// testRSAPublicKey returns a new public key as a PEM, in normalized form and
+// its fingerprint
+func testRSAPublicKey(t *testing.T) (string, string, string) {
+	r := require.New(t)
+
+	priv, err := rsa.GenerateKey(rand.Reader, 1024)
+	r.NoError(err)
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	r.NoError(err)
+	pemKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	fp, err := snowflake.RSAPublicKeyFingerprint(pemKey)
+	r.NoError(err)
+	return pemKey, snowflake.NormalizeRSAPublicKey(pemKey), fp
+}
+
+func TestUserCreateWithPEM(t *testing.T) {
+	r := require.New(t)
+
+	pemKey, body, fp := testRSAPublicKey(t)
+	d := user(t, "", map[string]interface{}{"name": "good_name", "rsa_public_key": pemKey})
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`^CREATE USER "good_name" RSA_PUBLIC_KEY='` + regexp.QuoteMeta(body) + `'$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectReadUserWithKeys(mock, fp, "null")
+		err := resources.CreateUser(d, client)
+		r.NoError(err)
+		r.Equal(fp, d.Get("rsa_public_key_fp").(string))
+		r.Equal("", d.Get("rsa_public_key_2_fp").(string))
+	})
+}
+
+func TestUserRotateRSAPublicKey(t *testing.T) {
+	r := require.New(t)
+
+	_, oldBody, oldFP := testRSAPublicKey(t)
+	newPEM, newBody, newFP := testRSAPublicKey(t)
+
+	res := resources.User()
+	// userState is the state of a user with only its keys, as the other
+	// attributes read back by the mocks are not configured
+	userState := func(key, fp, fp2 string) *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID: "good_name",
+			Attributes: map[string]string{
+				"id":                    "good_name",
+				"name":                  "good_name",
+				"rsa_public_key":        key,
+				"rsa_public_key_fp":     fp,
+				"rsa_public_key_2_fp":   fp2,
+				"rotate_rsa_public_key": "true",
+			},
+		}
+	}
+	cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":                  "good_name",
+		"rsa_public_key":        newPEM,
+		"rotate_rsa_public_key": true,
+	})
+
+	// The new key is staged in the second slot
+	state := userState(oldBody, oldFP, "")
+	diff, err := res.Diff(state, cfg, nil)
+	r.NoError(err)
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		expectDescribeUser(mock, oldFP, "null")
+		mock.ExpectExec(`^ALTER USER "good_name" SET RSA_PUBLIC_KEY_2='` + regexp.QuoteMeta(newBody) + `'$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectReadUserWithKeys(mock, oldFP, newFP)
+		state, err := res.Apply(state, diff, client)
+		r.NoError(err)
+		r.Equal(newBody, state.Attributes["rsa_public_key"])
+		r.Equal(oldFP, state.Attributes["rsa_public_key_fp"])
+		r.Equal(newFP, state.Attributes["rsa_public_key_2_fp"])
+	})
+
+	// The next apply promotes it, though the configuration is unchanged
+	state = userState(newBody, oldFP, newFP)
+	diff, err = res.Diff(state, cfg, nil)
+	r.NoError(err)
+	r.NotNil(diff)
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		expectDescribeUser(mock, oldFP, newFP)
+		mock.ExpectExec(`^ALTER USER "good_name" SET RSA_PUBLIC_KEY='` + regexp.QuoteMeta(newBody) + `'$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`^ALTER USER "good_name" UNSET RSA_PUBLIC_KEY_2$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectReadUserWithKeys(mock, newFP, "null")
+		state, err := res.Apply(state, diff, client)
+		r.NoError(err)
+		r.Equal(newFP, state.Attributes["rsa_public_key_fp"])
+		r.Equal("", state.Attributes["rsa_public_key_2_fp"])
+	})
+
+	// After which there is nothing left to do
+	diff, err = res.Diff(userState(newBody, newFP, ""), cfg, nil)
+	r.NoError(err)
+	r.Nil(diff)
+}
+
+func TestUserStopRotatingRSAPublicKey(t *testing.T) {
+	r := require.New(t)
+
+	oldPEM, oldBody, oldFP := testRSAPublicKey(t)
+	_, _, newFP := testRSAPublicKey(t)
+
+	res := resources.User()
+	cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":                  "good_name",
+		"rsa_public_key":        oldPEM,
+		"rotate_rsa_public_key": false,
+	})
+
+	// Rotation is turned off while a key is staged in the second slot
+	state := &terraform.InstanceState{
+		ID: "good_name",
+		Attributes: map[string]string{
+			"id":                    "good_name",
+			"name":                  "good_name",
+			"rsa_public_key":        oldBody,
+			"rsa_public_key_fp":     oldFP,
+			"rsa_public_key_2_fp":   newFP,
+			"rotate_rsa_public_key": "true",
+		},
+	}
+	diff, err := res.Diff(state, cfg, nil)
+	r.NoError(err)
+	r.NotNil(diff)
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`^ALTER USER "good_name" UNSET RSA_PUBLIC_KEY_2$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectReadUserWithKeys(mock, oldFP, "null")
+		state, err := res.Apply(state, diff, client)
+		r.NoError(err)
+		r.Equal("", state.Attributes["rsa_public_key_2_fp"])
+	})
+
+	// A key found in the second slot later on is planned to be unset too
+	state.Attributes["rotate_rsa_public_key"] = "false"
+	diff, err = res.Diff(state, cfg, nil)
+	r.NoError(err)
+	r.NotNil(diff)
+
+	state.Attributes["rsa_public_key_2_fp"] = ""
+	diff, err = res.Diff(state, cfg, nil)
+	r.NoError(err)
+	r.Nil(diff)
+}
+
+func TestUserRSAPublicKey2UnderRotation(t *testing.T) {
+	r := require.New(t)
+
+	pemKey, _, _ := testRSAPublicKey(t)
+	res := resources.User()
+
+	// an explicit false does not conflict with the second key
+	cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":                  "good_name",
+		"rsa_public_key_2":      pemKey,
+		"rotate_rsa_public_key": false,
+	})
+	_, errs := res.Validate(cfg)
+	r.Empty(errs)
+	_, err := res.Diff(nil, cfg, nil)
+	r.NoError(err)
+
+	cfg = terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":                  "good_name",
+		"rsa_public_key_2":      pemKey,
+		"rotate_rsa_public_key": true,
+	})
+	_, err = res.Diff(nil, cfg, nil)
+	r.EqualError(err, "rsa_public_key_2 cannot be set under rotate_rsa_public_key, which manages the second slot")
+}
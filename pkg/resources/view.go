@@ -1,10 +1,8 @@
 package resources
 
 import (
-	"database/sql"
 	"fmt"
 	"log"
-	"regexp"
 	"strings"
 
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
@@ -12,26 +10,27 @@ import (
 	"github.com/pkg/errors"
 )
 
-var space = regexp.MustCompile(`\s+`)
-
 var viewSchema = map[string]*schema.Schema{
 	"name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "Specifies the identifier for the view; must be unique for the schema in which the view is created. Don't use the | character.",
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "Specifies the identifier for the view; must be unique for the schema in which the view is created. Don't use the | character.",
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"database": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "The database in which to create the view. Don't use the | character.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The database in which to create the view. Don't use the | character.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"schema": {
-		Type:        schema.TypeString,
-		Optional:    true,
-		Default:     "PUBLIC",
-		Description: "The schema in which to create the view. Don't use the | character.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Optional:         true,
+		Default:          "PUBLIC",
+		Description:      "The schema in which to create the view. Don't use the | character.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"or_replace": {
 		Type:        schema.TypeBool,
@@ -59,22 +58,12 @@ var viewSchema = map[string]*schema.Schema{
 	},
 }
 
-func normalizeQuery(str string) string {
-	return strings.TrimSpace(space.ReplaceAllString(str, " "))
-}
-
-// DiffSuppressStatement will suppress diffs between statemens if they differ in only case or in
-// runs of whitespace (\s+ = \s). This is needed because the snowflake api does not faithfully
-// round-trip queries so we cannot do a simple character-wise comparison to detect changes.
-//
-// Warnings: We will have false positives in cases where a change in case or run of whitespace is
-// semantically significant.
-//
-// If we can find a sql parser that can handle the snowflake dialect then we should switch to parsing
-// queries and either comparing ASTs or emiting a canonical serialization for comparison. I couldnt'
-// find such a library.
+// DiffSuppressStatement will suppress diffs between statements that mean the same, as Snowflake does
+// not faithfully round-trip them. Statements are compared as normalized token streams, so differences
+// in whitespace, comments, trailing semicolons and the case of keywords and unquoted identifiers are
+// ignored, while the case of string literals and quoted identifiers is not.
 func DiffSuppressStatement(_, old, new string, d *schema.ResourceData) bool {
-	return strings.EqualFold(normalizeQuery(old), normalizeQuery(new))
+	return snowflake.EqualStatements(old, new)
 }
 
 // View returns a pointer to the resource representing a view
@@ -95,7 +84,10 @@ func View() *schema.Resource {
 
 // CreateView implements schema.CreateFunc
 func CreateView(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	name := data.Get("name").(string)
 	schema := data.Get("schema").(string)
 	database := data.Get("database").(string)
@@ -118,7 +110,7 @@ func CreateView(data *schema.ResourceData, meta interface{}) error {
 
 	q := builder.Create()
 	log.Print("[DEBUG] xxx ", q)
-	err := snowflake.Exec(db, q)
+	err := client.ExecContext(ctx, q)
 	if err != nil {
 		return errors.Wrapf(err, "error creating view %v", name)
 	}
@@ -130,15 +122,16 @@ func CreateView(data *schema.ResourceData, meta interface{}) error {
 
 // ReadView implements schema.ReadFunc
 func ReadView(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
+
 	dbName, schema, view, err := splitViewID(data.Id())
 	if err != nil {
 		return err
 	}
 
-	q := snowflake.View(view).WithDB(dbName).WithSchema(schema).Show()
-	row := snowflake.QueryRow(db, q)
-	v, err := snowflake.ScanView(row)
+	v, err := snowflake.ReadView(ctx, client, dbName, schema, view)
 	if err != nil {
 		return err
 	}
@@ -165,8 +158,7 @@ func ReadView(data *schema.ResourceData, meta interface{}) error {
 
 	// Want to only capture the Select part of the query because before that is the Create part of the view which we no longer care about
 
-	extractor := snowflake.NewViewSelectStatementExtractor(v.Text.String)
-	substringOfQuery, err := extractor.Extract()
+	substringOfQuery, err := snowflake.ExtractBody(v.Text.String)
 	if err != nil {
 		return err
 	}
@@ -181,6 +173,9 @@ func ReadView(data *schema.ResourceData, meta interface{}) error {
 
 // UpdateView implements schema.UpdateFunc
 func UpdateView(data *schema.ResourceData, meta interface{}) error {
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutUpdate)
+	defer cancel()
+
 	// https://www.terraform.io/docs/extend/writing-custom-providers.html#error-handling-amp-partial-state
 	data.Partial(true)
 
@@ -191,12 +186,12 @@ func UpdateView(data *schema.ResourceData, meta interface{}) error {
 
 	builder := snowflake.View(view).WithDB(dbName).WithSchema(schema)
 
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
 	if data.HasChange("name") {
 		_, name := data.GetChange("name")
 
 		q := builder.Rename(name.(string))
-		err := snowflake.Exec(db, q)
+		err := client.ExecContext(ctx, q)
 		if err != nil {
 			return errors.Wrapf(err, "error renaming view %v", data.Id())
 		}
@@ -210,13 +205,13 @@ func UpdateView(data *schema.ResourceData, meta interface{}) error {
 
 		if c := comment.(string); c == "" {
 			q := builder.RemoveComment()
-			err := snowflake.Exec(db, q)
+			err := client.ExecContext(ctx, q)
 			if err != nil {
 				return errors.Wrapf(err, "error unsetting comment for view %v", data.Id())
 			}
 		} else {
 			q := builder.ChangeComment(c)
-			err := snowflake.Exec(db, q)
+			err := client.ExecContext(ctx, q)
 			if err != nil {
 				return errors.Wrapf(err, "error updating comment for view %v", data.Id())
 			}
@@ -231,13 +226,13 @@ func UpdateView(data *schema.ResourceData, meta interface{}) error {
 
 		if secure.(bool) {
 			q := builder.Secure()
-			err := snowflake.Exec(db, q)
+			err := client.ExecContext(ctx, q)
 			if err != nil {
 				return errors.Wrapf(err, "error setting secure for view %v", data.Id())
 			}
 		} else {
 			q := builder.Unsecure()
-			err := snowflake.Exec(db, q)
+			err := client.ExecContext(ctx, q)
 			if err != nil {
 				return errors.Wrapf(err, "error unsetting secure for view %v", data.Id())
 			}
@@ -249,7 +244,10 @@ func UpdateView(data *schema.ResourceData, meta interface{}) error {
 
 // DeleteView implements schema.DeleteFunc
 func DeleteView(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutDelete)
+	defer cancel()
+
 	dbName, schema, view, err := splitViewID(data.Id())
 	if err != nil {
 		return err
@@ -257,7 +255,7 @@ func DeleteView(data *schema.ResourceData, meta interface{}) error {
 
 	q := snowflake.View(view).WithDB(dbName).WithSchema(schema).Drop()
 
-	err = snowflake.Exec(db, q)
+	err = client.ExecContext(ctx, q)
 	if err != nil {
 		return errors.Wrapf(err, "error deleting view %v", data.Id())
 	}
@@ -269,14 +267,16 @@ func DeleteView(data *schema.ResourceData, meta interface{}) error {
 
 // ViewExists implements schema.ExistsFunc
 func ViewExists(data *schema.ResourceData, meta interface{}) (bool, error) {
-	db := meta.(*sql.DB)
+	client := meta.(*snowflake.Client)
+	ctx, cancel := OperationContext(data, meta, schema.TimeoutRead)
+	defer cancel()
 	dbName, schema, view, err := splitViewID(data.Id())
 	if err != nil {
 		return false, err
 	}
 
 	q := snowflake.View(view).WithDB(dbName).WithSchema(schema).Show()
-	rows, err := db.Query(q)
+	rows, err := client.QueryContext(ctx, q)
 	if err != nil {
 		return false, err
 	}
@@ -1,12 +1,12 @@
 package resources_test
 
 import (
-	"database/sql"
 	"testing"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
 	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -32,13 +32,13 @@ func TestViewCreate(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.View().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(
 			`^CREATE SECURE VIEW "test_db"."PUBLIC"."good_name" COMMENT = 'great comment' AS SELECT \* FROM test_db.PUBLIC.GREAT_TABLE WHERE account_id = 'bobs-account-id'$`,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
 
 		expectReadView(mock)
-		err := resources.CreateView(d, db)
+		err := resources.CreateView(d, client)
 		r.NoError(err)
 	})
 }
@@ -56,13 +56,13 @@ func TestViewCreateOrReplace(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.View().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(
 			`^CREATE OR REPLACE SECURE VIEW "test_db"."PUBLIC"."good_name" COMMENT = 'great comment' AS SELECT \* FROM test_db.PUBLIC.GREAT_TABLE WHERE account_id = 'bobs-account-id'$`,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
 
 		expectReadView(mock)
-		err := resources.CreateView(d, db)
+		err := resources.CreateView(d, client)
 		r.NoError(err)
 	})
 }
@@ -79,22 +79,52 @@ func TestViewCreateAmpersand(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.View().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(
 			`^CREATE SECURE VIEW "test_db"."PUBLIC"."good_name" COMMENT = 'great comment' AS SELECT \* FROM test_db.PUBLIC.GREAT_TABLE WHERE account_id LIKE 'bob%'$`,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
 
 		expectReadView(mock)
-		err := resources.CreateView(d, db)
+		err := resources.CreateView(d, client)
 		r.NoError(err)
 	})
 }
 
 func expectReadView(mock sqlmock.Sqlmock) {
 	rows := sqlmock.NewRows([]string{
-		"created_on", "name", "reserved", "database_name", "schema_name", "owner", "comment", "text", "is_secure", "is_materialized"},
-	).AddRow("2019-05-19 16:55:36.530 -0700", "good_name", "", "test_db", "GREAT_SCHEMA", "admin", "great comment", "SELECT * FROM test_db.GREAT_SCHEMA.GREAT_TABLE WHERE account_id = 'bobs-account-id'", true, false)
-	mock.ExpectQuery(`^SHOW VIEWS LIKE 'good_name' IN DATABASE "test_db"$`).WillReturnRows(rows)
+		"created_on", "name", "reserved", "database_name", "schema_name", "owner", "comment", "text", "is_secure", "is_materialized"}).
+		AddRow("2019-05-19 16:55:36.530 -0700", "good_name", "", "test_db", "PUBLIC", "admin", "great comment", "CREATE SECURE VIEW \"good_name\" COMMENT = 'great comment' AS SELECT * FROM test_db.GREAT_SCHEMA.GREAT_TABLE WHERE account_id = 'bobs-account-id'", true, false).
+		AddRow("2019-05-19 16:55:36.530 -0700", "other_name", "", "test_db", "PUBLIC", "admin", "", "create view other_name as SELECT 1", false, false)
+	mock.ExpectQuery(`^SHOW VIEWS IN SCHEMA "test_db"."PUBLIC"$`).WillReturnRows(rows)
+}
+
+func TestViewReadCached(t *testing.T) {
+	r := require.New(t)
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		// a single SHOW serves the Reads of all views in the schema
+		expectReadView(mock)
+		for _, name := range []string{"good_name", "other_name"} {
+			d := view(t, "test_db|PUBLIC|"+name, map[string]interface{}{"name": name, "database": "test_db", "schema": "PUBLIC"})
+			r.NoError(resources.ReadView(d, client))
+			r.Equal(name, d.Get("name").(string))
+		}
+
+		d := view(t, "test_db|PUBLIC|good_name", map[string]interface{}{"name": "good_name", "database": "test_db", "schema": "PUBLIC"})
+		r.NoError(resources.ReadView(d, client))
+		r.Equal("great comment", d.Get("comment").(string))
+		r.True(d.Get("is_secure").(bool))
+		r.Equal("SELECT * FROM test_db.GREAT_SCHEMA.GREAT_TABLE WHERE account_id = 'bobs-account-id'", d.Get("statement").(string))
+
+		// writes invalidate the cache
+		mock.ExpectExec(`^DROP VIEW "test_db"."PUBLIC"."other_name"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		d = view(t, "test_db|PUBLIC|other_name", map[string]interface{}{"name": "other_name", "database": "test_db", "schema": "PUBLIC"})
+		r.NoError(resources.DeleteView(d, client))
+
+		expectReadView(mock)
+		d = view(t, "test_db|PUBLIC|good_name", map[string]interface{}{"name": "good_name", "database": "test_db", "schema": "PUBLIC"})
+		r.NoError(resources.ReadView(d, client))
+	})
 }
 
 func TestDiffSuppressStatement(t *testing.T) {
@@ -112,6 +142,9 @@ func TestDiffSuppressStatement(t *testing.T) {
 		{"select", args{"", "select * from foo;", "select * from foo;", nil}, true},
 		{"view 1", args{"", testhelpers.MustFixture("view_1a.sql"), testhelpers.MustFixture("view_1b.sql"), nil}, true},
 		{"view 2", args{"", testhelpers.MustFixture("view_2a.sql"), testhelpers.MustFixture("view_2b.sql"), nil}, true},
+		{"comments", args{"", "-- everything\nselect * from foo;", "select * from foo", nil}, true},
+		{"literal case", args{"", "select * from foo where a = 'X'", "select * from foo where a = 'x'", nil}, false},
+		{"quoted identifier case", args{"", `select * from "foo"`, `select * from "FOO"`, nil}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
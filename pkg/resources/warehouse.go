@@ -1,27 +1,34 @@
 package resources
 
 import (
-	"database/sql"
 	"strings"
+	"time"
 
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
-// warehouseCreateProperties are only available via the CREATE statement
-var warehouseCreateProperties = []string{"initially_suspended", "wait_for_provisioning", "statement_timeout_in_seconds"}
-
-var warehouseProperties = []string{
-	"comment", "warehouse_size", "max_cluster_count", "min_cluster_count",
-	"scaling_policy", "auto_suspend", "auto_resume",
-	"resource_monitor",
+var warehouseProperties = Properties{
+	{Attribute: "comment", Type: StringProperty, Column: "comment"},
+	{Attribute: "warehouse_size", Type: EnumProperty, Column: "size"},
+	{Attribute: "max_cluster_count", Type: IntProperty, Column: "max_cluster_count"},
+	{Attribute: "min_cluster_count", Type: IntProperty, Column: "min_cluster_count"},
+	{Attribute: "scaling_policy", Type: EnumProperty, Column: "scaling_policy"},
+	{Attribute: "auto_suspend", Type: IntProperty, Column: "auto_suspend"},
+	{Attribute: "auto_resume", Type: BoolProperty, Column: "auto_resume"},
+	{Attribute: "resource_monitor", Type: IdentifierProperty, Column: "resource_monitor"},
+	{Attribute: "statement_timeout_in_seconds", Type: IntProperty, ObjectParameter: true, Unset: UnsetAlways},
+	// These are only available via the CREATE statement
+	{Attribute: "initially_suspended", Type: BoolProperty, CreateOnly: true},
+	{Attribute: "wait_for_provisioning", Type: BoolProperty, CreateOnly: true},
 }
 
 var warehouseSchema = map[string]*schema.Schema{
 	"name": {
-		Type:     schema.TypeString,
-		Required: true,
+		Type:             schema.TypeString,
+		Required:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"comment": {
 		Type:     schema.TypeString,
@@ -85,10 +92,11 @@ var warehouseSchema = map[string]*schema.Schema{
 		Optional:    true,
 	},
 	"resource_monitor": {
-		Type:        schema.TypeString,
-		Description: "Specifies the name of a resource monitor that is explicitly assigned to the warehouse.",
-		Optional:    true,
-		Computed:    true,
+		Type:             schema.TypeString,
+		Description:      "Specifies the name of a resource monitor that is explicitly assigned to the warehouse.",
+		Optional:         true,
+		Computed:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"wait_for_provisioning": {
 		Type:        schema.TypeBool,
@@ -96,11 +104,12 @@ var warehouseSchema = map[string]*schema.Schema{
 		Optional:    true,
 	},
 	"statement_timeout_in_seconds": {
-		Type:        schema.TypeInt,
-		Optional:    true,
-		Default:     0,
-		ForceNew:    false,
-		Description: "Specifies the time, in seconds, after which a running SQL statement (query, DDL, DML, etc.) is canceled by the system",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      intUnset,
+		ForceNew:     false,
+		ValidateFunc: validation.IntBetween(0, 604800),
+		Description:  "Specifies the time, in seconds, after which a running SQL statement (query, DDL, DML, etc.) is canceled by the system. Inherited from the account if not set.",
 	},
 }
 
@@ -116,66 +125,29 @@ func Warehouse() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+
+		// With wait_for_provisioning, creating or resizing a warehouse only
+		// returns once all of its servers are provisioned
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
 // CreateWarehouse implements schema.CreateFunc
 func CreateWarehouse(data *schema.ResourceData, meta interface{}) error {
-	props := append(warehouseProperties, warehouseCreateProperties...)
-	return CreateResource("warehouse", props, warehouseSchema, snowflake.Warehouse, ReadWarehouse)(data, meta)
+	return CreateResource("warehouse", warehouseProperties, snowflake.Warehouse, ReadWarehouse)(data, meta)
 }
 
 // ReadWarehouse implements schema.ReadFunc
 func ReadWarehouse(data *schema.ResourceData, meta interface{}) error {
-	db := meta.(*sql.DB)
-	stmt := snowflake.Warehouse(data.Id()).Show()
-
-	row := snowflake.QueryRow(db, stmt)
-	w, err := snowflake.ScanWarehouse(row)
-	if err != nil {
-		return err
-	}
-
-	err = data.Set("name", w.Name)
-	if err != nil {
-		return err
-	}
-	err = data.Set("comment", w.Comment)
-	if err != nil {
-		return err
-	}
-	err = data.Set("warehouse_size", w.Size)
-	if err != nil {
-		return err
-	}
-	err = data.Set("max_cluster_count", w.MaxClusterCount)
-	if err != nil {
-		return err
-	}
-	err = data.Set("min_cluster_count", w.MinClusterCount)
-	if err != nil {
-		return err
-	}
-	err = data.Set("scaling_policy", w.ScalingPolicy)
-	if err != nil {
-		return err
-	}
-	err = data.Set("auto_suspend", w.AutoSuspend)
-	if err != nil {
-		return err
-	}
-	err = data.Set("auto_resume", w.AutoResume)
-	if err != nil {
-		return err
-	}
-	err = data.Set("resource_monitor", w.ResourceMonitor)
-
-	return err
+	return ReadResource("warehouse", warehouseProperties, snowflake.Warehouse)(data, meta)
 }
 
 // UpdateWarehouse implements schema.UpdateFunc
 func UpdateWarehouse(data *schema.ResourceData, meta interface{}) error {
-	return UpdateResource("warehouse", warehouseProperties, warehouseSchema, snowflake.Warehouse, ReadWarehouse)(data, meta)
+	return UpdateResource("warehouse", warehouseProperties, snowflake.Warehouse, ReadWarehouse)(data, meta)
 }
 
 // DeleteWarehouse implements schema.DeleteFunc
@@ -6,20 +6,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
-var validWarehousePrivileges = newPrivilegeSet(
-	privilegeAll,
-	privilegeModify,
-	privilegeMonitor,
-	privilegeOperate,
-	privilegeOwnership,
-	privilegeUsage,
-)
+var validWarehousePrivileges = grantObjectWarehouse.privileges()
 var warehouseGrantSchema = map[string]*schema.Schema{
 	"warehouse_name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "The name of the warehouse on which to grant privileges.",
-		ForceNew:    true,
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The name of the warehouse on which to grant privileges.",
+		ForceNew:         true,
+		DiffSuppressFunc: diffIdentifier,
 	},
 	"privilege": {
 		Type:         schema.TypeString,
@@ -35,6 +29,7 @@ var warehouseGrantSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Grants privilege to these roles.",
 		ForceNew:    true,
+		Set:         hashIdentifier,
 	},
 	"with_grant_option": {
 		Type:        schema.TypeBool,
@@ -52,7 +47,8 @@ func WarehouseGrant() *schema.Resource {
 		Read:   ReadWarehouseGrant,
 		Delete: DeleteWarehouseGrant,
 
-		Schema: warehouseGrantSchema,
+		Schema:        warehouseGrantSchema,
+		CustomizeDiff: customizeDiffGrant(grantObjectWarehouse, warehouseGrantSchema),
 		// FIXME - tests for this don't currently work
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -1,14 +1,15 @@
 package resources_test
 
 import (
-	"database/sql"
 	"testing"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/provider"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/resources"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	. "github.com/chanzuckerberg/terraform-provider-snowflake/pkg/testhelpers"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/stretchr/testify/require"
 )
 
@@ -28,17 +29,22 @@ func TestWarehouseCreate(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resources.Warehouse().Schema, in)
 	r.NotNil(d)
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`CREATE WAREHOUSE "good_name" COMMENT='great comment`).WillReturnResult(sqlmock.NewResult(1, 1))
 		expectReadWarehouse(mock)
-		err := resources.CreateWarehouse(d, db)
+		err := resources.CreateWarehouse(d, client)
 		r.NoError(err)
 	})
 }
 
 func expectReadWarehouse(mock sqlmock.Sqlmock) {
 	rows := sqlmock.NewRows([]string{"name", "comment", "size"}).AddRow("good_name", "mock comment", "SMALL")
-	mock.ExpectQuery("SHOW WAREHOUSES LIKE 'good_name'").WillReturnRows(rows)
+	mock.ExpectQuery(`^SHOW WAREHOUSES$`).WillReturnRows(rows)
+
+	params := sqlmock.NewRows([]string{"key", "value", "default", "level", "description", "type"}).
+		AddRow("STATEMENT_TIMEOUT_IN_SECONDS", "60", "172800", "WAREHOUSE", "desc", "NUMBER").
+		AddRow("MAX_CONCURRENCY_LEVEL", "4", "8", "ACCOUNT", "desc", "NUMBER")
+	mock.ExpectQuery(`^SHOW PARAMETERS IN WAREHOUSE "good_name"$`).WillReturnRows(params)
 }
 
 func TestWarehouseRead(t *testing.T) {
@@ -46,11 +52,96 @@ func TestWarehouseRead(t *testing.T) {
 
 	d := warehouse(t, "good_name", map[string]interface{}{"name": "good_name"})
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		expectReadWarehouse(mock)
-		err := resources.ReadWarehouse(d, db)
+		err := resources.ReadWarehouse(d, client)
 		r.NoError(err)
 		r.Equal("mock comment", d.Get("comment").(string))
+		r.Equal("SMALL", d.Get("warehouse_size").(string))
+		r.Equal(60, d.Get("statement_timeout_in_seconds").(int))
+	})
+}
+
+func TestWarehouseReadNotFound(t *testing.T) {
+	r := require.New(t)
+
+	d := warehouse(t, "good_name", map[string]interface{}{"name": "good_name"})
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`^SHOW WAREHOUSES$`).WillReturnRows(sqlmock.NewRows([]string{"name"}))
+		mock.ExpectQuery(`^SHOW WAREHOUSES LIKE 'good\\\\_name'$`).WillReturnRows(sqlmock.NewRows([]string{"name"}))
+		err := resources.ReadWarehouse(d, client)
+		r.NoError(err)
+		r.Equal("", d.Id())
+	})
+}
+
+func TestWarehouseUpdate(t *testing.T) {
+	r := require.New(t)
+
+	res := resources.Warehouse()
+	state := &terraform.InstanceState{
+		ID:         "good_name",
+		Attributes: map[string]string{"id": "good_name", "name": "good_name", "comment": "mock comment", "statement_timeout_in_seconds": "60"},
+	}
+	cfg := map[string]interface{}{
+		"name":                  "good_name",
+		"comment":               "mock comment",
+		"resource_monitor":      "rm",
+		"wait_for_provisioning": true,
+	}
+	diff, err := res.Diff(state, terraform.NewResourceConfigRaw(cfg), nil)
+	r.NoError(err)
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`^ALTER WAREHOUSE "good_name" SET RESOURCE_MONITOR="rm"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`^ALTER WAREHOUSE "good_name" UNSET STATEMENT_TIMEOUT_IN_SECONDS$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectReadWarehouse(mock)
+		_, err := res.Apply(state, diff, client)
+		r.NoError(err)
+	})
+}
+
+func TestWarehouseCreateExplicitZero(t *testing.T) {
+	r := require.New(t)
+
+	in := map[string]interface{}{
+		"name":                         "good_name",
+		"statement_timeout_in_seconds": 0,
+	}
+	d := schema.TestResourceDataRaw(t, resources.Warehouse().Schema, in)
+	r.NotNil(d)
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`^CREATE WAREHOUSE "good_name" STATEMENT_TIMEOUT_IN_SECONDS=0$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectReadWarehouse(mock)
+		err := resources.CreateWarehouse(d, client)
+		r.NoError(err)
+	})
+}
+
+func TestWarehouseUpdateExplicitZero(t *testing.T) {
+	r := require.New(t)
+
+	res := resources.Warehouse()
+	state := &terraform.InstanceState{
+		ID:         "good_name",
+		Attributes: map[string]string{"id": "good_name", "name": "good_name", "comment": "mock comment", "statement_timeout_in_seconds": "60"},
+	}
+	cfg := map[string]interface{}{
+		"name":                         "good_name",
+		"comment":                      "mock comment",
+		"statement_timeout_in_seconds": 0,
+	}
+	diff, err := res.Diff(state, terraform.NewResourceConfigRaw(cfg), nil)
+	r.NoError(err)
+
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
+		// 0 is set, not taken for the attribute being removed
+		mock.ExpectExec(`^ALTER WAREHOUSE "good_name" SET STATEMENT_TIMEOUT_IN_SECONDS=0$`).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectReadWarehouse(mock)
+		_, err := res.Apply(state, diff, client)
+		r.NoError(err)
 	})
 }
 
@@ -59,9 +150,9 @@ func TestWarehouseDelete(t *testing.T) {
 
 	d := warehouse(t, "drop_it", map[string]interface{}{"name": "drop_it"})
 
-	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+	WithMockClient(t, func(client *snowflake.Client, mock sqlmock.Sqlmock) {
 		mock.ExpectExec(`DROP WAREHOUSE "drop_it"`).WillReturnResult(sqlmock.NewResult(1, 1))
-		err := resources.DeleteWarehouse(d, db)
+		err := resources.DeleteWarehouse(d, client)
 		r.NoError(err)
 	})
 }
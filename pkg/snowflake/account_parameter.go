@@ -0,0 +1,42 @@
+package snowflake
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AccountParameterBuilder abstracts the creation of SQL queries for a
+// parameter of the account
+type AccountParameterBuilder struct {
+	key string
+}
+
+// AccountParameter returns a pointer to a Builder for the account parameter
+// key
+func AccountParameter(key string) *AccountParameterBuilder {
+	return &AccountParameterBuilder{key: strings.ToUpper(key)}
+}
+
+// Set returns the SQL query that sets the parameter to value, after checking
+// it against the catalog
+func (b *AccountParameterBuilder) Set(value string) (string, error) {
+	pt, err := LookupParameter(b.key, AccountType)
+	if err != nil {
+		return "", err
+	}
+	v, err := FormatParameterValue(pt, value)
+	if err != nil {
+		return "", fmt.Errorf("invalid value for %v: %v", b.key, err)
+	}
+	return fmt.Sprintf(`ALTER ACCOUNT SET %v = %v`, b.key, v), nil
+}
+
+// Unset returns the SQL query that restores the parameter's default
+func (b *AccountParameterBuilder) Unset() string {
+	return fmt.Sprintf(`ALTER ACCOUNT UNSET %v`, b.key)
+}
+
+// Show returns the SQL query that shows the parameter
+func (b *AccountParameterBuilder) Show() string {
+	return fmt.Sprintf(`SHOW PARAMETERS LIKE %v IN ACCOUNT`, LikePattern(b.key))
+}
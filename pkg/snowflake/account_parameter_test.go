@@ -0,0 +1,21 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountParameterBuilder(t *testing.T) {
+	r := require.New(t)
+
+	b := AccountParameter("timezone")
+	stmt, err := b.Set("UTC")
+	r.NoError(err)
+	r.Equal(`ALTER ACCOUNT SET TIMEZONE = 'UTC'`, stmt)
+	r.Equal(`ALTER ACCOUNT UNSET TIMEZONE`, b.Unset())
+	r.Equal(`SHOW PARAMETERS LIKE 'TIMEZONE' IN ACCOUNT`, b.Show())
+
+	_, err = AccountParameter("MIN_DATA_RETENTION_TIME_IN_DAYS").Set("a week")
+	r.EqualError(err, "invalid value for MIN_DATA_RETENTION_TIME_IN_DAYS: expected an integer, got a week")
+}
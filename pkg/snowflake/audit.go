@@ -0,0 +1,237 @@
+package snowflake
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/snowflakedb/gosnowflake"
+)
+
+// Outcomes of the statements in the audit log
+const (
+	AuditSuccess = "success"
+	AuditFailure = "failure"
+)
+
+// AuditRecord is a line of the audit log
+type AuditRecord struct {
+	Time       time.Time `json:"time"`
+	Resource   string    `json:"resource,omitempty"`
+	ResourceID string    `json:"resource_id,omitempty"`
+	Statement  string    `json:"statement"`
+	QueryID    string    `json:"query_id,omitempty"`
+	Outcome    string    `json:"outcome"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+}
+
+// AuditLog writes a JSON line for every statement run against a db. Secrets
+// in statements and errors are redacted.
+//
+// A statement that ran is not failed because its record could not be
+// written. Instead the log keeps the error, and a Client with a failed log
+// refuses to run any further statement, so that at most one goes unrecorded.
+type AuditLog struct {
+	mu  sync.Mutex
+	w   io.Writer
+	enc *json.Encoder
+	err error
+}
+
+// NewAuditLog returns an AuditLog that writes to w. If w has a Sync method,
+// such as an *os.File, every record is synced once written.
+func NewAuditLog(w io.Writer) *AuditLog {
+	return &AuditLog{w: w, enc: json.NewEncoder(w)}
+}
+
+// OpenAuditLog returns an AuditLog that appends to the file at path, creating
+// it if needed. The file is closed by Close.
+func OpenAuditLog(path string) (*AuditLog, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not open audit log %v", path)
+	}
+	return NewAuditLog(f), nil
+}
+
+// Write redacts r and writes it as a line of the log. Once a write has failed
+// every later one fails with the same error.
+func (l *AuditLog) Write(r AuditRecord) error {
+	r.Statement = Redact(r.Statement)
+	r.Error = Redact(r.Error)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return l.err
+	}
+
+	err := l.enc.Encode(r)
+	if s, ok := l.w.(interface{ Sync() error }); ok && err == nil {
+		err = s.Sync()
+	}
+	if err != nil {
+		l.err = errors.Wrap(err, "could not write audit log")
+	}
+	return l.err
+}
+
+// Err returns the error of the first write that failed, if any
+func (l *AuditLog) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// Close closes the underlying writer if it is an io.Closer. Writes to a
+// closed log fail.
+func (l *AuditLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err == nil {
+		l.err = errors.New("audit log is closed")
+	}
+	if c, ok := l.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// record writes the outcome of stmt, run with ctx since start
+func (l *AuditLog) record(ctx context.Context, start time.Time, stmt, queryID string, err error) {
+	r := AuditRecord{
+		Time:       start.UTC(),
+		Statement:  stmt,
+		QueryID:    queryID,
+		Outcome:    AuditSuccess,
+		DurationMS: time.Since(start).Milliseconds(),
+	}
+	if res, ok := ctx.Value(auditResourceKey{}).(auditResource); ok {
+		r.Resource = res.name
+		r.ResourceID = res.id
+	}
+	if err != nil {
+		r.Outcome = AuditFailure
+		r.Error = err.Error()
+		var sfErr *gosnowflake.SnowflakeError
+		if r.QueryID == "" && errors.As(err, &sfErr) {
+			r.QueryID = sfErr.QueryID
+		}
+	}
+
+	// The error fails the statements run after this one, see AuditLog.
+	if werr := l.Write(r); werr != nil {
+		log.Printf("[ERROR] %v", werr)
+	}
+}
+
+type auditResourceKey struct{}
+
+type auditResource struct {
+	name string
+	id   string
+}
+
+// WithAuditResource returns a context that attributes the statements run with
+// it to the resource of type name with ID id in the audit log
+func WithAuditResource(ctx context.Context, name, id string) context.Context {
+	return context.WithValue(ctx, auditResourceKey{}, auditResource{name: name, id: id})
+}
+
+// credentialsPrefix matches the start of the credentials of stages, e.g.
+// CREDENTIALS = (AWS_KEY_ID='...' AWS_SECRET_KEY='...')
+var credentialsPrefix = regexp.MustCompile(`(?i)\bCREDENTIALS\s*=\s*\(`)
+
+var redactions = []struct {
+	re          *regexp.Regexp
+	replacement string
+}{
+	// Secret values, e.g. PASSWORD='...', ADMIN_PASSWORD = '...' or MASTER_KEY='...'
+	{regexp.MustCompile(`(?i)\b(\w*(?:PASSWORD|SECRET|TOKEN|KEY_ID|MASTER_KEY|PRIVATE_KEY)\w*\s*=\s*)('(?:[^'\\]|\\.|'')*'|"[^"]*"|[^\s,)]+)`), "${1}'****'"},
+}
+
+// Redact replaces the secrets in stmt, such as passwords and credentials,
+// with ****
+func Redact(stmt string) string {
+	stmt = redactCredentials(stmt)
+	for _, r := range redactions {
+		stmt = r.re.ReplaceAllString(stmt, r.replacement)
+	}
+	return stmt
+}
+
+// redactCredentials replaces the parenthesized credentials of stages with
+// (****). The statement is tokenized, so that a ) in a literal does not end
+// them. If it cannot be, everything after the first CREDENTIALS = ( is
+// redacted.
+func redactCredentials(stmt string) string {
+	if !credentialsPrefix.MatchString(stmt) {
+		return stmt
+	}
+	tokens, err := Tokenize(stmt)
+	if err != nil {
+		loc := credentialsPrefix.FindStringIndex(stmt)
+		return stmt[:loc[1]] + "****)"
+	}
+
+	b := strings.Builder{}
+	for i := 0; i < len(tokens); i++ {
+		b.WriteString(tokens[i].Text)
+		if !tokens[i].Is("CREDENTIALS") {
+			continue
+		}
+		open := credentialsOpen(tokens, i+1)
+		if open < 0 {
+			continue
+		}
+		for _, t := range tokens[i+1 : open] {
+			b.WriteString(t.Text)
+		}
+		b.WriteString("(****)")
+
+		// Skip to the matching ), or the end of an unterminated list
+		depth := 0
+		for i = open; i < len(tokens); i++ {
+			if tokens[i].Kind != TokenSymbol {
+				continue
+			}
+			if tokens[i].Text == "(" {
+				depth++
+			} else if tokens[i].Text == ")" {
+				depth--
+				if depth == 0 {
+					break
+				}
+			}
+		}
+	}
+	return b.String()
+}
+
+// credentialsOpen returns the index of the ( that opens the credentials after
+// CREDENTIALS, where tokens[i:] follow it, or -1 if they are not followed by
+// = (
+func credentialsOpen(tokens []Token, i int) int {
+	want := []string{"=", "("}
+	for ; i < len(tokens) && len(want) > 0; i++ {
+		if !tokens[i].Significant() {
+			continue
+		}
+		if tokens[i].Kind != TokenSymbol || tokens[i].Text != want[0] {
+			return -1
+		}
+		want = want[1:]
+		if len(want) == 0 {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,173 @@
+package snowflake_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		stmt     string
+		expected string
+	}{
+		{`CREATE USER "u" PASSWORD='hunter2' COMMENT='hi'`, `CREATE USER "u" PASSWORD='****' COMMENT='hi'`},
+		{`ALTER USER "u" SET PASSWORD = 'it''s \' secret'`, `ALTER USER "u" SET PASSWORD = '****'`},
+		{`CREATE MANAGED ACCOUNT "a" ADMIN_NAME='admin', ADMIN_PASSWORD='abc123!', TYPE=READER`, `CREATE MANAGED ACCOUNT "a" ADMIN_NAME='admin', ADMIN_PASSWORD='****', TYPE=READER`},
+		{`CREATE STAGE "s" URL = 's3://b' CREDENTIALS = (AWS_KEY_ID='id' AWS_SECRET_KEY='key')`, `CREATE STAGE "s" URL = 's3://b' CREDENTIALS = (****)`},
+		{`ALTER STAGE "s" SET ENCRYPTION = (TYPE='AWS_SSE_KMS' MASTER_KEY='abc')`, `ALTER STAGE "s" SET ENCRYPTION = (TYPE='AWS_SSE_KMS' MASTER_KEY='****')`},
+		{`CREATE STAGE "s" CREDENTIALS=(AZURE_SAS_TOKEN='tok')`, `CREATE STAGE "s" CREDENTIALS=(****)`},
+		{`CREATE STAGE "s" CREDENTIALS = (AWS_KEY_ID='id' AWS_SECRET_KEY='ab)cd''e)f') COMMENT='c'`, `CREATE STAGE "s" CREDENTIALS = (****) COMMENT='c'`},
+		{`ALTER STAGE "s" SET CREDENTIALS = (AWS_SECRET_KEY='a\')b') URL='s3://b'`, `ALTER STAGE "s" SET CREDENTIALS = (****) URL='s3://b'`},
+		{`CREATE STAGE "s" CREDENTIALS = (AWS_SECRET_KEY='ab)cd`, `CREATE STAGE "s" CREDENTIALS = (****)`},
+		{`ALTER USER "u" SET RSA_PUBLIC_KEY='MIIB'`, `ALTER USER "u" SET RSA_PUBLIC_KEY='MIIB'`},
+		{`SHOW USERS LIKE 'password'`, `SHOW USERS LIKE 'password'`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.stmt, func(t *testing.T) {
+			require.Equal(t, tt.expected, snowflake.Redact(tt.stmt))
+		})
+	}
+}
+
+func readAuditRecords(t *testing.T, buf *bytes.Buffer) []snowflake.AuditRecord {
+	records := []snowflake.AuditRecord{}
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var r snowflake.AuditRecord
+		require.NoError(t, dec.Decode(&r))
+		records = append(records, r)
+	}
+	return records
+}
+
+func TestExecAudited(t *testing.T) {
+	r := require.New(t)
+	db, mock, err := sqlmock.New()
+	r.NoError(err)
+	defer db.Close()
+
+	buf := &bytes.Buffer{}
+	client := snowflake.NewClient(db)
+	client.Audit = snowflake.NewAuditLog(buf)
+
+	mock.ExpectExec(`^ALTER USER "u" SET PASSWORD='hunter2'$`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`^SELECT LAST_QUERY_ID\(\)$`).WillReturnRows(sqlmock.NewRows([]string{"LAST_QUERY_ID()"}).AddRow("0190-abc"))
+
+	ctx := snowflake.WithAuditResource(context.Background(), "snowflake_user", "u")
+	r.NoError(client.ExecContext(ctx, `ALTER USER "u" SET PASSWORD='hunter2'`))
+	r.NoError(mock.ExpectationsWereMet())
+
+	records := readAuditRecords(t, buf)
+	r.Len(records, 1)
+	rec := records[0]
+	r.Equal("snowflake_user", rec.Resource)
+	r.Equal("u", rec.ResourceID)
+	r.Equal(`ALTER USER "u" SET PASSWORD='****'`, rec.Statement)
+	r.Equal("0190-abc", rec.QueryID)
+	r.Equal(snowflake.AuditSuccess, rec.Outcome)
+	r.Empty(rec.Error)
+	r.False(rec.Time.IsZero())
+	r.NotContains(buf.String(), "hunter2")
+}
+
+func TestExecAuditedFailure(t *testing.T) {
+	r := require.New(t)
+	db, mock, err := sqlmock.New()
+	r.NoError(err)
+	defer db.Close()
+
+	buf := &bytes.Buffer{}
+	client := snowflake.NewClient(db)
+	client.Audit = snowflake.NewAuditLog(buf)
+
+	mock.ExpectExec(`^DROP USER "u"$`).WillReturnError(errors.New("insufficient privileges"))
+
+	r.Error(client.Exec(`DROP USER "u"`))
+	r.NoError(mock.ExpectationsWereMet())
+
+	records := readAuditRecords(t, buf)
+	r.Len(records, 1)
+	r.Equal(`DROP USER "u"`, records[0].Statement)
+	r.Equal(snowflake.AuditFailure, records[0].Outcome)
+	r.Equal("insufficient privileges", records[0].Error)
+	r.Empty(records[0].Resource)
+}
+
+func TestQueryAudited(t *testing.T) {
+	r := require.New(t)
+	db, mock, err := sqlmock.New()
+	r.NoError(err)
+	defer db.Close()
+
+	buf := &bytes.Buffer{}
+	client := snowflake.NewClient(db)
+	client.Audit = snowflake.NewAuditLog(buf)
+
+	mock.ExpectQuery(`^SHOW USERS LIKE 'u'$`).WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("u"))
+
+	rows, err := client.Query(`SHOW USERS LIKE 'u'`)
+	r.NoError(err)
+	rows.Close()
+
+	records := readAuditRecords(t, buf)
+	r.Len(records, 1)
+	r.Equal(`SHOW USERS LIKE 'u'`, records[0].Statement)
+	r.Equal(snowflake.AuditSuccess, records[0].Outcome)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestExecRefusedAfterAuditFailure(t *testing.T) {
+	r := require.New(t)
+	db, mock, err := sqlmock.New()
+	r.NoError(err)
+	defer db.Close()
+
+	client := snowflake.NewClient(db)
+	client.Audit = snowflake.NewAuditLog(failingWriter{})
+
+	// the statement that ran is not failed, the next one is not run
+	mock.ExpectExec(`^DROP USER "u"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`^SELECT LAST_QUERY_ID\(\)$`).WillReturnRows(sqlmock.NewRows([]string{"LAST_QUERY_ID()"}).AddRow("0190-abc"))
+	r.NoError(client.Exec(`DROP USER "u"`))
+	r.Error(client.Audit.Err())
+
+	err = client.Exec(`DROP USER "v"`)
+	r.Error(err)
+	r.Contains(err.Error(), "disk full")
+	r.NoError(mock.ExpectationsWereMet())
+}
+
+func TestOpenAuditLog(t *testing.T) {
+	r := require.New(t)
+	dir, err := ioutil.TempDir("", "audit")
+	r.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	l, err := snowflake.OpenAuditLog(path)
+	r.NoError(err)
+	r.NoError(l.Write(snowflake.AuditRecord{Statement: `DROP USER "u"`, Outcome: snowflake.AuditSuccess}))
+	r.NoError(l.Close())
+	r.Error(l.Write(snowflake.AuditRecord{Statement: `DROP USER "v"`}))
+
+	b, err := ioutil.ReadFile(path)
+	r.NoError(err)
+	records := readAuditRecords(t, bytes.NewBuffer(b))
+	r.Len(records, 1)
+	r.Equal(`DROP USER "u"`, records[0].Statement)
+}
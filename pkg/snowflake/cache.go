@@ -0,0 +1,142 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// ShowCache memoizes the results of SHOW statements for the lifetime of a
+// Client, so that the Reads of many objects in the same scope, e.g. all
+// views of a database, are served by a single round-trip. Any write through
+// Exec invalidates the whole cache, so a Read never sees results older than
+// the provider's last change.
+//
+// Cached values are shared between callers and must not be modified.
+type ShowCache struct {
+	mu      sync.Mutex
+	entries map[string]*showCacheEntry
+}
+
+type showCacheEntry struct {
+	once  sync.Once
+	value interface{}
+	err   error
+}
+
+// NewShowCache returns an empty ShowCache
+func NewShowCache() *ShowCache {
+	return &ShowCache{entries: map[string]*showCacheEntry{}}
+}
+
+// Get returns the value cached under key, calling load to fill it on a miss.
+// Concurrent callers for the same key share a single call to load. Errors
+// are not cached.
+func (c *ShowCache) Get(key string, load func() (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if !ok {
+		e = &showCacheEntry{}
+		c.entries[key] = e
+	}
+	c.mu.Unlock()
+
+	e.once.Do(func() {
+		e.value, e.err = load()
+	})
+
+	if e.err != nil {
+		c.mu.Lock()
+		if c.entries[key] == e {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+	}
+	return e.value, e.err
+}
+
+// Invalidate drops all cached results. Loads in flight complete for their
+// callers but are not cached.
+func (c *ShowCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]*showCacheEntry{}
+}
+
+// ShowAll returns the rows of stmt, a SHOW statement of all the objects in a
+// scope, e.g. SHOW SCHEMAS IN DATABASE "db", each scanned into a new value
+// returned by newRow. The rows are cached in the client's ShowCache, so that
+// the Reads of the other objects in the scope are served by the same
+// round-trip.
+func (c *Client) ShowAll(ctx context.Context, stmt string, newRow func() interface{}) ([]interface{}, error) {
+	v, err := c.cache.Get(stmt, func() (interface{}, error) {
+		rows, err := c.QueryContext(ctx, stmt)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		all := []interface{}{}
+		for rows.Next() {
+			r := newRow()
+			err = rows.StructScan(r)
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, r)
+		}
+		return all, rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]interface{}), nil
+}
+
+// showFind returns the row for which match is true of scope, a SHOW statement
+// of all the objects in a scope read with ShowAll. Snowflake caps the output
+// of SHOW at 10,000 rows, so an object missing from scope is looked up in the
+// rows of like, a SHOW statement of the objects with its name, before
+// sql.ErrNoRows is returned.
+func (c *Client) showFind(ctx context.Context, scope, like string, newRow func() interface{}, match func(interface{}) bool) (interface{}, error) {
+	for _, stmt := range []string{scope, like} {
+		rows, err := c.ShowAll(ctx, stmt, newRow)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			if match(r) {
+				return r, nil
+			}
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// ShowRows is ShowAll for callers that read the columns of the rows by name.
+// The values are those returned by the driver. A statement must not be read
+// with both ShowAll and ShowRows, as they share the cache.
+func (c *Client) ShowRows(ctx context.Context, stmt string) ([]map[string]interface{}, error) {
+	v, err := c.cache.Get(stmt, func() (interface{}, error) {
+		rows, err := c.QueryContext(ctx, stmt)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		all := []map[string]interface{}{}
+		for rows.Next() {
+			r := map[string]interface{}{}
+			err = rows.MapScan(r)
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, r)
+		}
+		return all, rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]map[string]interface{}), nil
+}
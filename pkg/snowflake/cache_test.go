@@ -0,0 +1,144 @@
+package snowflake_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShowCache(t *testing.T) {
+	r := require.New(t)
+	c := snowflake.NewShowCache()
+
+	loads := 0
+	load := func() (interface{}, error) {
+		loads++
+		return loads, nil
+	}
+
+	v, err := c.Get("SHOW VIEWS", load)
+	r.NoError(err)
+	r.Equal(1, v)
+
+	v, err = c.Get("SHOW VIEWS", load)
+	r.NoError(err)
+	r.Equal(1, v)
+	r.Equal(1, loads)
+
+	v, err = c.Get("SHOW TABLES", load)
+	r.NoError(err)
+	r.Equal(2, v)
+
+	c.Invalidate()
+	v, err = c.Get("SHOW VIEWS", load)
+	r.NoError(err)
+	r.Equal(3, v)
+}
+
+func TestShowCacheErrorsNotCached(t *testing.T) {
+	r := require.New(t)
+	c := snowflake.NewShowCache()
+
+	_, err := c.Get("SHOW VIEWS", func() (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+	r.Error(err)
+
+	v, err := c.Get("SHOW VIEWS", func() (interface{}, error) {
+		return "ok", nil
+	})
+	r.NoError(err)
+	r.Equal("ok", v)
+}
+
+func TestShowCacheConcurrentLoadsCoalesce(t *testing.T) {
+	r := require.New(t)
+	c := snowflake.NewShowCache()
+
+	var mu sync.Mutex
+	loads := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Get("SHOW VIEWS", func() (interface{}, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				loads++
+				return nil, nil
+			})
+			r.NoError(err)
+		}()
+	}
+	wg.Wait()
+	r.Equal(1, loads)
+}
+
+func TestExecInvalidatesCache(t *testing.T) {
+	r := require.New(t)
+	db, mock, err := sqlmock.New()
+	r.NoError(err)
+	defer db.Close()
+
+	client := snowflake.NewClient(db)
+	c := client.Cache()
+	_, err = c.Get("SHOW VIEWS", func() (interface{}, error) { return 1, nil })
+	r.NoError(err)
+
+	mock.ExpectExec(`^DROP VIEW "v"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+	r.NoError(client.Exec(`DROP VIEW "v"`))
+
+	v, err := c.Get("SHOW VIEWS", func() (interface{}, error) { return 2, nil })
+	r.NoError(err)
+	r.Equal(2, v)
+	r.NoError(mock.ExpectationsWereMet())
+}
+
+func TestReadSchemaSharesShow(t *testing.T) {
+	r := require.New(t)
+	db, mock, err := sqlmock.New()
+	r.NoError(err)
+	defer db.Close()
+	client := snowflake.NewClient(db)
+
+	rows := sqlmock.NewRows([]string{"name", "database_name", "comment"}).AddRow("a", "db", "first").AddRow("b", "db", "second")
+	mock.ExpectQuery(`^SHOW SCHEMAS IN DATABASE "db"$`).WillReturnRows(rows)
+
+	a, err := snowflake.ReadSchema(context.Background(), client, "db", "a")
+	r.NoError(err)
+	r.Equal("first", a.Comment.String)
+	b, err := snowflake.ReadSchema(context.Background(), client, "db", "b")
+	r.NoError(err)
+	r.Equal("second", b.Comment.String)
+	// a schema missing from the scope is looked up by name
+	mock.ExpectQuery(`^SHOW SCHEMAS LIKE 'c' IN DATABASE "db"$`).WillReturnRows(sqlmock.NewRows([]string{"name", "database_name", "comment"}))
+	_, err = snowflake.ReadSchema(context.Background(), client, "db", "c")
+	r.Equal(sql.ErrNoRows, err)
+	r.NoError(mock.ExpectationsWereMet())
+}
+
+func TestReadSchemaBeyondShowLimit(t *testing.T) {
+	r := require.New(t)
+	db, mock, err := sqlmock.New()
+	r.NoError(err)
+	defer db.Close()
+	client := snowflake.NewClient(db)
+
+	// SHOW output is capped, so the scope can miss a schema that exists
+	rows := sqlmock.NewRows([]string{"name", "database_name", "comment"}).AddRow("a", "db", "first")
+	mock.ExpectQuery(`^SHOW SCHEMAS IN DATABASE "db"$`).WillReturnRows(rows)
+	rows = sqlmock.NewRows([]string{"name", "database_name", "comment"}).AddRow("z", "db", "last")
+	mock.ExpectQuery(`^SHOW SCHEMAS LIKE 'z' IN DATABASE "db"$`).WillReturnRows(rows)
+
+	z, err := snowflake.ReadSchema(context.Background(), client, "db", "z")
+	r.NoError(err)
+	r.Equal("last", z.Comment.String)
+	r.NoError(mock.ExpectationsWereMet())
+}
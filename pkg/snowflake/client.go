@@ -0,0 +1,102 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+)
+
+// Client is the meta the provider passes to its resources. It wraps the db
+// along with what was learned about the session when the provider was
+// configured, and the state shared by the statements run against the db:
+// their retry policy, the ShowCache and the audit log.
+type Client struct {
+	DB      *sql.DB
+	Session Session
+	// RetryPolicy determines how statements that fail with a transient error
+	// are retried
+	RetryPolicy RetryPolicy
+	// Audit, if not nil, records every statement run by the client
+	Audit *AuditLog
+	// IdentifierPolicy is the policy the provider was configured with.
+	// Resources normalize the names they pass to builders with it; builders
+	// take names literally.
+	IdentifierPolicy IdentifierPolicy
+	// RequireSysadminRollup makes granting roles that do not roll up to
+	// SYSADMIN an error at plan time. Otherwise it is a logged warning.
+	RequireSysadminRollup bool
+	// Resource is the type name of the resource, e.g. snowflake_user, whose
+	// functions the client is passed to. It is recorded in the audit log.
+	Resource string
+	// StopContext is cancelled when Terraform asks the provider to stop, e.g.
+	// on Ctrl-C. Statements run with a context derived from it are cancelled
+	// then.
+	StopContext context.Context
+
+	cache *ShowCache
+}
+
+// Session describes whom the provider is connected as
+type Session struct {
+	Account string
+	Region  string
+	Role    string
+}
+
+// NewClient returns a Client for db with an empty Session, the
+// DefaultRetryPolicy, an empty ShowCache, no audit log, the quoted identifier
+// policy, roles required to roll up to SYSADMIN and a StopContext that is
+// never cancelled
+func NewClient(db *sql.DB) *Client {
+	return &Client{
+		DB:                    db,
+		RetryPolicy:           DefaultRetryPolicy,
+		IdentifierPolicy:      IdentifierPolicyQuoted,
+		RequireSysadminRollup: true,
+		StopContext:           context.Background(),
+		cache:                 NewShowCache(),
+	}
+}
+
+// ForResource returns a copy of the client for the functions of the resource
+// of type name. The copy shares the db, ShowCache and audit log of c.
+func (c *Client) ForResource(name string) *Client {
+	rc := *c
+	rc.Resource = name
+	return &rc
+}
+
+// LoadSession reads the current account, region and role of the client's
+// session into its Session. As it runs a statement it also verifies the
+// credentials of the db.
+func (c *Client) LoadSession(ctx context.Context) error {
+	var account, region, role sql.NullString
+	err := c.QueryRowContext(ctx, "SELECT CURRENT_ACCOUNT(), CURRENT_REGION(), CURRENT_ROLE()").Scan(&account, &region, &role)
+	if err != nil {
+		return errors.Wrap(err, "could not read the current session")
+	}
+	c.Session = Session{
+		Account: account.String,
+		Region:  region.String,
+		Role:    role.String,
+	}
+	return nil
+}
+
+// Cache returns the ShowCache of the client
+func (c *Client) Cache() *ShowCache {
+	return c.cache
+}
+
+// Close closes the client's audit log, if any, and its db
+func (c *Client) Close() error {
+	var err error
+	if c.Audit != nil {
+		err = c.Audit.Close()
+	}
+	if dbErr := c.DB.Close(); err == nil {
+		err = dbErr
+	}
+	return err
+}
@@ -0,0 +1,41 @@
+package snowflake_test
+
+import (
+	"context"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSession(t *testing.T) {
+	r := require.New(t)
+	db, mock, err := sqlmock.New()
+	r.NoError(err)
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"CURRENT_ACCOUNT()", "CURRENT_REGION()", "CURRENT_ROLE()"}).AddRow("AB12345", "AWS_US_WEST_2", "SYSADMIN")
+	mock.ExpectQuery(`^SELECT CURRENT_ACCOUNT\(\), CURRENT_REGION\(\), CURRENT_ROLE\(\)$`).WillReturnRows(rows)
+
+	client := snowflake.NewClient(db)
+	r.NoError(client.LoadSession(context.Background()))
+	r.Equal(snowflake.Session{Account: "AB12345", Region: "AWS_US_WEST_2", Role: "SYSADMIN"}, client.Session)
+	r.Equal(db, client.DB)
+	r.NoError(mock.ExpectationsWereMet())
+}
+
+func TestLoadSessionError(t *testing.T) {
+	r := require.New(t)
+	db, mock, err := sqlmock.New()
+	r.NoError(err)
+	defer db.Close()
+
+	mock.ExpectQuery(`^SELECT CURRENT_ACCOUNT`).WillReturnError(errTokenExpired)
+	client := snowflake.NewClient(db)
+	client.RetryPolicy = snowflake.RetryPolicy{MaxAttempts: 1}
+
+	err = client.LoadSession(context.Background())
+	r.Error(err)
+	r.Contains(err.Error(), "could not read the current session")
+}
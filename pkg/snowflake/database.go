@@ -35,7 +35,7 @@ func DatabaseFromShare(name, provider, share string) *DatabaseShareBuilder {
 
 // Create returns the SQL statement required to create a database from a share
 func (dsb *DatabaseShareBuilder) Create() string {
-	return fmt.Sprintf(`CREATE DATABASE "%v" FROM SHARE "%v"."%v"`, dsb.name, dsb.provider, dsb.share)
+	return fmt.Sprintf(`CREATE DATABASE %v FROM SHARE %v`, Identifier{dsb.name}, Identifier{dsb.provider, dsb.share})
 }
 
 // DatabaseCloneBuilder is a basic builder that just creates databases from a source database
@@ -54,7 +54,7 @@ func DatabaseFromDatabase(name, database string) *DatabaseCloneBuilder {
 
 // Create returns the SQL statement required to create a database from a source database
 func (dsb *DatabaseCloneBuilder) Create() string {
-	return fmt.Sprintf(`CREATE DATABASE "%v" CLONE "%v"`, dsb.name, dsb.database)
+	return fmt.Sprintf(`CREATE DATABASE %v CLONE %v`, Identifier{dsb.name}, Identifier{dsb.database})
 }
 
 type database struct {
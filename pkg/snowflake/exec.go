@@ -1,32 +1,129 @@
 package snowflake
 
 import (
+	"context"
 	"database/sql"
 	"log"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
 )
 
+// Exec will run query against the db with the DefaultRetryPolicy. Resources
+// use the methods of their Client instead, which also audit statements and
+// invalidate the ShowCache.
 func Exec(db *sql.DB, query string) error {
-	log.Print("[DEBUG] stmt ", query)
-
-	_, err := db.Exec(query)
-	return err
+	return NewClient(db).Exec(query)
 }
 
 // QueryRow will run stmt against the db and return the row. We use
 // [DB.Unsafe](https://godoc.org/github.com/jmoiron/sqlx#DB.Unsafe) so that we can scan to structs
 // without worrying about newly introduced columns
 func QueryRow(db *sql.DB, stmt string) *sqlx.Row {
-	log.Print("[DEBUG] stmt ", stmt)
-	sdb := sqlx.NewDb(db, "snowflake").Unsafe()
-	return sdb.QueryRowx(stmt)
+	return NewClient(db).QueryRow(stmt)
 }
 
 // Query will run stmt against the db and return the rows. We use
 // [DB.Unsafe](https://godoc.org/github.com/jmoiron/sqlx#DB.Unsafe) so that we can scan to structs
 // without worrying about newly introduced columns
 func Query(db *sql.DB, stmt string) (*sqlx.Rows, error) {
-	sdb := sqlx.NewDb(db, "snowflake").Unsafe()
-	return sdb.Queryx(stmt)
+	return NewClient(db).Query(stmt)
+}
+
+// Exec will run query against the client's db, retrying transient errors
+// according to its RetryPolicy, and invalidate its ShowCache
+func (c *Client) Exec(query string) error {
+	return c.ExecContext(context.Background(), query)
+}
+
+// ExecContext is Exec, cancelling the query when ctx is done. It fails
+// without running query if the client's audit log could not be written.
+func (c *Client) ExecContext(ctx context.Context, query string) error {
+	log.Print("[DEBUG] stmt ", Redact(query))
+
+	if c.Audit != nil {
+		if err := c.Audit.Err(); err != nil {
+			return errors.WithMessage(err, "refusing to run a statement that cannot be audited")
+		}
+	}
+
+	// Even a failed statement may have changed something.
+	defer c.cache.Invalidate()
+
+	return c.RetryPolicy.do(ctx, query, func() error {
+		if c.Audit == nil {
+			_, err := c.DB.ExecContext(ctx, query)
+			return err
+		}
+		return c.execAudited(ctx, query)
+	})
+}
+
+// execAudited runs query on a connection of its own, so that the ID of the
+// query can be read back for the audit log
+func (c *Client) execAudited(ctx context.Context, query string) error {
+	start := time.Now()
+	conn, err := c.DB.Conn(ctx)
+	if err != nil {
+		c.Audit.record(ctx, start, query, "", err)
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, query)
+	queryID := ""
+	if err == nil {
+		var id sql.NullString
+		// The ID is only informational, so failing to read it is not an error.
+		_ = conn.QueryRowContext(ctx, "SELECT LAST_QUERY_ID()").Scan(&id)
+		queryID = id.String
+	}
+	c.Audit.record(ctx, start, query, queryID, err)
+	return err
+}
+
+// QueryRow will run stmt against the client's db and return the row
+func (c *Client) QueryRow(stmt string) *sqlx.Row {
+	return c.QueryRowContext(context.Background(), stmt)
+}
+
+// QueryRowContext is QueryRow, cancelling the query when ctx is done
+func (c *Client) QueryRowContext(ctx context.Context, stmt string) *sqlx.Row {
+	log.Print("[DEBUG] stmt ", Redact(stmt))
+	sdb := sqlx.NewDb(c.DB, "snowflake").Unsafe()
+
+	var row *sqlx.Row
+	// The error is returned on Scan, the row is only needed to retry on it.
+	_ = c.RetryPolicy.do(ctx, stmt, func() error {
+		start := time.Now()
+		row = sdb.QueryRowxContext(ctx, stmt)
+		if c.Audit != nil {
+			c.Audit.record(ctx, start, stmt, "", row.Err())
+		}
+		return row.Err()
+	})
+	return row
+}
+
+// Query will run stmt against the client's db and return the rows
+func (c *Client) Query(stmt string) (*sqlx.Rows, error) {
+	return c.QueryContext(context.Background(), stmt)
+}
+
+// QueryContext is Query, cancelling the query when ctx is done
+func (c *Client) QueryContext(ctx context.Context, stmt string) (*sqlx.Rows, error) {
+	sdb := sqlx.NewDb(c.DB, "snowflake").Unsafe()
+
+	var rows *sqlx.Rows
+	err := c.RetryPolicy.do(ctx, stmt, func() error {
+		start := time.Now()
+		var err error
+		rows, err = sdb.QueryxContext(ctx, stmt)
+		if c.Audit != nil {
+			c.Audit.record(ctx, start, stmt, "", err)
+		}
+		return err
+	})
+	return rows, err
 }
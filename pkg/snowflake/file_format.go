@@ -1,7 +1,7 @@
 package snowflake
 
 import (
-	"database/sql"
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -24,11 +24,7 @@ type FileFormatBuilder struct {
 
 // QualifiedName prepends the db and schema and escapes everything nicely
 func (fb *FileFormatBuilder) QualifiedName() string {
-	var n strings.Builder
-
-	n.WriteString(fmt.Sprintf(`"%v"."%v"."%v"`, fb.db, fb.schema, fb.name))
-
-	return n.String()
+	return Identifier{fb.db, fb.schema, fb.name}.String()
 }
 
 // WithType adds a type to the FileFormatBuilder
@@ -92,15 +88,15 @@ func (fb *FileFormatBuilder) Create() string {
 	builder.WriteString(fmt.Sprintf(` TRIM_SPACE = %v`, fb.trimSpace))
 
 	if fb.fileFormatType != "" {
-		builder.WriteString(fmt.Sprintf(` TYPE = "%v"`, fb.fileFormatType))
+		builder.WriteString(fmt.Sprintf(` TYPE = '%v'`, EscapeString(fb.fileFormatType)))
 	}
 
 	if fb.comment != "" {
-		builder.WriteString(fmt.Sprintf(` COMMENT = "%v"`, EscapeString(fb.comment)))
+		builder.WriteString(fmt.Sprintf(` COMMENT = '%v'`, EscapeString(fb.comment)))
 	}
 
 	if fb.compression != "" {
-		builder.WriteString(fmt.Sprintf(` COMPRESSION = "%v"`, fb.compression))
+		builder.WriteString(fmt.Sprintf(` COMPRESSION = '%v'`, EscapeString(fb.compression)))
 	}
 
 	if len(fb.nullIf) > 0 {
@@ -116,12 +112,12 @@ func (fb *FileFormatBuilder) Create() string {
 
 // ChangeComment returns the SQL query that will update the comment on the file format.
 func (fb *FileFormatBuilder) ChangeComment(comment string) string {
-	return fmt.Sprintf(`ALTER FILE FORMAT %v SET COMMENT = "%v"`, fb.QualifiedName(), comment)
+	return fmt.Sprintf(`ALTER FILE FORMAT %v SET COMMENT = '%v'`, fb.QualifiedName(), EscapeString(comment))
 }
 
 // ChangeComment returns the SQL query that will update the compression on the file format.
 func (fb *FileFormatBuilder) ChangeCompression(compression string) string {
-	return fmt.Sprintf(`ALTER FILE FORMAT %v SET COMPRESSION = "%v"`, fb.QualifiedName(), compression)
+	return fmt.Sprintf(`ALTER FILE FORMAT %v SET COMPRESSION = '%v'`, fb.QualifiedName(), EscapeString(compression))
 }
 
 // ChangeComment returns the SQL query that will update binary as text on the file format.
@@ -155,7 +151,7 @@ func (fb *FileFormatBuilder) Describe() string {
 
 // Show returns the SQL query that will show a file format.
 func (fb *FileFormatBuilder) Show() string {
-	return fmt.Sprintf(`SHOW FILE FORMATS LIKE '%v' IN DATABASE "%v"`, fb.name, fb.db)
+	return fmt.Sprintf(`SHOW FILE FORMATS LIKE %v IN DATABASE %v`, LikePattern(fb.name), Identifier{fb.db})
 }
 
 type fileFormatMetadata struct {
@@ -188,8 +184,8 @@ type descFileFormatRow struct {
 }
 
 // DescFileFormat queries the file format with a describe and returns the file format data.
-func DescFileFormat(db *sql.DB, query string) (*fileFormatData, error) {
-	rows, err := Query(db, query)
+func DescFileFormat(ctx context.Context, c *Client, query string) (*fileFormatData, error) {
+	rows, err := c.QueryContext(ctx, query)
 	if err != nil {
 		return &fileFormatData{}, err
 	}
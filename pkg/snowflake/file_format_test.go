@@ -47,15 +47,15 @@ func TestFileFormatCreate(t *testing.T) {
 	r.Equal(query, ff.Create())
 
 	ff.WithType(fileFormatType)
-	query += fmt.Sprintf(` TYPE = "%v"`, fileFormatType)
+	query += fmt.Sprintf(` TYPE = '%v'`, fileFormatType)
 	r.Equal(query, ff.Create())
 
 	ff.WithComment(comment)
-	query += fmt.Sprintf(` COMMENT = "%v"`, comment)
+	query += fmt.Sprintf(` COMMENT = '%v'`, comment)
 	r.Equal(query, ff.Create())
 
 	ff.WithCompression(compression)
-	query += fmt.Sprintf(` COMPRESSION = "%v"`, compression)
+	query += fmt.Sprintf(` COMPRESSION = '%v'`, compression)
 	r.Equal(query, ff.Create())
 
 	ff.WithNullIf([]string{`\N`, "NULL", ""})
@@ -67,7 +67,7 @@ func TestFileFormatChangeComment(t *testing.T) {
 	r := require.New(t)
 	ff := snowflake.FileFormat(fileFormatName, databaseName, schemaName)
 	r.Equal(
-		fmt.Sprintf(`ALTER FILE FORMAT "%v"."%v"."%v" SET COMMENT = "%v"`, databaseName, schemaName, fileFormatName, comment),
+		fmt.Sprintf(`ALTER FILE FORMAT "%v"."%v"."%v" SET COMMENT = '%v'`, databaseName, schemaName, fileFormatName, comment),
 		ff.ChangeComment(comment),
 	)
 }
@@ -76,11 +76,25 @@ func TestFileFormatChangeCompression(t *testing.T) {
 	r := require.New(t)
 	ff := snowflake.FileFormat(fileFormatName, databaseName, schemaName)
 	r.Equal(
-		fmt.Sprintf(`ALTER FILE FORMAT "%v"."%v"."%v" SET COMPRESSION = "%v"`, databaseName, schemaName, fileFormatName, compression),
+		fmt.Sprintf(`ALTER FILE FORMAT "%v"."%v"."%v" SET COMPRESSION = '%v'`, databaseName, schemaName, fileFormatName, compression),
 		ff.ChangeCompression(compression),
 	)
 }
 
+func TestFileFormatEscapesKeywords(t *testing.T) {
+	r := require.New(t)
+	ff := snowflake.FileFormat(fileFormatName, databaseName, schemaName)
+	ff.WithType(`csv' COMMENT = 'x`).WithCompression(`auto"`)
+	r.Equal(
+		fmt.Sprintf(`CREATE FILE FORMAT "%v"."%v"."%v" BINARY_AS_TEXT = false TRIM_SPACE = false TYPE = 'csv\' COMMENT = \'x' COMPRESSION = 'auto"'`, databaseName, schemaName, fileFormatName),
+		ff.Create(),
+	)
+	r.Equal(
+		fmt.Sprintf(`ALTER FILE FORMAT "%v"."%v"."%v" SET COMPRESSION = 'x\''`, databaseName, schemaName, fileFormatName),
+		ff.ChangeCompression(`x'`),
+	)
+}
+
 func TestFileFormatChangeBinaryAsText(t *testing.T) {
 	r := require.New(t)
 	ff := snowflake.FileFormat(fileFormatName, databaseName, schemaName)
@@ -130,7 +144,7 @@ func TestFileFormatShow(t *testing.T) {
 	r := require.New(t)
 	ff := snowflake.FileFormat(fileFormatName, databaseName, schemaName)
 	r.Equal(
-		fmt.Sprintf(`SHOW FILE FORMATS LIKE '%v' IN DATABASE "%v"`, fileFormatName, databaseName),
+		fmt.Sprintf(`SHOW FILE FORMATS LIKE 'test\\_file\\_format' IN DATABASE "%v"`, databaseName),
 		ff.Show(),
 	)
 }
@@ -29,12 +29,12 @@ type FutureGrantBuilder struct {
 func getNameAndQualifiedName(db, schema string) (string, string, futureGrantTarget) {
 	name := schema
 	futureTarget := futureSchemaTarget
-	qualifiedName := fmt.Sprintf(`"%v"."%v"`, db, schema)
+	qualifiedName := Identifier{db, schema}.String()
 
 	if schema == "" {
 		name = db
 		futureTarget = futureDatabaseTarget
-		qualifiedName = fmt.Sprintf(`"%v"`, db)
+		qualifiedName = Identifier{db}.String()
 	}
 
 	return name, qualifiedName, futureTarget
@@ -49,7 +49,7 @@ func (fgb *FutureGrantBuilder) Name() string {
 func FutureSchemaGrant(db string) GrantBuilder {
 	return &FutureGrantBuilder{
 		name:              db,
-		qualifiedName:     fmt.Sprintf(`"%v"`, db),
+		qualifiedName:     Identifier{db}.String(),
 		futureGrantType:   futureSchemaType,
 		futureGrantTarget: futureDatabaseTarget,
 	}
@@ -77,6 +77,12 @@ func FutureViewGrant(db, schema string) GrantBuilder {
 	}
 }
 
+// ObjectType returns the type of the future objects of the grant, e.g. TABLE,
+// as shown in the grant_on column of SHOW FUTURE GRANTS
+func (fgb *FutureGrantBuilder) ObjectType() string {
+	return string(fgb.futureGrantType)
+}
+
 // Show returns the SQL that will show all privileges on the grant
 func (fgb *FutureGrantBuilder) Show() string {
 	return fmt.Sprintf(`SHOW FUTURE GRANTS IN %v %v`, fgb.futureGrantTarget, fgb.qualifiedName)
@@ -87,14 +93,30 @@ func (fgb *FutureGrantBuilder) Show() string {
 type FutureGrantExecutable struct {
 	grantName         string
 	granteeName       string
+	granteeType       GranteeType
 	futureGrantType   futureGrantType
 	futureGrantTarget futureGrantTarget
 }
 
+// Grantee returns a pointer to a FutureGrantExecutable for a grantee. Only
+// roles and database roles can be granted privileges on future objects, so
+// an error is returned for any other grantee type.
+func (fgb *FutureGrantBuilder) Grantee(t GranteeType, n string) (GrantExecutable, error) {
+	if t != RoleGrantee && t != DatabaseRoleGrantee {
+		return nil, fmt.Errorf("privileges on future %vS cannot be granted to %v %v", fgb.futureGrantType, t, n)
+	}
+	return fgb.executable(t, n), nil
+}
+
 // Role returns a pointer to a FutureGrantExecutable for a role
 func (fgb *FutureGrantBuilder) Role(n string) GrantExecutable {
+	return fgb.executable(RoleGrantee, n)
+}
+
+func (fgb *FutureGrantBuilder) executable(t GranteeType, n string) *FutureGrantExecutable {
 	return &FutureGrantExecutable{
-		granteeName:       n,
+		granteeName:       qualifiedGranteeName(t, n),
+		granteeType:       t,
 		grantName:         fgb.qualifiedName,
 		futureGrantType:   fgb.futureGrantType,
 		futureGrantTarget: fgb.futureGrantTarget,
@@ -110,18 +132,18 @@ func (gb *FutureGrantBuilder) Share(n string) GrantExecutable {
 func (fge *FutureGrantExecutable) Grant(p string, w bool) string {
 	var template string
 	if w == true {
-		template = `GRANT %v ON FUTURE %vS IN %v %v TO ROLE "%v" WITH GRANT OPTION`
+		template = `GRANT %v ON FUTURE %vS IN %v %v TO %v %v WITH GRANT OPTION`
 	} else {
-		template = `GRANT %v ON FUTURE %vS IN %v %v TO ROLE "%v"`
+		template = `GRANT %v ON FUTURE %vS IN %v %v TO %v %v`
 	}
 	return fmt.Sprintf(template,
-		p, fge.futureGrantType, fge.futureGrantTarget, fge.grantName, fge.granteeName)
+		p, fge.futureGrantType, fge.futureGrantTarget, fge.grantName, fge.granteeType, fge.granteeName)
 }
 
 // Revoke returns the SQL that will revoke future privileges on the grant from the grantee
 func (fge *FutureGrantExecutable) Revoke(p string) string {
-	return fmt.Sprintf(`REVOKE %v ON FUTURE %vS IN %v %v FROM ROLE "%v"`,
-		p, fge.futureGrantType, fge.futureGrantTarget, fge.grantName, fge.granteeName)
+	return fmt.Sprintf(`REVOKE %v ON FUTURE %vS IN %v %v FROM %v %v`,
+		p, fge.futureGrantType, fge.futureGrantTarget, fge.grantName, fge.granteeType, fge.granteeName)
 }
 
 // Show returns the SQL that will show all future grants on the schema
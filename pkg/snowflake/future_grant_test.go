@@ -20,6 +20,13 @@ func TestFutureSchemaGrant(t *testing.T) {
 
 	s = fvg.Role("bob").Revoke("USAGE")
 	r.Equal(`REVOKE USAGE ON FUTURE SCHEMAS IN DATABASE "test_db" FROM ROLE "bob"`, s)
+
+	ge, err := fvg.Grantee(snowflake.DatabaseRoleGrantee, "test_db.bob")
+	r.NoError(err)
+	r.Equal(`GRANT USAGE ON FUTURE SCHEMAS IN DATABASE "test_db" TO DATABASE ROLE "test_db"."bob"`, ge.Grant("USAGE", false))
+
+	_, err = fvg.Grantee(snowflake.ShareGrantee, "bob")
+	r.EqualError(err, `privileges on future SCHEMAS cannot be granted to SHARE bob`)
 }
 
 func TestFutureTableGrant(t *testing.T) {
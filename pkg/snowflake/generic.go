@@ -11,12 +11,15 @@ import (
 type EntityType string
 
 const (
+	AccountType            EntityType = "ACCOUNT"
 	DatabaseType           EntityType = "DATABASE"
 	ManagedAccountType     EntityType = "MANAGED ACCOUNT"
 	ResourceMonitorType    EntityType = "RESOURCE MONITOR"
 	RoleType               EntityType = "ROLE"
+	SchemaType             EntityType = "SCHEMA"
 	ShareType              EntityType = "SHARE"
 	StorageIntegrationType EntityType = "STORAGE INTEGRATION"
+	TaskType               EntityType = "TASK"
 	UserType               EntityType = "USER"
 	WarehouseType          EntityType = "WAREHOUSE"
 )
@@ -27,24 +30,31 @@ type Builder struct {
 }
 
 func (b *Builder) Show() string {
-	return fmt.Sprintf(`SHOW %sS LIKE '%s'`, b.entityType, b.name)
+	return fmt.Sprintf(`SHOW %sS LIKE %s`, b.entityType, LikePattern(b.name))
+}
+
+// ShowAll returns the SQL query that will show all objects of the builder's
+// type in the account
+func (b *Builder) ShowAll() string {
+	return fmt.Sprintf(`SHOW %sS`, b.entityType)
 }
 
 func (b *Builder) Describe() string {
-	return fmt.Sprintf(`DESCRIBE %s "%s"`, b.entityType, b.name)
+	return fmt.Sprintf(`DESCRIBE %s %v`, b.entityType, Identifier{b.name})
 }
 
 func (b *Builder) Drop() string {
-	return fmt.Sprintf(`DROP %s "%s"`, b.entityType, b.name)
+	return fmt.Sprintf(`DROP %s %v`, b.entityType, Identifier{b.name})
 }
 
 func (b *Builder) Rename(newName string) string {
-	return fmt.Sprintf(`ALTER %s "%s" RENAME TO "%s"`, b.entityType, b.name, newName)
+	return fmt.Sprintf(`ALTER %s %v RENAME TO %v`, b.entityType, Identifier{b.name}, Identifier{newName})
 }
 
 // SettingBuilder is an interface for a builder that allows you to set key value pairs
 type SettingBuilder interface {
 	SetString(string, string)
+	SetIdentifier(string, string)
 	SetStringList(string, []string)
 	SetBool(string, bool)
 	SetInt(string, int)
@@ -55,10 +65,12 @@ type AlterPropertiesBuilder struct {
 	name                 string
 	entityType           EntityType
 	stringProperties     map[string]string
+	identifierProperties map[string]string
 	stringListProperties map[string][]string
 	boolProperties       map[string]bool
 	intProperties        map[string]int
 	floatProperties      map[string]float64
+	unsetProperties      map[string]bool
 }
 
 func (b *Builder) Alter() *AlterPropertiesBuilder {
@@ -66,64 +78,97 @@ func (b *Builder) Alter() *AlterPropertiesBuilder {
 		name:                 b.name,
 		entityType:           b.entityType,
 		stringProperties:     make(map[string]string),
+		identifierProperties: make(map[string]string),
 		stringListProperties: make(map[string][]string),
 		boolProperties:       make(map[string]bool),
 		intProperties:        make(map[string]int),
 		floatProperties:      make(map[string]float64),
+		unsetProperties:      make(map[string]bool),
 	}
 }
 
+// SetString, like the other setters and Unset, replaces whatever was set or
+// unset for key before, so that a property is never both set and unset
 func (ab *AlterPropertiesBuilder) SetString(key, value string) {
-	ab.stringProperties[key] = value
+	ab.stringProperties[ab.clear(key)] = value
+}
+
+// SetIdentifier sets key to the name of an object, e.g. a warehouse's
+// resource monitor, which is written as an identifier rather than a string
+func (ab *AlterPropertiesBuilder) SetIdentifier(key, value string) {
+	ab.identifierProperties[ab.clear(key)] = value
 }
 
 func (ab *AlterPropertiesBuilder) SetStringList(key string, value []string) {
-	ab.stringListProperties[key] = value
+	ab.stringListProperties[ab.clear(key)] = value
 }
 
 func (ab *AlterPropertiesBuilder) SetBool(key string, value bool) {
-	ab.boolProperties[key] = value
+	ab.boolProperties[ab.clear(key)] = value
 }
 
 func (ab *AlterPropertiesBuilder) SetInt(key string, value int) {
-	ab.intProperties[key] = value
+	ab.intProperties[ab.clear(key)] = value
 }
 
 func (ab *AlterPropertiesBuilder) SetFloat(key string, value float64) {
-	ab.floatProperties[key] = value
+	ab.floatProperties[ab.clear(key)] = value
 }
 
-func (ab *AlterPropertiesBuilder) Statement() string {
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf(`ALTER %s "%s" SET`, ab.entityType, ab.name)) // TODO handle error
+// Unset reverts the property key to its default, e.g. for a property that
+// was removed from the configuration
+func (ab *AlterPropertiesBuilder) Unset(key string) {
+	ab.unsetProperties[ab.clear(key)] = true
+}
 
-	for k, v := range ab.stringProperties {
-		sb.WriteString(fmt.Sprintf(" %s='%s'", strings.ToUpper(k), EscapeString(v)))
-	}
+// clear forgets what was set or unset for key, and returns it as written in
+// the statements. Keys are not case sensitive.
+func (ab *AlterPropertiesBuilder) clear(key string) string {
+	key = strings.ToUpper(key)
+	delete(ab.stringProperties, key)
+	delete(ab.identifierProperties, key)
+	delete(ab.stringListProperties, key)
+	delete(ab.boolProperties, key)
+	delete(ab.intProperties, key)
+	delete(ab.floatProperties, key)
+	delete(ab.unsetProperties, key)
+	return key
+}
 
-	for k, v := range ab.stringListProperties {
-		sb.WriteString(fmt.Sprintf(" %s=%s", strings.ToUpper(k), formatStringList(v)))
-	}
+// Statement returns the statement that sets the properties
+func (ab *AlterPropertiesBuilder) Statement() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`ALTER %s %v SET`, ab.entityType, Identifier{ab.name})) // TODO handle error
+	writeProperties(&sb, ab.stringProperties, ab.identifierProperties, ab.stringListProperties, ab.boolProperties, ab.intProperties, ab.floatProperties)
+	return sb.String()
+}
 
-	for k, v := range ab.boolProperties {
-		sb.WriteString(fmt.Sprintf(" %s=%t", strings.ToUpper(k), v))
-	}
+// UnsetStatement returns the statement that unsets the properties passed to
+// Unset
+func (ab *AlterPropertiesBuilder) UnsetStatement() string {
+	keys := boolKeys(ab.unsetProperties)
+	return fmt.Sprintf(`ALTER %s %v UNSET %s`, ab.entityType, Identifier{ab.name}, strings.Join(keys, ", "))
+}
 
-	for k, v := range ab.intProperties {
-		sb.WriteString(fmt.Sprintf(" %s=%d", strings.ToUpper(k), v))
+// Statements returns the statements that apply all changes. Snowflake does
+// not allow SET and UNSET in one statement, so there is one for each, if it
+// has anything to do.
+func (ab *AlterPropertiesBuilder) Statements() []string {
+	stmts := []string{}
+	if len(ab.stringProperties)+len(ab.identifierProperties)+len(ab.stringListProperties)+len(ab.boolProperties)+len(ab.intProperties)+len(ab.floatProperties) > 0 {
+		stmts = append(stmts, ab.Statement())
 	}
-
-	for k, v := range ab.floatProperties {
-		sb.WriteString(fmt.Sprintf(" %s=%.2f", strings.ToUpper(k), v))
+	if len(ab.unsetProperties) > 0 {
+		stmts = append(stmts, ab.UnsetStatement())
 	}
-
-	return sb.String()
+	return stmts
 }
 
 type CreateBuilder struct {
 	name                 string
 	entityType           EntityType
 	stringProperties     map[string]string
+	identifierProperties map[string]string
 	stringListProperties map[string][]string
 	boolProperties       map[string]bool
 	intProperties        map[string]int
@@ -135,6 +180,7 @@ func (b *Builder) Create() *CreateBuilder {
 		name:                 b.name,
 		entityType:           b.entityType,
 		stringProperties:     make(map[string]string),
+		identifierProperties: make(map[string]string),
 		stringListProperties: make(map[string][]string),
 		boolProperties:       make(map[string]bool),
 		intProperties:        make(map[string]int),
@@ -146,6 +192,12 @@ func (b *CreateBuilder) SetString(key, value string) {
 	b.stringProperties[key] = value
 }
 
+// SetIdentifier sets key to the name of an object, e.g. a warehouse's
+// resource monitor, which is written as an identifier rather than a string
+func (b *CreateBuilder) SetIdentifier(key, value string) {
+	b.identifierProperties[key] = value
+}
+
 func (b *CreateBuilder) SetStringList(key string, value []string) {
 	b.stringListProperties[key] = value
 }
@@ -164,58 +216,91 @@ func (b *CreateBuilder) SetFloat(key string, value float64) {
 
 func (b *CreateBuilder) Statement() string {
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf(`CREATE %s "%s"`, b.entityType, b.name)) // TODO handle error
+	sb.WriteString(fmt.Sprintf(`CREATE %s %v`, b.entityType, Identifier{b.name})) // TODO handle error
+	writeProperties(&sb, b.stringProperties, b.identifierProperties, b.stringListProperties, b.boolProperties, b.intProperties, b.floatProperties)
+	return sb.String()
+}
 
-	sortedStringProperties := make([]string, 0)
-	for k := range b.stringProperties {
-		sortedStringProperties = append(sortedStringProperties, k)
+// writeProperties writes properties as KEY=value pairs to sb. They are
+// ordered by type and then by key, so that the same properties always make
+// the same statement.
+func writeProperties(
+	sb *strings.Builder,
+	stringProperties map[string]string,
+	identifierProperties map[string]string,
+	stringListProperties map[string][]string,
+	boolProperties map[string]bool,
+	intProperties map[string]int,
+	floatProperties map[string]float64,
+) {
+	for _, k := range stringKeys(stringProperties) {
+		sb.WriteString(fmt.Sprintf(" %s='%s'", strings.ToUpper(k), EscapeString(stringProperties[k])))
 	}
-	sort.Strings(sortedStringProperties)
-
-	for _, k := range sortedStringProperties {
-		sb.WriteString(fmt.Sprintf(" %s='%s'", strings.ToUpper(k), EscapeString(b.stringProperties[k])))
+	for _, k := range stringKeys(identifierProperties) {
+		sb.WriteString(fmt.Sprintf(" %s=%v", strings.ToUpper(k), Identifier{identifierProperties[k]}))
 	}
-
-	sortedStringListProperties := make([]string, 0)
-	for k := range b.stringListProperties {
-		sortedStringListProperties = append(sortedStringListProperties, k)
+	for _, k := range stringListKeys(stringListProperties) {
+		sb.WriteString(fmt.Sprintf(" %s=%s", strings.ToUpper(k), formatStringList(stringListProperties[k])))
 	}
-
-	for _, k := range sortedStringListProperties {
-		sb.WriteString(fmt.Sprintf(" %s=%s", strings.ToUpper(k), formatStringList(b.stringListProperties[k])))
+	for _, k := range boolKeys(boolProperties) {
+		sb.WriteString(fmt.Sprintf(" %s=%t", strings.ToUpper(k), boolProperties[k]))
 	}
-
-	sortedBoolProperties := make([]string, 0)
-	for k := range b.boolProperties {
-		sortedBoolProperties = append(sortedBoolProperties, k)
+	for _, k := range intKeys(intProperties) {
+		sb.WriteString(fmt.Sprintf(" %s=%d", strings.ToUpper(k), intProperties[k]))
 	}
-	sort.Strings(sortedBoolProperties)
-
-	for _, k := range sortedBoolProperties {
-		sb.WriteString(fmt.Sprintf(" %s=%t", strings.ToUpper(k), b.boolProperties[k]))
+	for _, k := range floatKeys(floatProperties) {
+		sb.WriteString(fmt.Sprintf(" %s=%.2f", strings.ToUpper(k), floatProperties[k]))
 	}
+}
 
-	sortedIntProperties := make([]string, 0)
-	for k := range b.intProperties {
-		sortedIntProperties = append(sortedIntProperties, k)
+// stringKeys returns the sorted keys of m
+func stringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
-	sort.Strings(sortedIntProperties)
+	sort.Strings(keys)
+	return keys
+}
 
-	for _, k := range sortedIntProperties {
-		sb.WriteString(fmt.Sprintf(" %s=%d", strings.ToUpper(k), b.intProperties[k]))
+// stringListKeys returns the sorted keys of m
+func stringListKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
+	sort.Strings(keys)
+	return keys
+}
 
-	sortedFloatProperties := make([]string, 0)
-	for k := range b.floatProperties {
-		sortedFloatProperties = append(sortedFloatProperties, k)
+// boolKeys returns the sorted keys of m
+func boolKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
-	sort.Strings(sortedFloatProperties)
+	sort.Strings(keys)
+	return keys
+}
 
-	for _, k := range sortedFloatProperties {
-		sb.WriteString(fmt.Sprintf(" %s=%.2f", strings.ToUpper(k), b.floatProperties[k]))
+// intKeys returns the sorted keys of m
+func intKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
+	sort.Strings(keys)
+	return keys
+}
 
-	return sb.String()
+// floatKeys returns the sorted keys of m
+func floatKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 func formatStringList(list []string) string {
@@ -23,3 +23,67 @@ func TestFormatStringListWithEscape(t *testing.T) {
 
 	r.Equal("('th\\'is', 'is', 'just', 'a', 'test')", out)
 }
+
+func TestCreateBuilderIsDeterministic(t *testing.T) {
+	r := require.New(t)
+
+	b := Role("r").Create()
+	b.SetStringList("z_list", []string{"b"})
+	b.SetStringList("a_list", []string{"a"})
+	b.SetString("comment", "c")
+	b.SetString("a", "x")
+	b.SetBool("b", true)
+	b.SetBool("a", false)
+	for i := 0; i < 10; i++ {
+		r.Equal(`CREATE ROLE "r" A='x' COMMENT='c' A_LIST=('a') Z_LIST=('b') A=false B=true`, b.Statement())
+	}
+}
+
+func TestAlterPropertiesBuilder(t *testing.T) {
+	r := require.New(t)
+
+	b := Warehouse("w").Alter()
+	b.SetString("warehouse_size", "SMALL")
+	b.SetString("comment", "c")
+	b.SetStringList("list", []string{"a", "b"})
+	b.SetBool("auto_resume", true)
+	b.SetInt("max_cluster_count", 2)
+	b.SetInt("auto_suspend", 60)
+	b.SetFloat("quota", 1.5)
+	for i := 0; i < 10; i++ {
+		r.Equal(`ALTER WAREHOUSE "w" SET COMMENT='c' WAREHOUSE_SIZE='SMALL' LIST=('a', 'b') AUTO_RESUME=true AUTO_SUSPEND=60 MAX_CLUSTER_COUNT=2 QUOTA=1.50`, b.Statement())
+	}
+	r.Equal([]string{b.Statement()}, b.Statements())
+
+	// a property is either set or unset, whichever was asked for last
+	b.Unset("resource_monitor")
+	b.Unset("comment")
+	b.Unset("auto_resume")
+	b.SetBool("AUTO_RESUME", false)
+	r.Equal([]string{
+		`ALTER WAREHOUSE "w" SET WAREHOUSE_SIZE='SMALL' LIST=('a', 'b') AUTO_RESUME=false AUTO_SUSPEND=60 MAX_CLUSTER_COUNT=2 QUOTA=1.50`,
+		`ALTER WAREHOUSE "w" UNSET COMMENT, RESOURCE_MONITOR`,
+	}, b.Statements())
+
+	unsetOnly := Warehouse("w").Alter()
+	unsetOnly.Unset("comment")
+	r.Equal([]string{`ALTER WAREHOUSE "w" UNSET COMMENT`}, unsetOnly.Statements())
+	r.Empty(Warehouse("w").Alter().Statements())
+}
+
+func TestSetIdentifier(t *testing.T) {
+	r := require.New(t)
+
+	c := Warehouse("w").Create()
+	c.SetString("comment", "c")
+	c.SetIdentifier("resource_monitor", `my "monitor"`)
+	r.Equal(`CREATE WAREHOUSE "w" COMMENT='c' RESOURCE_MONITOR="my ""monitor"""`, c.Statement())
+
+	a := Warehouse("w").Alter()
+	a.SetIdentifier("resource_monitor", "rm")
+	r.Equal([]string{`ALTER WAREHOUSE "w" SET RESOURCE_MONITOR="rm"`}, a.Statements())
+
+	m := ResourceMonitor("m").Create()
+	m.SetIdentifier("owner", "o")
+	r.Equal(`CREATE RESOURCE MONITOR "m" OWNER="o"`, m.Statement())
+}
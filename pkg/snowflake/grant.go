@@ -2,6 +2,7 @@ package snowflake
 
 import (
 	"fmt"
+	"strings"
 )
 
 type grantType string
@@ -28,6 +29,7 @@ type GrantExecutable interface {
 
 type GrantBuilder interface {
 	Name() string
+	Grantee(GranteeType, string) (GrantExecutable, error)
 	Role(string) GrantExecutable
 	Share(string) GrantExecutable
 	Show() string
@@ -56,7 +58,7 @@ func AccountGrant() GrantBuilder {
 func DatabaseGrant(name string) GrantBuilder {
 	return &CurrentGrantBuilder{
 		name:          name,
-		qualifiedName: fmt.Sprintf(`"%v"`, name),
+		qualifiedName: Identifier{name}.String(),
 		grantType:     databaseType,
 	}
 }
@@ -65,7 +67,7 @@ func DatabaseGrant(name string) GrantBuilder {
 func SchemaGrant(db, schema string) GrantBuilder {
 	return &CurrentGrantBuilder{
 		name:          schema,
-		qualifiedName: fmt.Sprintf(`"%v"."%v"`, db, schema),
+		qualifiedName: Identifier{db, schema}.String(),
 		grantType:     schemaType,
 	}
 }
@@ -74,7 +76,7 @@ func SchemaGrant(db, schema string) GrantBuilder {
 func StageGrant(db, schema, stage string) GrantBuilder {
 	return &CurrentGrantBuilder{
 		name:          stage,
-		qualifiedName: fmt.Sprintf(`"%v"."%v"."%v"`, db, schema, stage),
+		qualifiedName: Identifier{db, schema, stage}.String(),
 		grantType:     stageType,
 	}
 }
@@ -83,7 +85,7 @@ func StageGrant(db, schema, stage string) GrantBuilder {
 func ViewGrant(db, schema, view string) GrantBuilder {
 	return &CurrentGrantBuilder{
 		name:          view,
-		qualifiedName: fmt.Sprintf(`"%v"."%v"."%v"`, db, schema, view),
+		qualifiedName: Identifier{db, schema, view}.String(),
 		grantType:     viewType,
 	}
 }
@@ -92,7 +94,7 @@ func ViewGrant(db, schema, view string) GrantBuilder {
 func TableGrant(db, schema, table string) GrantBuilder {
 	return &CurrentGrantBuilder{
 		name:          table,
-		qualifiedName: fmt.Sprintf(`"%v"."%v"."%v"`, db, schema, table),
+		qualifiedName: Identifier{db, schema, table}.String(),
 		grantType:     tableType,
 	}
 }
@@ -101,7 +103,7 @@ func TableGrant(db, schema, table string) GrantBuilder {
 func ResourceMonitorGrant(w string) GrantBuilder {
 	return &CurrentGrantBuilder{
 		name:          w,
-		qualifiedName: fmt.Sprintf(`"%v"`, w),
+		qualifiedName: Identifier{w}.String(),
 		grantType:     resourceMonitorType,
 	}
 }
@@ -110,7 +112,7 @@ func ResourceMonitorGrant(w string) GrantBuilder {
 func IntegrationGrant(w string) GrantBuilder {
 	return &CurrentGrantBuilder{
 		name:          w,
-		qualifiedName: fmt.Sprintf(`"%v"`, w),
+		qualifiedName: Identifier{w}.String(),
 		grantType:     integrationType,
 	}
 }
@@ -119,7 +121,7 @@ func IntegrationGrant(w string) GrantBuilder {
 func WarehouseGrant(w string) GrantBuilder {
 	return &CurrentGrantBuilder{
 		name:          w,
-		qualifiedName: fmt.Sprintf(`"%v"`, w),
+		qualifiedName: Identifier{w}.String(),
 		grantType:     warehouseType,
 	}
 }
@@ -129,40 +131,64 @@ func (gb *CurrentGrantBuilder) Show() string {
 	return fmt.Sprintf(`SHOW GRANTS ON %v %v`, gb.grantType, gb.qualifiedName)
 }
 
-type granteeType string
+// GranteeType is the kind of principal a privilege can be granted to
+type GranteeType string
 
 const (
-	roleType  granteeType = "ROLE"
-	shareType granteeType = "SHARE"
-	userType  granteeType = "USER" // user is only supported for RoleGrants.
+	RoleGrantee         GranteeType = "ROLE"
+	ShareGrantee        GranteeType = "SHARE"
+	UserGrantee         GranteeType = "USER"
+	DatabaseRoleGrantee GranteeType = "DATABASE ROLE"
 )
 
+// GranteeTypeFromShow converts the granted_to/grant_to column of SHOW GRANTS,
+// which uses underscores (e.g. DATABASE_ROLE), to a GranteeType.
+func GranteeTypeFromShow(s string) GranteeType {
+	return GranteeType(strings.ReplaceAll(strings.ToUpper(s), "_", " "))
+}
+
+// qualifiedGranteeName quotes the grantee name. Database roles are named
+// <database>.<role> and have each part quoted separately.
+func qualifiedGranteeName(t GranteeType, n string) string {
+	if t == DatabaseRoleGrantee {
+		parts := strings.SplitN(n, ".", 2)
+		if len(parts) == 2 {
+			return Identifier{parts[0], parts[1]}.String()
+		}
+	}
+	return Identifier{n}.String()
+}
+
 // CurrentGrantExecutable abstracts the creation of SQL queries to build grants for
 // different resources
 type CurrentGrantExecutable struct {
 	grantName   string
 	grantType   grantType
 	granteeName string
-	granteeType granteeType
+	granteeType GranteeType
+}
+
+// Grantee returns a pointer to a CurrentGrantExecutable for a grantee of any type
+func (gb *CurrentGrantBuilder) Grantee(t GranteeType, n string) (GrantExecutable, error) {
+	return gb.executable(t, n), nil
 }
 
 // Role returns a pointer to a CurrentGrantExecutable for a role
 func (gb *CurrentGrantBuilder) Role(n string) GrantExecutable {
-	return &CurrentGrantExecutable{
-		grantName:   gb.qualifiedName,
-		grantType:   gb.grantType,
-		granteeName: n,
-		granteeType: roleType,
-	}
+	return gb.executable(RoleGrantee, n)
 }
 
 // Share returns a pointer to a CurrentGrantExecutable for a share
 func (gb *CurrentGrantBuilder) Share(n string) GrantExecutable {
+	return gb.executable(ShareGrantee, n)
+}
+
+func (gb *CurrentGrantBuilder) executable(t GranteeType, n string) *CurrentGrantExecutable {
 	return &CurrentGrantExecutable{
 		grantName:   gb.qualifiedName,
 		grantType:   gb.grantType,
-		granteeName: n,
-		granteeType: shareType,
+		granteeName: qualifiedGranteeName(t, n),
+		granteeType: t,
 	}
 }
 
@@ -170,11 +196,11 @@ func (gb *CurrentGrantBuilder) Share(n string) GrantExecutable {
 func (ge *CurrentGrantExecutable) Grant(p string, w bool) string {
 	var template string
 	if p == `OWNERSHIP` {
-		template = `GRANT %v ON %v %v TO %v "%v" COPY CURRENT GRANTS`
+		template = `GRANT %v ON %v %v TO %v %v COPY CURRENT GRANTS`
 	} else if w == true {
-		template = `GRANT %v ON %v %v TO %v "%v" WITH GRANT OPTION`
+		template = `GRANT %v ON %v %v TO %v %v WITH GRANT OPTION`
 	} else {
-		template = `GRANT %v ON %v %v TO %v "%v"`
+		template = `GRANT %v ON %v %v TO %v %v`
 	}
 	return fmt.Sprintf(template,
 		p, ge.grantType, ge.grantName, ge.granteeType, ge.granteeName)
@@ -182,11 +208,11 @@ func (ge *CurrentGrantExecutable) Grant(p string, w bool) string {
 
 // Revoke returns the SQL that will revoke privileges on the grant from the grantee
 func (ge *CurrentGrantExecutable) Revoke(p string) string {
-	return fmt.Sprintf(`REVOKE %v ON %v %v FROM %v "%v"`,
+	return fmt.Sprintf(`REVOKE %v ON %v %v FROM %v %v`,
 		p, ge.grantType, ge.grantName, ge.granteeType, ge.granteeName)
 }
 
 // Show returns the SQL that will show all grants of the grantee
 func (ge *CurrentGrantExecutable) Show() string {
-	return fmt.Sprintf(`SHOW GRANTS OF %v "%v"`, ge.granteeType, ge.granteeName)
+	return fmt.Sprintf(`SHOW GRANTS OF %v %v`, ge.granteeType, ge.granteeName)
 }
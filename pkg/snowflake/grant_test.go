@@ -29,6 +29,19 @@ func TestDatabaseGrant(t *testing.T) {
 
 	s = dg.Share("bob").Revoke("USAGE")
 	r.Equal(`REVOKE USAGE ON DATABASE "testDB" FROM SHARE "bob"`, s)
+
+	ge, err := dg.Grantee(snowflake.DatabaseRoleGrantee, "testDB.bob")
+	r.NoError(err)
+	r.Equal(`GRANT USAGE ON DATABASE "testDB" TO DATABASE ROLE "testDB"."bob"`, ge.Grant("USAGE", false))
+	r.Equal(`REVOKE USAGE ON DATABASE "testDB" FROM DATABASE ROLE "testDB"."bob"`, ge.Revoke("USAGE"))
+}
+
+func TestGranteeTypeFromShow(t *testing.T) {
+	r := require.New(t)
+	r.Equal(snowflake.RoleGrantee, snowflake.GranteeTypeFromShow("ROLE"))
+	r.Equal(snowflake.ShareGrantee, snowflake.GranteeTypeFromShow("SHARE"))
+	r.Equal(snowflake.UserGrantee, snowflake.GranteeTypeFromShow("USER"))
+	r.Equal(snowflake.DatabaseRoleGrantee, snowflake.GranteeTypeFromShow("DATABASE_ROLE"))
 }
 
 func TestSchemaGrant(t *testing.T) {
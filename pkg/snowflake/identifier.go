@@ -0,0 +1,68 @@
+package snowflake
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Identifier is the name of a Snowflake object, qualified by the names of the
+// objects that contain it, e.g. Identifier{"db", "schema", "table"}. Its
+// String quotes every part, so names are always taken literally, whatever
+// their case and whatever characters they contain.
+type Identifier []string
+
+// String returns the identifier as SQL, e.g. "db"."schema"."table". Double
+// quotes in names are escaped by doubling them. Empty parts are left empty, as
+// in "db".."table" for a table in the PUBLIC schema. Names are not normalized:
+// resources apply the provider's IdentifierPolicy before they reach builders.
+func (id Identifier) String() string {
+	parts := make([]string, len(id))
+	for i, p := range id {
+		if p != "" {
+			parts[i] = `"` + strings.Replace(p, `"`, `""`, -1) + `"`
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// ParseIdentifier reverses Identifier.String
+func ParseIdentifier(s string) (Identifier, error) {
+	id := Identifier{}
+	var part strings.Builder
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quoted && c == '"' && i+1 < len(s) && s[i+1] == '"':
+			part.WriteByte('"')
+			i++
+		case c == '"':
+			quoted = !quoted
+		case !quoted && c == '.':
+			id = append(id, part.String())
+			part.Reset()
+		case quoted:
+			part.WriteByte(c)
+		default:
+			return nil, fmt.Errorf("unexpected %q at %d of identifier %v", c, i, s)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in identifier %v", s)
+	}
+	return append(id, part.String()), nil
+}
+
+// EscapeLike escapes the wildcards _ and % in s, and the escape character \,
+// so that s only matches itself as a LIKE pattern
+func EscapeLike(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, `_`, `\_`, -1)
+	return strings.Replace(s, `%`, `\%`, -1)
+}
+
+// LikePattern returns the string literal of a LIKE pattern that only matches
+// name, e.g. for SHOW ... LIKE
+func LikePattern(name string) string {
+	return `'` + EscapeString(EscapeLike(name)) + `'`
+}
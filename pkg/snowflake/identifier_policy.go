@@ -0,0 +1,62 @@
+package snowflake
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// IdentifierPolicy decides how the names in a configuration map to the names
+// of Snowflake objects
+type IdentifierPolicy string
+
+const (
+	// IdentifierPolicyQuoted preserves the case of names by always quoting
+	// them, so analytics and ANALYTICS are different objects. It is the
+	// default.
+	IdentifierPolicyQuoted IdentifierPolicy = "quoted"
+	// IdentifierPolicyUppercase follows Snowflake's semantics for unquoted
+	// identifiers: names that could be written unquoted are folded to
+	// uppercase, so analytics and ANALYTICS are the same object. Other names,
+	// e.g. with spaces, are taken literally; configurations cannot use them,
+	// see Validate.
+	IdentifierPolicyUppercase IdentifierPolicy = "uppercase"
+)
+
+// IdentifierPolicies are the valid identifier policies
+var IdentifierPolicies = []string{string(IdentifierPolicyQuoted), string(IdentifierPolicyUppercase)}
+
+var unquotedIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*$`)
+
+// Normalize returns the name of the object that name refers to under p
+func (p IdentifierPolicy) Normalize(name string) string {
+	if p == IdentifierPolicyUppercase && unquotedIdentifier.MatchString(name) {
+		return strings.ToUpper(name)
+	}
+	return name
+}
+
+// Validate returns an error if name cannot be used in a configuration under p.
+// Under the uppercase policy a name is only folded if it is a valid unquoted
+// identifier, anything else would silently keep its case.
+func (p IdentifierPolicy) Validate(name string) error {
+	if p == IdentifierPolicyUppercase && !unquotedIdentifier.MatchString(name) {
+		return fmt.Errorf("'%s' is not a valid unquoted identifier, as the uppercase identifier policy requires", name)
+	}
+	return nil
+}
+
+// ParseIdentifierPolicy returns the policy named s
+func ParseIdentifierPolicy(s string) (IdentifierPolicy, error) {
+	for _, p := range IdentifierPolicies {
+		if strings.EqualFold(s, p) {
+			return IdentifierPolicy(p), nil
+		}
+	}
+	return "", fmt.Errorf("unknown identifier policy %v, expected one of %v", s, strings.Join(IdentifierPolicies, ", "))
+}
+
+// Equal reports whether a and b name the same object under p
+func (p IdentifierPolicy) Equal(a, b string) bool {
+	return p.Normalize(a) == p.Normalize(b)
+}
@@ -0,0 +1,81 @@
+package snowflake_test
+
+import (
+	"testing"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentifierPolicyNormalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		quoted    string
+		uppercase string
+	}{
+		{"analytics", "analytics", "ANALYTICS"},
+		{"Mixed_Case$1", "Mixed_Case$1", "MIXED_CASE$1"},
+		{"_private", "_private", "_PRIVATE"},
+		{"has space", "has space", "has space"},
+		{"1starts_with_digit", "1starts_with_digit", "1starts_with_digit"},
+		{`a"b`, `a"b`, `a"b`},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			r.Equal(tt.quoted, snowflake.IdentifierPolicyQuoted.Normalize(tt.name))
+			r.Equal(tt.uppercase, snowflake.IdentifierPolicyUppercase.Normalize(tt.name))
+		})
+	}
+}
+
+func TestParseIdentifierPolicy(t *testing.T) {
+	r := require.New(t)
+
+	p, err := snowflake.ParseIdentifierPolicy("quoted")
+	r.NoError(err)
+	r.Equal(snowflake.IdentifierPolicyQuoted, p)
+
+	p, err = snowflake.ParseIdentifierPolicy("UPPERCASE")
+	r.NoError(err)
+	r.Equal(snowflake.IdentifierPolicyUppercase, p)
+
+	_, err = snowflake.ParseIdentifierPolicy("lowercase")
+	r.EqualError(err, "unknown identifier policy lowercase, expected one of quoted, uppercase")
+}
+
+func TestIdentifierPolicyUppercase(t *testing.T) {
+	r := require.New(t)
+	p := snowflake.IdentifierPolicyUppercase
+
+	r.True(p.Equal("analytics", "ANALYTICS"))
+	r.False(p.Equal("has space", "HAS SPACE"))
+
+	r.NoError(p.Validate("analyst$1"))
+	r.EqualError(p.Validate("1analyst"), "'1analyst' is not a valid unquoted identifier, as the uppercase identifier policy requires")
+
+	// validation runs before the provider is configured, so it must not
+	// depend on the policy
+	_, errs := snowflake.ValidateIdentifier("1analyst")
+	r.Empty(errs)
+}
+
+func TestIdentifierPolicyQuoted(t *testing.T) {
+	r := require.New(t)
+	p := snowflake.IdentifierPolicyQuoted
+
+	r.False(p.Equal("analytics", "ANALYTICS"))
+	r.NoError(p.Validate("1analyst"))
+}
+
+// TestIdentifierLiteral checks that builders take names literally: the policy
+// is applied by the resources, with the client's policy, before names reach
+// them
+func TestIdentifierLiteral(t *testing.T) {
+	r := require.New(t)
+
+	r.Equal(`"db"."has space"."t"`, snowflake.Identifier{"db", "has space", "t"}.String())
+	r.Equal(`DROP DATABASE "analytics"`, snowflake.Database("analytics").Drop())
+	r.Equal(`SHOW ROLES LIKE 'analyst'`, snowflake.Role("analyst").Show())
+}
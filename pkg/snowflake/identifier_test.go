@@ -0,0 +1,146 @@
+package snowflake_test
+
+import (
+	"math/rand"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentifier(t *testing.T) {
+	tests := []struct {
+		id       snowflake.Identifier
+		expected string
+	}{
+		{snowflake.Identifier{"db"}, `"db"`},
+		{snowflake.Identifier{"db", "schema", "table"}, `"db"."schema"."table"`},
+		{snowflake.Identifier{"db", "", "table"}, `"db".."table"`},
+		{snowflake.Identifier{`my "quoted" db`}, `"my ""quoted"" db"`},
+		{snowflake.Identifier{`a.b`, `c`}, `"a.b"."c"`},
+		{snowflake.Identifier{`"`}, `""""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			r := require.New(t)
+			r.Equal(tt.expected, tt.id.String())
+
+			parsed, err := snowflake.ParseIdentifier(tt.expected)
+			r.NoError(err)
+			r.Equal(tt.id, parsed)
+		})
+	}
+}
+
+func TestParseIdentifierErrors(t *testing.T) {
+	r := require.New(t)
+	_, err := snowflake.ParseIdentifier(`"db`)
+	r.EqualError(err, `unterminated quote in identifier "db`)
+	_, err = snowflake.ParseIdentifier(`db`)
+	r.Error(err)
+	_, err = snowflake.ParseIdentifier(`"db"x`)
+	r.Error(err)
+}
+
+func TestLikePattern(t *testing.T) {
+	r := require.New(t)
+	r.Equal(`'good\\_name'`, snowflake.LikePattern("good_name"))
+	r.Equal(`'100\\%'`, snowflake.LikePattern("100%"))
+	r.Equal(`'a\\\\b'`, snowflake.LikePattern(`a\b`))
+	r.Equal(`'it\'s'`, snowflake.LikePattern(`it's`))
+}
+
+func TestBuildersEscapeIdentifiers(t *testing.T) {
+	r := require.New(t)
+	r.Equal(`DROP DATABASE "a""b"`, snowflake.Database(`a"b`).Drop())
+	r.Equal(`ALTER ROLE "a""b" RENAME TO "c""d"`, snowflake.Role(`a"b`).Rename(`c"d`))
+	r.Equal(`SHOW USERS LIKE 'a\\_b\'c'`, snowflake.User(`a_b'c`).Show())
+	r.Equal(`GRANT ROLE "r""1" TO USER "u""1"`, snowflake.RoleGrant(`r"1`).User(`u"1`).Grant())
+	r.Equal(`SHOW GRANTS ON SCHEMA "d""b"."s"`, snowflake.SchemaGrant(`d"b`, "s").Show())
+	r.Equal(`ALTER STAGE "db"."s"."st" SET COMMENT = 'it\'s'`, snowflake.Stage("st", "db", "s").ChangeComment("it's"))
+	r.Equal(`DROP VIEW "db".."v""1"`, snowflake.View(`v"1`).WithDB("db").Drop())
+}
+
+// unquoteString reads a string literal the way Snowflake does, with \ escaping
+// the character after it
+func unquoteString(t *testing.T, literal string) string {
+	require.True(t, strings.HasPrefix(literal, "'") && strings.HasSuffix(literal, "'"), literal)
+	literal = literal[1 : len(literal)-1]
+
+	var s strings.Builder
+	for i := 0; i < len(literal); i++ {
+		if literal[i] == '\\' {
+			i++
+		} else {
+			require.NotEqual(t, byte('\''), literal[i], "unescaped quote in %v", literal)
+		}
+		s.WriteByte(literal[i])
+	}
+	return s.String()
+}
+
+// likeRegexp compiles a LIKE pattern with \ as its escape character
+func likeRegexp(pattern string) *regexp.Regexp {
+	var re strings.Builder
+	re.WriteString(`(?s)^`)
+	p := []rune(pattern)
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; {
+		case c == '\\' && i+1 < len(p):
+			i++
+			re.WriteString(regexp.QuoteMeta(string(p[i])))
+		case c == '%':
+			re.WriteString(`.*`)
+		case c == '_':
+			re.WriteString(`.`)
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	re.WriteString(`$`)
+	return regexp.MustCompile(re.String())
+}
+
+// names generates names that are heavy in the characters that need escaping
+type names []string
+
+func (names) Generate(rand *rand.Rand, size int) reflect.Value {
+	alphabet := []rune(`"'\._%aZ .ü` + "\n")
+	n := make(names, 1+rand.Intn(3))
+	for i := range n {
+		name := make([]rune, rand.Intn(size+1))
+		for j := range name {
+			name[j] = alphabet[rand.Intn(len(alphabet))]
+		}
+		n[i] = string(name)
+	}
+	return reflect.ValueOf(n)
+}
+
+// TestIdentifierFuzz checks on random names that quoting round-trips and that
+// LIKE patterns only match the name they were made from.
+func TestIdentifierFuzz(t *testing.T) {
+	config := &quick.Config{MaxCount: 2000}
+
+	roundTrip := func(n names) bool {
+		id := snowflake.Identifier(n)
+		parsed, err := snowflake.ParseIdentifier(id.String())
+		return err == nil && reflect.DeepEqual(id, parsed)
+	}
+	require.NoError(t, quick.Check(roundTrip, config))
+
+	likeMatchesOnlyName := func(n names) bool {
+		re := likeRegexp(unquoteString(t, snowflake.LikePattern(n[0])))
+		for _, other := range n[1:] {
+			if other != n[0] && re.MatchString(other) {
+				return false
+			}
+		}
+		return re.MatchString(n[0])
+	}
+	require.NoError(t, quick.Check(likeMatchesOnlyName, config))
+}
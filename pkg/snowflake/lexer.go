@@ -0,0 +1,322 @@
+package snowflake
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TokenKind is the lexical class of a Token
+type TokenKind int
+
+// The kinds of tokens of Snowflake SQL
+const (
+	// TokenSpace is a run of whitespace
+	TokenSpace TokenKind = iota
+	// TokenComment is a -- or // comment up to the end of the line, or a
+	// /* */ comment
+	TokenComment
+	// TokenWord is a keyword or an unquoted identifier, e.g. SELECT or
+	// my_table. Variables and positional columns, e.g. $1, are words too.
+	TokenWord
+	// TokenQuotedIdentifier is an identifier in double quotes, e.g. "My Table"
+	TokenQuotedIdentifier
+	// TokenString is a string literal in single quotes, with either \ or
+	// doubled quotes as escapes, e.g. 'it\'s' or 'it''s'
+	TokenString
+	// TokenDollarString is a string literal in $$, e.g. the body of a function
+	TokenDollarString
+	// TokenNumber is a numeric literal, e.g. 42 or 1.5e3
+	TokenNumber
+	// TokenSymbol is an operator or punctuation, e.g. ( or ::
+	TokenSymbol
+)
+
+var tokenKindNames = map[TokenKind]string{
+	TokenSpace:            "space",
+	TokenComment:          "comment",
+	TokenWord:             "word",
+	TokenQuotedIdentifier: "quoted identifier",
+	TokenString:           "string",
+	TokenDollarString:     "dollar-quoted string",
+	TokenNumber:           "number",
+	TokenSymbol:           "symbol",
+}
+
+func (k TokenKind) String() string {
+	return tokenKindNames[k]
+}
+
+// Token is a lexical token of a SQL text
+type Token struct {
+	Kind TokenKind
+	// Text is the token as it appears in the input
+	Text string
+	// Pos is the byte offset of the token in the input
+	Pos int
+}
+
+// End returns the byte offset in the input just after the token
+func (t Token) End() int {
+	return t.Pos + len(t.Text)
+}
+
+// Is reports whether t is the keyword kw, ignoring case
+func (t Token) Is(kw string) bool {
+	return t.Kind == TokenWord && strings.EqualFold(t.Text, kw)
+}
+
+// Significant reports whether t has a meaning, i.e. is neither whitespace
+// nor a comment
+func (t Token) Significant() bool {
+	return t.Kind != TokenSpace && t.Kind != TokenComment
+}
+
+// Value returns the value of a string literal or the name of a quoted
+// identifier, without quotes and escapes. For other tokens it is their text.
+func (t Token) Value() string {
+	switch t.Kind {
+	case TokenQuotedIdentifier:
+		return strings.Replace(t.Text[1:len(t.Text)-1], `""`, `"`, -1)
+	case TokenDollarString:
+		return t.Text[2 : len(t.Text)-2]
+	case TokenString:
+		return unescapeLiteral(t.Text[1 : len(t.Text)-1])
+	}
+	return t.Text
+}
+
+// Canonical returns a form of t that is the same for all tokens that mean
+// the same: keywords and unquoted identifiers are uppercased, as Snowflake
+// resolves them, quoted identifiers that only name an uppercase identifier
+// are unquoted, and string literals are requoted with the escapes of
+// EscapeString. The case of literals and other quoted identifiers is kept.
+func (t Token) Canonical() string {
+	switch t.Kind {
+	case TokenWord, TokenNumber:
+		return strings.ToUpper(t.Text)
+	case TokenQuotedIdentifier:
+		name := t.Value()
+		if unquotedIdentifier.MatchString(name) && name == strings.ToUpper(name) {
+			return name
+		}
+		return t.Text
+	case TokenString, TokenDollarString:
+		return `'` + EscapeString(t.Value()) + `'`
+	case TokenSpace:
+		return " "
+	}
+	return t.Text
+}
+
+// Tokenize splits sql into tokens, including whitespace and comments, so
+// that the concatenation of their Text is sql. It fails on unterminated
+// literals, quoted identifiers and comments.
+func Tokenize(sql string) ([]Token, error) {
+	l := &lexer{input: sql}
+	tokens := []Token{}
+	for l.pos < len(l.input) {
+		t, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, nil
+}
+
+// CanonicalTokens returns the canonical form of the significant tokens of
+// sql, for comparing statements regardless of formatting, comments and the
+// case of keywords and unquoted identifiers
+func CanonicalTokens(sql string) ([]string, error) {
+	tokens, err := Tokenize(sql)
+	if err != nil {
+		return nil, err
+	}
+	canonical := []string{}
+	for _, t := range tokens {
+		if t.Significant() {
+			canonical = append(canonical, t.Canonical())
+		}
+	}
+	return canonical, nil
+}
+
+// multiCharSymbols are the operators of more than one character, longest
+// first
+var multiCharSymbols = []string{"::", "<=", ">=", "<>", "!=", "||", "=>", "->"}
+
+type lexer struct {
+	input string
+	pos   int
+}
+
+func (l *lexer) peek(offset int) rune {
+	if l.pos+offset >= len(l.input) {
+		return 0
+	}
+	r, _ := utf8.DecodeRuneInString(l.input[l.pos+offset:])
+	return r
+}
+
+func (l *lexer) hasPrefix(s string) bool {
+	return strings.HasPrefix(l.input[l.pos:], s)
+}
+
+// emit returns the token of kind from start to the current position
+func (l *lexer) emit(kind TokenKind, start int) Token {
+	return Token{Kind: kind, Text: l.input[start:l.pos], Pos: start}
+}
+
+// advanceWhile moves past the runes that satisfy f
+func (l *lexer) advanceWhile(f func(rune) bool) {
+	for l.pos < len(l.input) {
+		r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		if !f(r) {
+			return
+		}
+		l.pos += size
+	}
+}
+
+func (l *lexer) next() (Token, error) {
+	start := l.pos
+	r := l.peek(0)
+	switch {
+	case unicode.IsSpace(r):
+		l.advanceWhile(unicode.IsSpace)
+		return l.emit(TokenSpace, start), nil
+	case l.hasPrefix("--") || l.hasPrefix("//"):
+		l.advanceWhile(func(r rune) bool { return r != '\n' })
+		return l.emit(TokenComment, start), nil
+	case l.hasPrefix("/*"):
+		end := strings.Index(l.input[l.pos+2:], "*/")
+		if end < 0 {
+			return Token{}, fmt.Errorf("unterminated comment at %d", start)
+		}
+		l.pos += 2 + end + 2
+		return l.emit(TokenComment, start), nil
+	case l.hasPrefix("$$"):
+		end := strings.Index(l.input[l.pos+2:], "$$")
+		if end < 0 {
+			return Token{}, fmt.Errorf("unterminated $$ string at %d", start)
+		}
+		l.pos += 2 + end + 2
+		return l.emit(TokenDollarString, start), nil
+	case r == '\'':
+		err := l.quoted('\'', true)
+		if err != nil {
+			return Token{}, err
+		}
+		return l.emit(TokenString, start), nil
+	case r == '"':
+		err := l.quoted('"', false)
+		if err != nil {
+			return Token{}, err
+		}
+		return l.emit(TokenQuotedIdentifier, start), nil
+	case isWordStart(r) || (r == '$' && isWordPart(l.peek(1))):
+		l.pos += utf8.RuneLen(r)
+		l.advanceWhile(isWordPart)
+		return l.emit(TokenWord, start), nil
+	case isDigit(r) || (r == '.' && isDigit(l.peek(1))):
+		l.number()
+		return l.emit(TokenNumber, start), nil
+	}
+
+	for _, s := range multiCharSymbols {
+		if l.hasPrefix(s) {
+			l.pos += len(s)
+			return l.emit(TokenSymbol, start), nil
+		}
+	}
+	l.pos += utf8.RuneLen(r)
+	if r == utf8.RuneError {
+		// An invalid byte is kept as a symbol of its own
+		l.pos = start + 1
+	}
+	return l.emit(TokenSymbol, start), nil
+}
+
+// quoted moves past a literal quoted with q, in which q is escaped by
+// doubling it or, if backslash is true, with a \
+func (l *lexer) quoted(q byte, backslash bool) error {
+	start := l.pos
+	l.pos++
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		switch {
+		case backslash && c == '\\':
+			l.pos += 2
+		case c == q && l.pos+1 < len(l.input) && l.input[l.pos+1] == q:
+			l.pos += 2
+		case c == q:
+			l.pos++
+			return nil
+		default:
+			l.pos++
+		}
+	}
+	l.pos = len(l.input)
+	return fmt.Errorf("unterminated %c at %d", q, start)
+}
+
+func (l *lexer) number() {
+	l.advanceWhile(isDigit)
+	if l.peek(0) == '.' {
+		l.pos++
+		l.advanceWhile(isDigit)
+	}
+	if e := l.peek(0); (e == 'e' || e == 'E') && (isDigit(l.peek(1)) || ((l.peek(1) == '+' || l.peek(1) == '-') && isDigit(l.peek(2)))) {
+		l.pos += 2
+		l.advanceWhile(isDigit)
+	}
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isWordStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isWordPart(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// literalEscapes are the escape sequences of string literals that do not
+// stand for the character after the \
+var literalEscapes = map[byte]string{
+	'n': "\n",
+	't': "\t",
+	'r': "\r",
+	'b': "\b",
+	'f': "\f",
+	'0': "\x00",
+}
+
+// unescapeLiteral returns the value of the body of a single quoted string
+// literal. Other escaped characters, e.g. \' or \\, stand for themselves.
+func unescapeLiteral(body string) string {
+	var b strings.Builder
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case c == '\\' && i+1 < len(body):
+			i++
+			if s, ok := literalEscapes[body[i]]; ok {
+				b.WriteString(s)
+			} else {
+				b.WriteByte(body[i])
+			}
+		case c == '\'' && i+1 < len(body) && body[i+1] == '\'':
+			i++
+			b.WriteByte('\'')
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
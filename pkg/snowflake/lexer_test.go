@@ -0,0 +1,111 @@
+package snowflake_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	r := require.New(t)
+
+	sql := `SELECT "My ""Col""", 'it\'s', 'it''s', $$a 'b'$$, $1::int, 1.5e3 -- trailing
+FROM db.s."t" /* block
+comment */ WHERE x <> 2// end`
+	tokens, err := snowflake.Tokenize(sql)
+	r.NoError(err)
+
+	text := ""
+	significant := []snowflake.Token{}
+	for _, tok := range tokens {
+		r.Equal(len(text), tok.Pos)
+		text += tok.Text
+		if tok.Significant() {
+			significant = append(significant, tok)
+		}
+	}
+	r.Equal(sql, text)
+
+	type kt struct {
+		kind snowflake.TokenKind
+		text string
+	}
+	expected := []kt{
+		{snowflake.TokenWord, "SELECT"},
+		{snowflake.TokenQuotedIdentifier, `"My ""Col"""`},
+		{snowflake.TokenSymbol, ","},
+		{snowflake.TokenString, `'it\'s'`},
+		{snowflake.TokenSymbol, ","},
+		{snowflake.TokenString, `'it''s'`},
+		{snowflake.TokenSymbol, ","},
+		{snowflake.TokenDollarString, `$$a 'b'$$`},
+		{snowflake.TokenSymbol, ","},
+		{snowflake.TokenWord, "$1"},
+		{snowflake.TokenSymbol, "::"},
+		{snowflake.TokenWord, "int"},
+		{snowflake.TokenSymbol, ","},
+		{snowflake.TokenNumber, "1.5e3"},
+		{snowflake.TokenWord, "FROM"},
+		{snowflake.TokenWord, "db"},
+		{snowflake.TokenSymbol, "."},
+		{snowflake.TokenWord, "s"},
+		{snowflake.TokenSymbol, "."},
+		{snowflake.TokenQuotedIdentifier, `"t"`},
+		{snowflake.TokenWord, "WHERE"},
+		{snowflake.TokenWord, "x"},
+		{snowflake.TokenSymbol, "<>"},
+		{snowflake.TokenNumber, "2"},
+	}
+	actual := []kt{}
+	for _, tok := range significant {
+		actual = append(actual, kt{tok.Kind, tok.Text})
+	}
+	r.Equal(expected, actual)
+
+	r.Equal(`My "Col"`, significant[1].Value())
+	r.Equal(`it's`, significant[3].Value())
+	r.Equal(`it's`, significant[5].Value())
+	r.Equal(`a 'b'`, significant[7].Value())
+	r.True(significant[0].Is("select"))
+	r.False(significant[1].Is("My"))
+}
+
+func TestTokenizeErrors(t *testing.T) {
+	for _, sql := range []string{`'abc`, `'abc\'`, `"abc`, `/* abc`, `$$ abc`} {
+		t.Run(sql, func(t *testing.T) {
+			_, err := snowflake.Tokenize(sql)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestCanonicalTokens(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     string
+		b     string
+		equal bool
+	}{
+		{"case of keywords", "select * from foo", "SELECT *\n  FROM FOO", true},
+		{"comments", "select 1 -- one", "/* one */ select 1", true},
+		{"quoted uppercase", `select "FOO" from "BAR"`, "select foo from bar", true},
+		{"escapes", `select 'it\'s'`, `select 'it''s'`, true},
+		{"dollar", `select $$x$$`, `select 'x'`, true},
+		{"quoted lowercase", `select "foo"`, "select foo", false},
+		{"case of literals", "select 'a'", "select 'A'", false},
+		{"space in literals", "select 'a  b'", "select 'a b'", false},
+		{"operators", "select a<>b", "select a < > b", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			a, err := snowflake.CanonicalTokens(tt.a)
+			r.NoError(err)
+			b, err := snowflake.CanonicalTokens(tt.b)
+			r.NoError(err)
+			r.Equal(tt.equal, strings.Join(a, " ") == strings.Join(b, " "), "%v\n%v", a, b)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package snowflake
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CurrentGrantsAction determines what happens to the outbound privileges on an
+// object when its ownership is transferred.
+type CurrentGrantsAction string
+
+const (
+	// CopyCurrentGrants keeps all existing outbound privileges on the object.
+	CopyCurrentGrants CurrentGrantsAction = "COPY"
+	// RevokeCurrentGrants removes all existing outbound privileges on the object.
+	RevokeCurrentGrants CurrentGrantsAction = "REVOKE"
+)
+
+// OwnershipBuilder abstracts the creation of SQL queries that transfer the
+// ownership of an object from one role to another.
+type OwnershipBuilder struct {
+	name          string
+	qualifiedName string
+	objectType    string
+}
+
+// Ownership returns a pointer to an OwnershipBuilder for the object of type
+// objectType. The qualified name is built from the non-empty parts of db,
+// schema and name, so account level objects only need name.
+func Ownership(objectType, db, schema, name string) *OwnershipBuilder {
+	id := Identifier{}
+	for _, p := range []string{db, schema, name} {
+		if p != "" {
+			id = append(id, p)
+		}
+	}
+	return &OwnershipBuilder{
+		name:          name,
+		qualifiedName: id.String(),
+		objectType:    strings.ToUpper(objectType),
+	}
+}
+
+// Name returns the object name for this OwnershipBuilder
+func (ob *OwnershipBuilder) Name() string {
+	return ob.name
+}
+
+// QualifiedName returns the fully qualified, quoted name of the object
+func (ob *OwnershipBuilder) QualifiedName() string {
+	return ob.qualifiedName
+}
+
+// Transfer returns the SQL that will transfer ownership of the object to role,
+// either copying or revoking the existing outbound privileges.
+func (ob *OwnershipBuilder) Transfer(role string, action CurrentGrantsAction) string {
+	return fmt.Sprintf(`GRANT OWNERSHIP ON %v %v TO ROLE %v %v CURRENT GRANTS`,
+		ob.objectType, ob.qualifiedName, Identifier{role}, action)
+}
+
+// Show returns the SQL that will show all privileges on the object
+func (ob *OwnershipBuilder) Show() string {
+	return fmt.Sprintf(`SHOW GRANTS ON %v %v`, ob.objectType, ob.qualifiedName)
+}
@@ -0,0 +1,24 @@
+package snowflake_test
+
+import (
+	"testing"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOwnership(t *testing.T) {
+	r := require.New(t)
+
+	ob := snowflake.Ownership("database", "", "", "test_db")
+	r.Equal("test_db", ob.Name())
+	r.Equal(`SHOW GRANTS ON DATABASE "test_db"`, ob.Show())
+	r.Equal(`GRANT OWNERSHIP ON DATABASE "test_db" TO ROLE "bob" COPY CURRENT GRANTS`, ob.Transfer("bob", snowflake.CopyCurrentGrants))
+
+	ob = snowflake.Ownership("SCHEMA", "test_db", "", "test_schema")
+	r.Equal(`GRANT OWNERSHIP ON SCHEMA "test_db"."test_schema" TO ROLE "bob" REVOKE CURRENT GRANTS`, ob.Transfer("bob", snowflake.RevokeCurrentGrants))
+
+	ob = snowflake.Ownership("FILE FORMAT", "test_db", "test_schema", "test_format")
+	r.Equal(`SHOW GRANTS ON FILE FORMAT "test_db"."test_schema"."test_format"`, ob.Show())
+	r.Equal(`GRANT OWNERSHIP ON FILE FORMAT "test_db"."test_schema"."test_format" TO ROLE "bob" COPY CURRENT GRANTS`, ob.Transfer("bob", snowflake.CopyCurrentGrants))
+}
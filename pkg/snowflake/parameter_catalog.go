@@ -0,0 +1,161 @@
+package snowflake
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParameterType is the type of the value of a parameter
+type ParameterType string
+
+const (
+	// ParameterTypeBoolean is TRUE or FALSE
+	ParameterTypeBoolean ParameterType = "BOOLEAN"
+	// ParameterTypeNumber is an integer
+	ParameterTypeNumber ParameterType = "NUMBER"
+	// ParameterTypeString is written as a string literal
+	ParameterTypeString ParameterType = "STRING"
+	// ParameterTypeIdentifier is the name of an object, e.g. a network policy
+	ParameterTypeIdentifier ParameterType = "IDENTIFIER"
+)
+
+type parameterDefinition struct {
+	typ ParameterType
+	// levels are the types of objects the parameter can be set on
+	levels []EntityType
+}
+
+var (
+	accountLevel   = []EntityType{AccountType}
+	sessionLevels  = []EntityType{AccountType, UserType, TaskType}
+	warehouseLevel = []EntityType{AccountType, WarehouseType}
+	storageLevels  = []EntityType{AccountType, DatabaseType, SchemaType}
+)
+
+// parameterCatalog is the catalog of the parameters the provider can manage,
+// by key. See https://docs.snowflake.com/en/sql-reference/parameters.html
+var parameterCatalog = map[string]parameterDefinition{
+	// Account parameters
+	"ALLOW_ID_TOKEN":                                  {ParameterTypeBoolean, accountLevel},
+	"CLIENT_ENCRYPTION_KEY_SIZE":                      {ParameterTypeNumber, accountLevel},
+	"MIN_DATA_RETENTION_TIME_IN_DAYS":                 {ParameterTypeNumber, accountLevel},
+	"NETWORK_POLICY":                                  {ParameterTypeIdentifier, []EntityType{AccountType, UserType}},
+	"PERIODIC_DATA_REKEYING":                          {ParameterTypeBoolean, accountLevel},
+	"PREVENT_UNLOAD_TO_INLINE_URL":                    {ParameterTypeBoolean, accountLevel},
+	"REQUIRE_STORAGE_INTEGRATION_FOR_STAGE_CREATION":  {ParameterTypeBoolean, accountLevel},
+	"REQUIRE_STORAGE_INTEGRATION_FOR_STAGE_OPERATION": {ParameterTypeBoolean, accountLevel},
+	"SSO_LOGIN_PAGE":                                  {ParameterTypeBoolean, accountLevel},
+
+	// Object parameters
+	"DATA_RETENTION_TIME_IN_DAYS":         {ParameterTypeNumber, storageLevels},
+	"DEFAULT_DDL_COLLATION":               {ParameterTypeString, storageLevels},
+	"MAX_CONCURRENCY_LEVEL":               {ParameterTypeNumber, warehouseLevel},
+	"MAX_DATA_EXTENSION_TIME_IN_DAYS":     {ParameterTypeNumber, storageLevels},
+	"PIPE_EXECUTION_PAUSED":               {ParameterTypeBoolean, []EntityType{AccountType, SchemaType}},
+	"STATEMENT_QUEUED_TIMEOUT_IN_SECONDS": {ParameterTypeNumber, []EntityType{AccountType, UserType, TaskType, WarehouseType}},
+	"STATEMENT_TIMEOUT_IN_SECONDS":        {ParameterTypeNumber, []EntityType{AccountType, UserType, TaskType, WarehouseType}},
+
+	// Session parameters
+	"ABORT_DETACHED_QUERY":                {ParameterTypeBoolean, sessionLevels},
+	"AUTOCOMMIT":                          {ParameterTypeBoolean, sessionLevels},
+	"BINARY_INPUT_FORMAT":                 {ParameterTypeString, sessionLevels},
+	"BINARY_OUTPUT_FORMAT":                {ParameterTypeString, sessionLevels},
+	"CLIENT_SESSION_KEEP_ALIVE":           {ParameterTypeBoolean, sessionLevels},
+	"DATE_INPUT_FORMAT":                   {ParameterTypeString, sessionLevels},
+	"DATE_OUTPUT_FORMAT":                  {ParameterTypeString, sessionLevels},
+	"ERROR_ON_NONDETERMINISTIC_MERGE":     {ParameterTypeBoolean, sessionLevels},
+	"ERROR_ON_NONDETERMINISTIC_UPDATE":    {ParameterTypeBoolean, sessionLevels},
+	"JSON_INDENT":                         {ParameterTypeNumber, sessionLevels},
+	"LOCK_TIMEOUT":                        {ParameterTypeNumber, sessionLevels},
+	"QUERY_TAG":                           {ParameterTypeString, sessionLevels},
+	"ROWS_PER_RESULTSET":                  {ParameterTypeNumber, sessionLevels},
+	"STRICT_JSON_OUTPUT":                  {ParameterTypeBoolean, sessionLevels},
+	"TIMESTAMP_DAY_IS_ALWAYS_24H":         {ParameterTypeBoolean, sessionLevels},
+	"TIMESTAMP_INPUT_FORMAT":              {ParameterTypeString, sessionLevels},
+	"TIMESTAMP_LTZ_OUTPUT_FORMAT":         {ParameterTypeString, sessionLevels},
+	"TIMESTAMP_NTZ_OUTPUT_FORMAT":         {ParameterTypeString, sessionLevels},
+	"TIMESTAMP_OUTPUT_FORMAT":             {ParameterTypeString, sessionLevels},
+	"TIMESTAMP_TYPE_MAPPING":              {ParameterTypeString, sessionLevels},
+	"TIMESTAMP_TZ_OUTPUT_FORMAT":          {ParameterTypeString, sessionLevels},
+	"TIMEZONE":                            {ParameterTypeString, sessionLevels},
+	"TIME_INPUT_FORMAT":                   {ParameterTypeString, sessionLevels},
+	"TIME_OUTPUT_FORMAT":                  {ParameterTypeString, sessionLevels},
+	"TRANSACTION_DEFAULT_ISOLATION_LEVEL": {ParameterTypeString, sessionLevels},
+	"TWO_DIGIT_CENTURY_START":             {ParameterTypeNumber, sessionLevels},
+	"UNSUPPORTED_DDL_ACTION":              {ParameterTypeString, sessionLevels},
+	"USE_CACHED_RESULT":                   {ParameterTypeBoolean, sessionLevels},
+	"WEEK_OF_YEAR_POLICY":                 {ParameterTypeNumber, sessionLevels},
+	"WEEK_START":                          {ParameterTypeNumber, sessionLevels},
+}
+
+// ParameterKeys returns the sorted keys of the parameters in the catalog that
+// can be set on objects of type t
+func ParameterKeys(t EntityType) []string {
+	keys := []string{}
+	for k, def := range parameterCatalog {
+		for _, l := range def.levels {
+			if l == t {
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// LookupParameter returns the type of the parameter key, which must be in the
+// catalog and settable on objects of type t
+func LookupParameter(key string, t EntityType) (ParameterType, error) {
+	def, ok := parameterCatalog[strings.ToUpper(key)]
+	if !ok {
+		return "", fmt.Errorf("unknown parameter %v", key)
+	}
+	for _, l := range def.levels {
+		if l == t {
+			return def.typ, nil
+		}
+	}
+	return "", fmt.Errorf("parameter %v cannot be set on %v", key, strings.ToLower(string(t)))
+}
+
+// FormatParameterValue returns value, a parameter value of type pt as it is
+// written in a configuration, as SQL. It fails on values that are not of the
+// type, e.g. yes for a BOOLEAN.
+func FormatParameterValue(pt ParameterType, value string) (string, error) {
+	switch pt {
+	case ParameterTypeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("expected true or false, got %v", value)
+		}
+		return strings.ToUpper(strconv.FormatBool(b)), nil
+	case ParameterTypeNumber:
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("expected an integer, got %v", value)
+		}
+		return strconv.FormatInt(i, 10), nil
+	case ParameterTypeIdentifier:
+		return Identifier{value}.String(), nil
+	}
+	return fmt.Sprintf(`'%v'`, EscapeString(value)), nil
+}
+
+// EqualParameterValues reports whether a and b are the same value of type pt,
+// e.g. true and TRUE, or 060 and 60, or the names of the same object under
+// policy
+func EqualParameterValues(policy IdentifierPolicy, pt ParameterType, a, b string) bool {
+	switch pt {
+	case ParameterTypeBoolean, ParameterTypeNumber:
+		fa, errA := FormatParameterValue(pt, a)
+		fb, errB := FormatParameterValue(pt, b)
+		if errA == nil && errB == nil {
+			return fa == fb
+		}
+	case ParameterTypeIdentifier:
+		return policy.Equal(a, b)
+	}
+	return a == b
+}
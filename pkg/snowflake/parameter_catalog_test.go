@@ -0,0 +1,65 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupParameter(t *testing.T) {
+	r := require.New(t)
+
+	pt, err := LookupParameter("timezone", AccountType)
+	r.NoError(err)
+	r.Equal(ParameterTypeString, pt)
+
+	pt, err = LookupParameter("NETWORK_POLICY", UserType)
+	r.NoError(err)
+	r.Equal(ParameterTypeIdentifier, pt)
+
+	_, err = LookupParameter("PERIODIC_DATA_REKEYING", UserType)
+	r.EqualError(err, "parameter PERIODIC_DATA_REKEYING cannot be set on user")
+
+	_, err = LookupParameter("NOT_A_PARAMETER", AccountType)
+	r.EqualError(err, "unknown parameter NOT_A_PARAMETER")
+
+	r.Contains(ParameterKeys(AccountType), "STATEMENT_TIMEOUT_IN_SECONDS")
+	r.NotContains(ParameterKeys(UserType), "MIN_DATA_RETENTION_TIME_IN_DAYS")
+}
+
+func TestFormatParameterValue(t *testing.T) {
+	cases := []struct {
+		typ      ParameterType
+		in       string
+		expected string
+	}{
+		{ParameterTypeBoolean, "true", "TRUE"},
+		{ParameterTypeBoolean, "False", "FALSE"},
+		{ParameterTypeNumber, "3600", "3600"},
+		{ParameterTypeNumber, "-1", "-1"},
+		{ParameterTypeString, "America/Los_Angeles", "'America/Los_Angeles'"},
+		{ParameterTypeString, "it's", `'it\'s'`},
+		{ParameterTypeIdentifier, "my_policy", `"my_policy"`},
+	}
+	for _, tc := range cases {
+		v, err := FormatParameterValue(tc.typ, tc.in)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, v)
+	}
+
+	_, err := FormatParameterValue(ParameterTypeBoolean, "yes")
+	require.Error(t, err)
+	_, err = FormatParameterValue(ParameterTypeNumber, "1.5")
+	require.Error(t, err)
+}
+
+func TestEqualParameterValues(t *testing.T) {
+	r := require.New(t)
+
+	r.True(EqualParameterValues(IdentifierPolicyQuoted, ParameterTypeBoolean, "true", "TRUE"))
+	r.True(EqualParameterValues(IdentifierPolicyQuoted, ParameterTypeNumber, "060", "60"))
+	r.False(EqualParameterValues(IdentifierPolicyQuoted, ParameterTypeNumber, "60", "61"))
+	r.False(EqualParameterValues(IdentifierPolicyQuoted, ParameterTypeString, "UTC", "utc"))
+	r.False(EqualParameterValues(IdentifierPolicyQuoted, ParameterTypeIdentifier, "POLICY", "policy"))
+	r.True(EqualParameterValues(IdentifierPolicyUppercase, ParameterTypeIdentifier, "POLICY", "policy"))
+}
@@ -0,0 +1,77 @@
+package snowflake
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Parameter is a row of the output of SHOW PARAMETERS
+type Parameter struct {
+	Key          string `db:"key"`
+	Value        string `db:"value"`
+	DefaultValue string `db:"default"`
+	// Level is where the value comes from: empty for the default, else the
+	// type of the object it is set on, e.g. ACCOUNT or WAREHOUSE
+	Level       string `db:"level"`
+	Description string `db:"description"`
+	Type        string `db:"type"`
+}
+
+// IsSetOn reports whether p is set on an object of type t itself, rather than
+// inherited from the account, a containing object or the default
+func (p *Parameter) IsSetOn(t EntityType) bool {
+	return strings.EqualFold(p.Level, string(t))
+}
+
+// ShowParameters returns the query that shows the parameters of the object
+// of type t named id, e.g. ShowParameters(SchemaType, Identifier{"db", "s"})
+func ShowParameters(t EntityType, id Identifier) string {
+	return fmt.Sprintf(`SHOW PARAMETERS IN %s %v`, t, id)
+}
+
+// ShowAccountParameters returns the query that shows the parameters of the
+// account
+func ShowAccountParameters() string {
+	return `SHOW PARAMETERS IN ACCOUNT`
+}
+
+// ShowParameters returns the query that shows the parameters of the object
+func (b *Builder) ShowParameters() string {
+	return ShowParameters(b.entityType, Identifier{b.name})
+}
+
+// Type returns the type of the object
+func (b *Builder) Type() EntityType {
+	return b.entityType
+}
+
+// ScanParameters scans the rows of SHOW PARAMETERS and closes them
+func ScanParameters(rows *sqlx.Rows) ([]*Parameter, error) {
+	defer rows.Close()
+
+	params := []*Parameter{}
+	for rows.Next() {
+		p := &Parameter{}
+		err := rows.StructScan(p)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, p)
+	}
+	return params, rows.Err()
+}
+
+// ParametersSetOn returns the values of the parameters that are set on an
+// object of type t itself, by key. Inherited values are left out, so that a
+// resource only reads back the parameters it manages.
+func ParametersSetOn(params []*Parameter, t EntityType) map[string]string {
+	values := map[string]string{}
+	for _, p := range params {
+		if p.IsSetOn(t) {
+			values[p.Key] = p.Value
+		}
+	}
+	return values
+}
@@ -0,0 +1,39 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShowObjectParameters(t *testing.T) {
+	r := require.New(t)
+
+	r.Equal(`SHOW PARAMETERS IN WAREHOUSE "w"`, Warehouse("w").ShowParameters())
+	r.Equal(`SHOW PARAMETERS IN USER "u"`, User("u").ShowParameters())
+	r.Equal(`SHOW PARAMETERS IN SCHEMA "db"."s"`, ShowParameters(SchemaType, Identifier{"db", "s"}))
+	r.Equal(`SHOW PARAMETERS IN SCHEMA "db"."s"`, Schema("s").WithDB("db").ShowParameters())
+	r.Equal(`SHOW PARAMETERS IN ACCOUNT`, ShowAccountParameters())
+	r.Equal(WarehouseType, Warehouse("w").Type())
+}
+
+func TestParametersSetOn(t *testing.T) {
+	r := require.New(t)
+
+	params := []*Parameter{
+		{Key: "STATEMENT_TIMEOUT_IN_SECONDS", Value: "60", DefaultValue: "172800", Level: "WAREHOUSE"},
+		{Key: "MAX_CONCURRENCY_LEVEL", Value: "4", DefaultValue: "8", Level: "ACCOUNT"},
+		{Key: "STATEMENT_QUEUED_TIMEOUT_IN_SECONDS", Value: "0", DefaultValue: "0", Level: ""},
+		// A value set on the object is managed even when it is the default
+		{Key: "TIMEZONE", Value: "America/Los_Angeles", DefaultValue: "America/Los_Angeles", Level: "warehouse"},
+	}
+
+	r.True(params[0].IsSetOn(WarehouseType))
+	r.False(params[1].IsSetOn(WarehouseType))
+	r.True(params[1].IsSetOn(AccountType))
+	r.Equal(map[string]string{
+		"STATEMENT_TIMEOUT_IN_SECONDS": "60",
+		"TIMEZONE":                     "America/Los_Angeles",
+	}, ParametersSetOn(params, WarehouseType))
+	r.Empty(ParametersSetOn(params, UserType))
+}
@@ -3,137 +3,89 @@ package snowflake
 import (
 	"fmt"
 	"strings"
-	"unicode"
 )
 
-// ViewSelectStatementExtractor is a simplistic parser that only exists to extract the select
-// statement from a create view statement
-//
-// The implementation is optimized for undertandable and predictable behavior. So far we only seek
-// to support queries of the sort that are generated by this project.
-//
-// Also there is little error handling and we assume queries are well-formed.
-type ViewSelectStatementExtractor struct {
-	input []rune
-	pos   int
-}
+// bodyKinds are the kinds of objects whose CREATE statements ExtractBody
+// supports
+var bodyKinds = []string{"VIEW", "TASK", "PIPE", "FUNCTION"}
 
-func NewViewSelectStatementExtractor(input string) *ViewSelectStatementExtractor {
-	return &ViewSelectStatementExtractor{
-		input: []rune(input),
+// ExtractBody returns the body of a CREATE VIEW, MATERIALIZED VIEW, TASK,
+// PIPE or FUNCTION statement, i.e. what follows its AS: the query of a view,
+// the statement of a task or pipe as written, or the definition of a
+// function, without the quotes around it.
+//
+// Everything before the AS, such as column lists, COPY GRANTS, comments and
+// quoted names, is skipped, whatever it contains.
+func ExtractBody(ddl string) (string, error) {
+	tokens, err := Tokenize(ddl)
+	if err != nil {
+		return "", err
 	}
-}
-
-func (e *ViewSelectStatementExtractor) Extract() (string, error) {
-	fmt.Printf("[DEBUG] extracting view query %s\n", string(e.input))
-	e.consumeSpace()
-	e.consumeToken("create")
-	e.consumeSpace()
-	e.consumeToken("or replace")
-	e.consumeSpace()
-	e.consumeToken("secure")
-	e.consumeSpace()
-	e.consumeToken("recursive")
-	e.consumeSpace()
-	e.consumeToken("view")
-	e.consumeSpace()
-	e.consumeToken("if not exists")
-	e.consumeSpace()
-	e.consumeIdentifier()
-	// TODO column list
-	// TODO copy grants
-	e.consumeComment()
-	e.consumeSpace()
-	e.consumeComment()
-	e.consumeSpace()
-	e.consumeToken("as")
-	e.consumeSpace()
-
-	return string(e.input[e.pos:]), nil
-}
-
-// consumeToken will move e.pos forward iff the token is the next part of the input. Comparison is
-// case-insensitive. Will return true if consumed.
-func (e *ViewSelectStatementExtractor) consumeToken(t string) bool {
-	found := 0
-	for i, r := range t {
-		// it is annoying that we have to convert the runes back to strings to do a case-insensitive
-		// comparison. Hopefully I am just missing something in the docs.
-		if e.pos+i > len(e.input) || !strings.EqualFold(string(r), string(e.input[e.pos+i])) {
-			break
+	significant := []Token{}
+	for _, t := range tokens {
+		if t.Significant() {
+			significant = append(significant, t)
 		}
-		found += 1
 	}
 
-	if found == len(t) {
-		e.pos += len(t)
-		return true
+	if len(significant) == 0 || !significant[0].Is("CREATE") {
+		return "", fmt.Errorf("expected a CREATE statement, got %v", ddl)
 	}
-	return false
-}
 
-func (e *ViewSelectStatementExtractor) consumeSpace() {
-	found := 0
-	for {
-		if e.pos+found > len(e.input)-1 || !unicode.IsSpace(e.input[e.pos+found]) {
-			break
+	kind := ""
+	i := 1
+	for ; i < len(significant) && kind == ""; i++ {
+		t := significant[i]
+		for _, k := range bodyKinds {
+			if t.Is(k) {
+				kind = k
+			}
 		}
-		found += 1
-	}
-	e.pos += found
-}
-
-func (e *ViewSelectStatementExtractor) consumeIdentifier() {
-	e.consumeNonSpace()
-}
-
-func (e *ViewSelectStatementExtractor) consumeNonSpace() {
-	found := 0
-	for {
-		if e.pos+found > len(e.input)-1 || unicode.IsSpace(e.input[e.pos+found]) {
+		if kind == "" && !isCreateModifier(t) {
 			break
 		}
-		found += 1
-	}
-	e.pos += found
-}
-
-func (e *ViewSelectStatementExtractor) consumeComment() {
-	if c := e.consumeToken("comment"); !c {
-		return
 	}
-
-	e.consumeSpace()
-
-	if c := e.consumeToken("="); !c {
-		return
+	if kind == "" {
+		return "", fmt.Errorf("expected a CREATE %v statement, got %v", strings.Join(bodyKinds, ", "), ddl)
 	}
 
-	e.consumeSpace()
-
-	if c := e.consumeToken("'"); !c {
-		return
+	// The body starts after the first AS outside of parentheses, so that an
+	// AS in a clustering key, e.g. CLUSTER BY (CAST(x AS INT)), is not taken
+	// for it.
+	depth := 0
+	for ; i < len(significant); i++ {
+		t := significant[i]
+		switch {
+		case t.Text == "(":
+			depth++
+		case t.Text == ")":
+			depth--
+		case depth == 0 && t.Is("AS"):
+			if kind == "FUNCTION" {
+				return functionBody(significant[i+1:], ddl)
+			}
+			return strings.TrimLeft(ddl[t.End():], " \t\r\n"), nil
+		}
 	}
+	return "", fmt.Errorf("expected AS in CREATE %v statement %v", kind, ddl)
+}
 
-	found := 0
-	escaped := false
-	for {
-		if e.pos+found > len(e.input)-1 {
-			break
-		}
+// createModifiers are the words that may come between CREATE and the kind of
+// object
+var createModifiers = []string{"OR", "REPLACE", "SECURE", "RECURSIVE", "MATERIALIZED", "TEMPORARY", "TEMP", "VOLATILE", "LOCAL", "GLOBAL", "TRANSIENT"}
 
-		if escaped {
-			escaped = false
-		} else if e.input[e.pos+found] == '\\' {
-			escaped = true
-		} else if e.input[e.pos+found] == '\'' {
-			break
+func isCreateModifier(t Token) bool {
+	for _, m := range createModifiers {
+		if t.Is(m) {
+			return true
 		}
-		found += 1
 	}
-	e.pos += found
+	return false
+}
 
-	if c := e.consumeToken("'"); !c {
-		return
+func functionBody(tokens []Token, ddl string) (string, error) {
+	if len(tokens) == 0 || (tokens[0].Kind != TokenString && tokens[0].Kind != TokenDollarString) {
+		return "", fmt.Errorf("expected a string after AS in CREATE FUNCTION statement %v", ddl)
 	}
+	return tokens[0].Value(), nil
 }
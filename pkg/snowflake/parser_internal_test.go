@@ -1,3 +0,0 @@
-package snowflake
-
-// Internal tests for ViewSelectStatementExtractor
@@ -1,11 +1,12 @@
 package snowflake
 
 import (
-	"fmt"
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
-func TestViewSelectStatementExtractor_Extract(t *testing.T) {
+func TestExtractBody(t *testing.T) {
 	basic := "create view foo as select * from bar;"
 	caps := "CREATE VIEW FOO AS SELECT * FROM BAR;"
 	parens := "create view foo as (select * from bar);"
@@ -27,135 +28,74 @@ from bar;`
 
 	comment := `create view foo comment='asdf' as select * from bar;`
 	commentEscape := `create view foo comment='asdf\'s are fun' as select * from bar;`
+	commentDoubledQuote := `create view foo comment='asdf''s as fun' as select * from bar;`
 	identifier := `create view "foo"."bar"."bam" comment='asdf\'s are fun' as select * from bar;`
+	identifierSpaces := `create view "my db"."my schema"."as view" as select * from bar;`
+	columns := `create view foo (id comment 'the id', "Name") copy grants as select id, name from bar;`
+	materialized := `create materialized view foo cluster by (cast(id as int)) as select * from bar;`
+	blockComment := `create /* as */ view foo // as
+as select 'as' from bar;`
 
 	full := `CREATE SECURE VIEW "rgdxfmnfhh"."PUBLIC"."rgdxfmnfhh" COMMENT = 'Terraform test resource' AS SELECT ROLE_NAME, ROLE_OWNER FROM INFORMATION_SCHEMA.APPLICABLE_ROLES`
 
-	type args struct {
-		input string
-	}
-	tests := []struct {
-		name    string
-		args    args
-		want    string
-		wantErr bool
-	}{
-		{"basic", args{basic}, "select * from bar;", false},
-		{"caps", args{caps}, "SELECT * FROM BAR;", false},
-		{"parens", args{parens}, "(select * from bar);", false},
-		{"multiline", args{multiline}, "select *\nfrom bar;", false},
-		{"multilineComment", args{multilineComment}, "-- comment\nselect *\nfrom bar;", false},
-		{"secure", args{secure}, "select * from bar;", false},
-		{"replace", args{replace}, "select * from bar;", false},
-		{"recursive", args{recursive}, "select * from bar;", false},
-		{"ine", args{ine}, "select * from bar;", false},
-		{"comment", args{comment}, "select * from bar;", false},
-		{"commentEscape", args{commentEscape}, "select * from bar;", false},
-		{"identifier", args{identifier}, "select * from bar;", false},
-		{"full", args{full}, "SELECT ROLE_NAME, ROLE_OWNER FROM INFORMATION_SCHEMA.APPLICABLE_ROLES", false},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			e := NewViewSelectStatementExtractor(tt.args.input)
-			got, err := e.Extract()
-			if (err != nil) != tt.wantErr {
-				t.Errorf("ViewSelectStatementExtractor.Extract() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if got != tt.want {
-				t.Errorf("ViewSelectStatementExtractor.Extract() = '%v', want '%v'", got, tt.want)
-			}
-		})
-	}
-}
+	task := `CREATE TASK "db"."schema"."task" WAREHOUSE = "wh" SCHEDULE = '5 MINUTE' WHEN SYSTEM$STREAM_HAS_DATA('s') AS INSERT INTO t SELECT 1`
+	pipe := `create pipe p auto_ingest = true comment = 'as' as copy into t from @s file_format = (type = 'CSV')`
+	function := `create function f(a number) returns table (b number) as 'select a as b'`
+	functionDollar := `create or replace function f() returns string language javascript as $$ return 'it''s'; $$`
 
-func TestViewSelectStatementExtractor_consumeToken(t *testing.T) {
-	type fields struct {
-		input []rune
-		pos   int
-	}
-	type args struct {
-		t string
-	}
 	tests := []struct {
-		name     string
-		fields   fields
-		args     args
-		posAfter int
-	}{
-		{"basic - found", fields{[]rune("foo"), 0}, args{"foo"}, 3},
-		{"basic - not found", fields{[]rune("foo"), 0}, args{"bar"}, 0},
-		{"basic - not found", fields{[]rune("fob"), 0}, args{"foo"}, 0},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			e := &ViewSelectStatementExtractor{
-				input: tt.fields.input,
-				pos:   tt.fields.pos,
-			}
-			e.consumeToken(tt.args.t)
-
-			if e.pos != tt.posAfter {
-				t.Errorf("pos after = %v, want %v", e.pos, tt.posAfter)
-			}
-		})
-	}
-}
-
-func TestViewSelectStatementExtractor_consumeSpace(t *testing.T) {
-	type fields struct {
-		input []rune
-		pos   int
-	}
-	tests := []struct {
-		name     string
-		fields   fields
-		posAfter int
+		name  string
+		input string
+		want  string
 	}{
-		{"simple", fields{[]rune("   foo"), 0}, 3},
-		{"empty", fields{[]rune(""), 0}, 0},
-		{"middle", fields{[]rune("foo \t\n bar"), 3}, 7},
+		{"basic", basic, "select * from bar;"},
+		{"caps", caps, "SELECT * FROM BAR;"},
+		{"parens", parens, "(select * from bar);"},
+		{"multiline", multiline, "select *\nfrom bar;"},
+		{"multilineComment", multilineComment, "-- comment\nselect *\nfrom bar;"},
+		{"secure", secure, "select * from bar;"},
+		{"replace", replace, "select * from bar;"},
+		{"recursive", recursive, "select * from bar;"},
+		{"ine", ine, "select * from bar;"},
+		{"comment", comment, "select * from bar;"},
+		{"commentEscape", commentEscape, "select * from bar;"},
+		{"commentDoubledQuote", commentDoubledQuote, "select * from bar;"},
+		{"identifier", identifier, "select * from bar;"},
+		{"identifierSpaces", identifierSpaces, "select * from bar;"},
+		{"columns", columns, "select id, name from bar;"},
+		{"materialized", materialized, "select * from bar;"},
+		{"blockComment", blockComment, "select 'as' from bar;"},
+		{"full", full, "SELECT ROLE_NAME, ROLE_OWNER FROM INFORMATION_SCHEMA.APPLICABLE_ROLES"},
+		{"task", task, "INSERT INTO t SELECT 1"},
+		{"pipe", pipe, "copy into t from @s file_format = (type = 'CSV')"},
+		{"function", function, "select a as b"},
+		{"functionDollar", functionDollar, " return 'it''s'; "},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fmt.Println(tt.name)
-			e := &ViewSelectStatementExtractor{
-				input: tt.fields.input,
-				pos:   tt.fields.pos,
-			}
-			e.consumeSpace()
-
-			if e.pos != tt.posAfter {
-				t.Errorf("pos after = %v, want %v", e.pos, tt.posAfter)
-			}
+			got, err := ExtractBody(tt.input)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
 		})
 	}
 }
 
-func TestViewSelectStatementExtractor_consumeComment(t *testing.T) {
-	type fields struct {
-		input []rune
-		pos   int
-	}
+func TestExtractBodyErrors(t *testing.T) {
 	tests := []struct {
-		name     string
-		fields   fields
-		posAfter int
+		name  string
+		input string
 	}{
-		{"basic", fields{[]rune("comment='foo'"), 0}, 13},
-		{"escaped", fields{[]rune(`comment='fo\'o'`), 0}, 15},
+		{"empty", ""},
+		{"notCreate", "select 1"},
+		{"table", "create table task as select 1"},
+		{"noAs", "create view foo"},
+		{"unterminated", "create view foo comment = 'as select 1"},
+		{"functionWithoutString", "create function f() returns number as 1"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			e := &ViewSelectStatementExtractor{
-				input: tt.fields.input,
-				pos:   tt.fields.pos,
-			}
-			e.consumeComment()
-
-			if e.pos != tt.posAfter {
-				t.Errorf("pos after = %v, want %v", e.pos, tt.posAfter)
-			}
+			_, err := ExtractBody(tt.input)
+			require.Error(t, err)
 		})
 	}
 }
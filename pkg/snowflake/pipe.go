@@ -1,6 +1,7 @@
 package snowflake
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -20,23 +21,17 @@ type PipeBuilder struct {
 
 // QualifiedName prepends the db and schema if set and escapes everything nicely
 func (pb *PipeBuilder) QualifiedName() string {
-	var n strings.Builder
-
-	if pb.db != "" && pb.schema != "" {
-		n.WriteString(fmt.Sprintf(`"%v"."%v".`, pb.db, pb.schema))
-	}
-
-	if pb.db != "" && pb.schema == "" {
-		n.WriteString(fmt.Sprintf(`"%v"..`, pb.db))
+	switch {
+	case pb.db != "" && pb.schema != "":
+		return Identifier{pb.db, pb.schema, pb.name}.String()
+	case pb.db != "":
+		// db..name is the object in the PUBLIC schema of db
+		return Identifier{pb.db, "", pb.name}.String()
+	case pb.schema != "":
+		return Identifier{pb.schema, pb.name}.String()
+	default:
+		return Identifier{pb.name}.String()
 	}
-
-	if pb.db == "" && pb.schema != "" {
-		n.WriteString(fmt.Sprintf(`"%v".`, pb.schema))
-	}
-
-	n.WriteString(fmt.Sprintf(`"%v"`, pb.name))
-
-	return n.String()
 }
 
 // WithAutoIngest adds the auto_ingest flag to the PipeBuilder
@@ -122,7 +117,7 @@ func (pb *PipeBuilder) Drop() string {
 
 // Show returns the SQL query that will show a pipe.
 func (pb *PipeBuilder) Show() string {
-	return fmt.Sprintf(`SHOW PIPES LIKE '%v' IN DATABASE "%v"`, pb.name, pb.db)
+	return fmt.Sprintf(`SHOW PIPES LIKE %v IN DATABASE %v`, LikePattern(pb.name), Identifier{pb.db})
 }
 
 type pipe struct {
@@ -141,3 +136,25 @@ func ScanPipe(row *sqlx.Row) (*pipe, error) {
 	e := row.StructScan(p)
 	return p, e
 }
+
+// ShowPipesInSchema returns the SQL query that will show all pipes in the
+// schema of the database db
+func ShowPipesInSchema(db, schema string) string {
+	return fmt.Sprintf(`SHOW PIPES IN SCHEMA %v`, Identifier{db, schema})
+}
+
+// ReadPipe returns the pipe name in schema of database db. All pipes of the
+// schema are read with a single query that is cached for the Reads of the
+// other pipes. It returns sql.ErrNoRows if the pipe does not exist.
+func ReadPipe(ctx context.Context, c *Client, db, schema, name string) (*pipe, error) {
+	p, err := c.showFind(ctx,
+		ShowPipesInSchema(db, schema),
+		fmt.Sprintf(`SHOW PIPES LIKE %v IN SCHEMA %v`, LikePattern(name), Identifier{db, schema}),
+		func() interface{} { return &pipe{} },
+		func(p interface{}) bool { return p.(*pipe).Name == name },
+	)
+	if err != nil {
+		return nil, err
+	}
+	return p.(*pipe), nil
+}
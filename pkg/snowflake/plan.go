@@ -0,0 +1,87 @@
+package snowflake
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Plan is a series of statements that is applied as a unit. Each step may
+// record a compensating statement; when a step fails, the compensating
+// statements of the steps already applied are run in reverse order, so a
+// failure midway does not leave half-made changes, such as temporary
+// databases, behind.
+type Plan struct {
+	steps []planStep
+}
+
+type planStep struct {
+	description string
+	stmt        string
+	undo        string
+}
+
+// NewPlan returns an empty Plan
+func NewPlan() *Plan {
+	return &Plan{}
+}
+
+// Add appends a step that runs stmt. undo, if not empty, reverses stmt if a
+// later step fails. description is used in errors, e.g. "creating database
+// foo".
+func (p *Plan) Add(description, stmt, undo string) *Plan {
+	p.steps = append(p.steps, planStep{description: description, stmt: stmt, undo: undo})
+	return p
+}
+
+// Len returns the number of steps of the plan
+func (p *Plan) Len() int {
+	return len(p.steps)
+}
+
+// Apply runs the steps of the plan with c in order. If a step fails its
+// error is returned, after undoing the steps before it. Statements that could
+// not be undone are logged and named in the error, as they may need to be
+// cleaned up by hand.
+func (p *Plan) Apply(ctx context.Context, c *Client) error {
+	for i, s := range p.steps {
+		err := c.ExecContext(ctx, s.stmt)
+		if err == nil {
+			continue
+		}
+
+		err = errors.Wrapf(err, "error %v", s.description)
+		failed := p.undo(c, i)
+		if len(failed) > 0 {
+			return errors.WithMessagef(err, "could not undo %v", strings.Join(failed, "; "))
+		}
+		return err
+	}
+	return nil
+}
+
+// undo runs the compensating statements of the steps before step n, returning
+// those that failed
+func (p *Plan) undo(c *Client, n int) []string {
+	// The plan's context may be what made the step fail, so undoing must not
+	// depend on it.
+	ctx := context.Background()
+
+	failed := []string{}
+	for i := n - 1; i >= 0; i-- {
+		s := p.steps[i]
+		if s.undo == "" {
+			continue
+		}
+
+		err := c.ExecContext(ctx, s.undo)
+		if err != nil {
+			log.Printf("[WARN] could not undo %v: %v", s.description, err)
+			failed = append(failed, fmt.Sprintf("%v (%v)", s.undo, err))
+		}
+	}
+	return failed
+}
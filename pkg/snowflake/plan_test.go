@@ -0,0 +1,71 @@
+package snowflake_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanApply(t *testing.T) {
+	r := require.New(t)
+	db, mock, err := sqlmock.New()
+	r.NoError(err)
+	defer db.Close()
+
+	plan := snowflake.NewPlan().
+		Add("creating database a", `CREATE DATABASE "a"`, `DROP DATABASE "a"`).
+		Add("altering database a", `ALTER DATABASE "a" SET COMMENT = 'x'`, "")
+	r.Equal(2, plan.Len())
+
+	mock.ExpectExec(`^CREATE DATABASE "a"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`^ALTER DATABASE "a" SET COMMENT = 'x'$`).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	r.NoError(plan.Apply(context.Background(), snowflake.NewClient(db)))
+	r.NoError(mock.ExpectationsWereMet())
+}
+
+func TestPlanApplyUndoesOnFailure(t *testing.T) {
+	r := require.New(t)
+	db, mock, err := sqlmock.New()
+	r.NoError(err)
+	defer db.Close()
+
+	plan := snowflake.NewPlan().
+		Add("creating database a", `CREATE DATABASE "a"`, `DROP DATABASE "a"`).
+		Add("granting usage on a", `GRANT USAGE ON DATABASE "a" TO SHARE "s"`, `REVOKE USAGE ON DATABASE "a" FROM SHARE "s"`).
+		Add("altering share s", `ALTER SHARE "s" SET ACCOUNTS=b`, `ALTER SHARE "s" UNSET ACCOUNTS`)
+
+	mock.ExpectExec(`^CREATE DATABASE "a"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`^GRANT USAGE ON DATABASE "a" TO SHARE "s"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`^ALTER SHARE "s" SET ACCOUNTS=b$`).WillReturnError(errors.New("no such account"))
+	// the failed step itself is not undone, the ones before it in reverse
+	mock.ExpectExec(`^REVOKE USAGE ON DATABASE "a" FROM SHARE "s"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`^DROP DATABASE "a"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = plan.Apply(context.Background(), snowflake.NewClient(db))
+	r.EqualError(err, "error altering share s: no such account")
+	r.NoError(mock.ExpectationsWereMet())
+}
+
+func TestPlanApplyUndoFailure(t *testing.T) {
+	r := require.New(t)
+	db, mock, err := sqlmock.New()
+	r.NoError(err)
+	defer db.Close()
+
+	plan := snowflake.NewPlan().
+		Add("creating database a", `CREATE DATABASE "a"`, `DROP DATABASE "a"`).
+		Add("creating database b", `CREATE DATABASE "b"`, `DROP DATABASE "b"`)
+
+	mock.ExpectExec(`^CREATE DATABASE "a"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`^CREATE DATABASE "b"$`).WillReturnError(errors.New("quota exceeded"))
+	mock.ExpectExec(`^DROP DATABASE "a"$`).WillReturnError(errors.New("insufficient privileges"))
+
+	err = plan.Apply(context.Background(), snowflake.NewClient(db))
+	r.EqualError(err, `could not undo DROP DATABASE "a" (insufficient privileges): error creating database b: quota exceeded`)
+	r.NoError(mock.ExpectationsWereMet())
+}
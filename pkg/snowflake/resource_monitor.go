@@ -60,12 +60,14 @@ const (
 func (rb *ResourceMonitorBuilder) Create() *ResourceMonitorCreateBuilder {
 	return &ResourceMonitorCreateBuilder{
 		CreateBuilder{
-			name:             rb.name,
-			entityType:       rb.entityType,
-			stringProperties: make(map[string]string),
-			boolProperties:   make(map[string]bool),
-			intProperties:    make(map[string]int),
-			floatProperties:  make(map[string]float64),
+			name:                 rb.name,
+			entityType:           rb.entityType,
+			stringProperties:     make(map[string]string),
+			identifierProperties: make(map[string]string),
+			stringListProperties: make(map[string][]string),
+			boolProperties:       make(map[string]bool),
+			intProperties:        make(map[string]int),
+			floatProperties:      make(map[string]float64),
 		},
 		make([]trigger, 0),
 	}
@@ -92,19 +94,9 @@ func (rcb *ResourceMonitorCreateBuilder) SuspendImmediatelyAt(pct int) *Resource
 // Statement returns the SQL statement needed to actually create the resource
 func (rcb *ResourceMonitorCreateBuilder) Statement() string {
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf(`CREATE %v "%v"`, rcb.entityType, rcb.name))
+	sb.WriteString(fmt.Sprintf(`CREATE %v %v`, rcb.entityType, Identifier{rcb.name}))
 
-	for k, v := range rcb.stringProperties {
-		sb.WriteString(fmt.Sprintf(` %v='%v'`, strings.ToUpper(k), EscapeString(v)))
-	}
-
-	for k, v := range rcb.intProperties {
-		sb.WriteString(fmt.Sprintf(` %v=%d`, strings.ToUpper(k), v))
-	}
-
-	for k, v := range rcb.floatProperties {
-		sb.WriteString(fmt.Sprintf(` %v=%.2f`, strings.ToUpper(k), v))
-	}
+	writeProperties(&sb, rcb.stringProperties, rcb.identifierProperties, rcb.stringListProperties, rcb.boolProperties, rcb.intProperties, rcb.floatProperties)
 
 	if len(rcb.triggers) > 0 {
 		sb.WriteString(" TRIGGERS")
@@ -13,7 +13,7 @@ func TestResourceMonitor(t *testing.T) {
 	r.NotNil(rm)
 
 	q := rm.Show()
-	r.Equal(`SHOW RESOURCE MONITORS LIKE 'resource_monitor'`, q)
+	r.Equal(`SHOW RESOURCE MONITORS LIKE 'resource\\_monitor'`, q)
 
 	q = rm.Create().Statement()
 	r.Equal(`CREATE RESOURCE MONITOR "resource_monitor"`, q)
@@ -34,6 +34,16 @@ func TestResourceMonitor(t *testing.T) {
 	q = cb.Statement()
 	r.Equal(`CREATE RESOURCE MONITOR "resource_monitor" FREQUENCY='YEARLY' CREDIT_QUOTA=666.67 TRIGGERS ON 80 PERCENT DO NOTIFY ON 90 PERCENT DO NOTIFY ON 95 PERCENT DO SUSPEND ON 100 PERCENT DO SUSPEND_IMMEDIATE`, q)
 
+	cb.SetString("start_timestamp", "IMMEDIATELY")
+	cb.SetString("end_timestamp", "2030-01-01 00:00")
+	for i := 0; i < 10; i++ {
+		q = cb.Statement()
+		r.Equal(`CREATE RESOURCE MONITOR "resource_monitor" END_TIMESTAMP='2030-01-01 00:00' FREQUENCY='YEARLY' START_TIMESTAMP='IMMEDIATELY' CREDIT_QUOTA=666.67 TRIGGERS ON 80 PERCENT DO NOTIFY ON 90 PERCENT DO NOTIFY ON 95 PERCENT DO SUSPEND ON 100 PERCENT DO SUSPEND_IMMEDIATE`, q)
+	}
+	cb = snowflake.ResourceMonitor("resource_monitor").Create()
+	cb.NotifyAt(80).NotifyAt(90).SuspendAt(95).SuspendImmediatelyAt(100)
+	cb.SetString("frequency", "YEARLY")
+
 	// Check if credit quota can be parsed correctly to float if given an integer
 	cb.SetFloat("credit_quota", 666)
 	q = cb.Statement()
@@ -0,0 +1,152 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/snowflakedb/gosnowflake"
+)
+
+// Snowflake error codes that are worth retrying on a fresh attempt.
+const (
+	errCodeServiceUnavailable = gosnowflake.ErrCodeServiceUnavailable
+	errCodeSessionGone        = gosnowflake.ErrSessionGone
+	errCodeSessionExpired     = 390112
+	errCodeTokenExpired       = 390114
+)
+
+var transientErrorCodes = map[int]bool{
+	errCodeServiceUnavailable: true,
+	errCodeSessionGone:        true,
+	errCodeSessionExpired:     true,
+	errCodeTokenExpired:       true,
+}
+
+// sqlStateSerializationFailure is returned by Snowflake for statements that
+// were rejected because the objects they modify were modified concurrently.
+const sqlStateSerializationFailure = "40001"
+
+// sqlStateClassConnection is the class of SQLSTATEs of connection exceptions.
+// A statement that fails with one may have been executed.
+const sqlStateClassConnection = "08"
+
+// readOnlyKeywords are the first keywords of the statements that are safe to
+// run twice
+var readOnlyKeywords = []string{"SHOW", "DESCRIBE", "DESC", "SELECT"}
+
+// RetryPolicy determines how often, and how long apart, statements that fail
+// with a transient error are attempted.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, it doubles with every
+	// further retry up to MaxDelay
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// DefaultRetryPolicy is the RetryPolicy of a new Client
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   time.Second,
+	MaxDelay:    30 * time.Second,
+}
+
+// Backoff returns the delay before the given retry, starting at 1
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < retry && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+// do calls f until it succeeds, fails with an error that is not transient,
+// runs out of attempts or ctx is done. Only read-only statements are retried
+// on errors that may have occurred after the statement was executed, e.g. on
+// a reset connection.
+func (p RetryPolicy) do(ctx context.Context, stmt string, f func() error) error {
+	isTransient := IsTransientError
+	if isReadOnly(stmt) {
+		isTransient = isTransientReadError
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || attempt >= p.MaxAttempts || ctx.Err() != nil || !isTransient(err) {
+			return err
+		}
+
+		delay := p.Backoff(attempt)
+		log.Printf("[WARN] attempt %d of %d of stmt %v failed with a transient error, retrying in %v: %v", attempt, p.MaxAttempts, Redact(stmt), delay, err)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+// IsTransientError reports whether err is likely to go away when the statement
+// is retried and is known to have occurred before the statement was executed,
+// so that retrying it does not run the statement twice.
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var sfErr *gosnowflake.SnowflakeError
+	if errors.As(err, &sfErr) {
+		return transientErrorCodes[sfErr.Number] || sfErr.SQLState == sqlStateSerializationFailure
+	}
+
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// isTransientReadError reports whether err is likely to go away when a
+// read-only statement is retried. Besides the errors of IsTransientError
+// these are connection errors that may occur while the statement runs.
+func isTransientReadError(err error) bool {
+	if IsTransientError(err) {
+		return true
+	}
+
+	var sfErr *gosnowflake.SnowflakeError
+	if errors.As(err, &sfErr) {
+		return strings.HasPrefix(sfErr.SQLState, sqlStateClassConnection)
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// isReadOnly reports whether stmt only reads, so that running it twice is safe
+func isReadOnly(stmt string) bool {
+	fields := strings.Fields(stmt)
+	if len(fields) == 0 {
+		return false
+	}
+	for _, keyword := range readOnlyKeywords {
+		if strings.EqualFold(fields[0], keyword) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package snowflake_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/snowflakedb/gosnowflake"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errTokenExpired = &gosnowflake.SnowflakeError{Number: 390114, Message: "Authentication token has expired."}
+	errConcurrent   = &gosnowflake.SnowflakeError{Number: 625, SQLState: "40001", Message: "object is being modified concurrently"}
+	errConnection   = &gosnowflake.SnowflakeError{Number: 1, SQLState: "08006", Message: "connection failure"}
+	errDoesNotExist = &gosnowflake.SnowflakeError{Number: 2003, SQLState: "02000", Message: "does not exist"}
+	errDial         = &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	errReset        = &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+)
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		err       error
+		transient bool
+	}{
+		{nil, false},
+		{errTokenExpired, true},
+		{errConcurrent, true},
+		{errConnection, false},
+		{pkgerrors.Wrap(errTokenExpired, "wrapped"), true},
+		{driver.ErrBadConn, true},
+		{errDial, true},
+		{errReset, false},
+		{io.ErrUnexpectedEOF, false},
+		{errDoesNotExist, false},
+		{errors.New("syntax error"), false},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v", tt.err), func(t *testing.T) {
+			require.Equal(t, tt.transient, snowflake.IsTransientError(tt.err))
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	r := require.New(t)
+	p := snowflake.RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	r.Equal(time.Second, p.Backoff(1))
+	r.Equal(2*time.Second, p.Backoff(2))
+	r.Equal(4*time.Second, p.Backoff(3))
+	r.Equal(5*time.Second, p.Backoff(4))
+	r.Equal(5*time.Second, p.Backoff(10))
+}
+
+func TestExecRetries(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxAttempts int
+		errs        []error
+		err         error
+	}{
+		{"succeeds after transient errors", 3, []error{errTokenExpired, errConcurrent}, nil},
+		{"gives up after max attempts", 2, []error{errTokenExpired, errConnection}, errConnection},
+		{"does not retry other errors", 3, []error{errDoesNotExist}, errDoesNotExist},
+		{"retries disabled", 1, []error{errTokenExpired}, errTokenExpired},
+		{"retries connection refused", 3, []error{errDial}, nil},
+		{"does not retry a reset connection", 3, []error{errReset}, errReset},
+		{"does not retry a connection failure", 3, []error{errConnection}, errConnection},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			db, mock, err := sqlmock.New()
+			r.NoError(err)
+			defer db.Close()
+			client := snowflake.NewClient(db)
+			client.RetryPolicy = snowflake.RetryPolicy{MaxAttempts: tt.maxAttempts}
+
+			for _, e := range tt.errs {
+				mock.ExpectExec(`^DROP ROLE "r"$`).WillReturnError(e)
+			}
+			if tt.err == nil {
+				mock.ExpectExec(`^DROP ROLE "r"$`).WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			err = client.Exec(`DROP ROLE "r"`)
+			r.Equal(tt.err, err)
+			r.NoError(mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestQueryRetries(t *testing.T) {
+	r := require.New(t)
+	db, mock, err := sqlmock.New()
+	r.NoError(err)
+	defer db.Close()
+	client := snowflake.NewClient(db)
+	client.RetryPolicy = snowflake.RetryPolicy{MaxAttempts: 3}
+
+	mock.ExpectQuery(`^SHOW ROLES$`).WillReturnError(errTokenExpired)
+	mock.ExpectQuery(`^SHOW ROLES$`).WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("r"))
+
+	rows, err := client.Query("SHOW ROLES")
+	r.NoError(err)
+	defer rows.Close()
+	r.True(rows.Next())
+	r.NoError(mock.ExpectationsWereMet())
+}
+
+func TestQueryRetriesResetConnection(t *testing.T) {
+	r := require.New(t)
+	db, mock, err := sqlmock.New()
+	r.NoError(err)
+	defer db.Close()
+	client := snowflake.NewClient(db)
+	client.RetryPolicy = snowflake.RetryPolicy{MaxAttempts: 3}
+
+	// read-only statements are safe to run twice
+	mock.ExpectQuery(`^SHOW ROLES$`).WillReturnError(errReset)
+	mock.ExpectQuery(`^SHOW ROLES$`).WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("r"))
+
+	rows, err := client.Query("SHOW ROLES")
+	r.NoError(err)
+	defer rows.Close()
+	r.True(rows.Next())
+	r.NoError(mock.ExpectationsWereMet())
+}
+
+func TestQueryRowRetries(t *testing.T) {
+	r := require.New(t)
+	db, mock, err := sqlmock.New()
+	r.NoError(err)
+	defer db.Close()
+	client := snowflake.NewClient(db)
+	client.RetryPolicy = snowflake.RetryPolicy{MaxAttempts: 3}
+
+	mock.ExpectQuery(`^SHOW ROLES$`).WillReturnError(errConnection)
+	mock.ExpectQuery(`^SHOW ROLES$`).WillReturnError(errDoesNotExist)
+
+	var name string
+	err = client.QueryRow("SHOW ROLES").Scan(&name)
+	r.Equal(errDoesNotExist, err)
+	r.NoError(mock.ExpectationsWereMet())
+}
+
+func TestExecContextCancelled(t *testing.T) {
+	r := require.New(t)
+	db, mock, err := sqlmock.New()
+	r.NoError(err)
+	defer db.Close()
+	client := snowflake.NewClient(db)
+	client.RetryPolicy = snowflake.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mock.ExpectExec(`^DROP ROLE "r"$`).WillReturnError(errTokenExpired).WillDelayFor(10 * time.Millisecond)
+	go cancel()
+
+	// the retry is abandoned rather than waiting out the backoff
+	err = client.ExecContext(ctx, `DROP ROLE "r"`)
+	r.Error(err)
+	r.NoError(mock.ExpectationsWereMet())
+}
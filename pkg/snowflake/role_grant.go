@@ -8,7 +8,7 @@ type RoleGrantBuilder struct {
 
 type RoleGrantExecutable struct {
 	name        string
-	granteeType granteeType
+	granteeType GranteeType
 	grantee     string
 }
 
@@ -19,7 +19,7 @@ func RoleGrant(name string) *RoleGrantBuilder {
 func (gb *RoleGrantBuilder) User(user string) *RoleGrantExecutable {
 	return &RoleGrantExecutable{
 		name:        gb.name,
-		granteeType: userType,
+		granteeType: UserGrantee,
 		grantee:     user,
 	}
 }
@@ -27,15 +27,15 @@ func (gb *RoleGrantBuilder) User(user string) *RoleGrantExecutable {
 func (gb *RoleGrantBuilder) Role(role string) *RoleGrantExecutable {
 	return &RoleGrantExecutable{
 		name:        gb.name,
-		granteeType: roleType,
+		granteeType: RoleGrantee,
 		grantee:     role,
 	}
 }
 
 func (gr *RoleGrantExecutable) Grant() string {
-	return fmt.Sprintf(`GRANT ROLE "%s" TO %s "%s"`, gr.name, gr.granteeType, gr.grantee) // nolint: gosec
+	return fmt.Sprintf(`GRANT ROLE %v TO %s %v`, Identifier{gr.name}, gr.granteeType, Identifier{gr.grantee}) // nolint: gosec
 }
 
 func (gr *RoleGrantExecutable) Revoke() string {
-	return fmt.Sprintf(`REVOKE ROLE "%s" FROM %s "%s"`, gr.name, gr.granteeType, gr.grantee) // nolint: gosec
+	return fmt.Sprintf(`REVOKE ROLE %v FROM %s %v`, Identifier{gr.name}, gr.granteeType, Identifier{gr.grantee}) // nolint: gosec
 }
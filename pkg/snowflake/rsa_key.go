@@ -0,0 +1,58 @@
+package snowflake
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// NormalizeRSAPublicKey returns key in the form Snowflake expects for
+// RSA_PUBLIC_KEY: the base64 body on one line, without the PEM header,
+// trailer and line breaks. Keys already in that form are returned as is.
+func NormalizeRSAPublicKey(key string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(key, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "-----") {
+			continue
+		}
+		b.WriteString(strings.Join(strings.Fields(line), ""))
+	}
+	return b.String()
+}
+
+// RSAPublicKeyFingerprint returns the fingerprint Snowflake shows as
+// RSA_PUBLIC_KEY_FP for key, in PEM or normalized form: SHA256: followed by
+// the base64 SHA-256 digest of the DER encoded key. It fails if key is not an
+// RSA public key.
+func RSAPublicKeyFingerprint(key string) (string, error) {
+	der, err := base64.StdEncoding.DecodeString(NormalizeRSAPublicKey(key))
+	if err != nil {
+		return "", fmt.Errorf("public key is not base64: %v", err)
+	}
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse public key: %v", err)
+	}
+	if _, ok := pub.(*rsa.PublicKey); !ok {
+		return "", fmt.Errorf("public key is not an RSA key")
+	}
+	sum := sha256.Sum256(der)
+	return "SHA256:" + base64.StdEncoding.EncodeToString(sum[:]), nil
+}
+
+// ValidateRSAPublicKey checks that val is an RSA public key, in PEM or
+// normalized form
+func ValidateRSAPublicKey(val interface{}) (warns []string, errs []error) {
+	key, ok := val.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("Unable to assert public key as string type.")}
+	}
+	if _, err := RSAPublicKeyFingerprint(key); err != nil {
+		errs = append(errs, err)
+	}
+	return
+}
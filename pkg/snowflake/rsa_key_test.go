@@ -0,0 +1,54 @@
+package snowflake
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRSAPublicKey(t *testing.T) {
+	r := require.New(t)
+
+	priv, err := rsa.GenerateKey(rand.Reader, 1024)
+	r.NoError(err)
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	r.NoError(err)
+	body := base64.StdEncoding.EncodeToString(der)
+	pemKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	r.Equal(body, NormalizeRSAPublicKey(pemKey))
+	r.Equal(body, NormalizeRSAPublicKey(strings.Replace(pemKey, "\n", "\r\n", -1)))
+	r.Equal(body, NormalizeRSAPublicKey(body))
+	r.Equal(body, NormalizeRSAPublicKey("  "+body[:10]+"\n"+body[10:]+"\n"))
+
+	sum := sha256.Sum256(der)
+	expected := "SHA256:" + base64.StdEncoding.EncodeToString(sum[:])
+	for _, key := range []string{pemKey, body} {
+		fp, err := RSAPublicKeyFingerprint(key)
+		r.NoError(err)
+		r.Equal(expected, fp)
+		_, errs := ValidateRSAPublicKey(key)
+		r.Empty(errs)
+	}
+
+	ec, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	r.NoError(err)
+	ecDer, err := x509.MarshalPKIXPublicKey(&ec.PublicKey)
+	r.NoError(err)
+	_, err = RSAPublicKeyFingerprint(base64.StdEncoding.EncodeToString(ecDer))
+	r.EqualError(err, "public key is not an RSA key")
+
+	_, errs := ValidateRSAPublicKey("not a key!")
+	r.Len(errs, 1)
+	_, errs = ValidateRSAPublicKey("bm90IGEga2V5")
+	r.Len(errs, 1)
+}
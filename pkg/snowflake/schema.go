@@ -1,6 +1,7 @@
 package snowflake
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
@@ -21,15 +22,10 @@ type SchemaBuilder struct {
 
 // QualifiedName prepends the db if set and escapes everything nicely
 func (sb *SchemaBuilder) QualifiedName() string {
-	var n strings.Builder
-
 	if sb.db != "" {
-		n.WriteString(fmt.Sprintf(`"%v".`, sb.db))
+		return Identifier{sb.db, sb.name}.String()
 	}
-
-	n.WriteString(fmt.Sprintf(`"%v"`, sb.name))
-
-	return n.String()
+	return Identifier{sb.name}.String()
 }
 
 // Managed adds the WITH MANAGED ACCESS flag to the SchemaBuilder
@@ -109,13 +105,13 @@ func (sb *SchemaBuilder) Create() string {
 
 // Rename returns the SQL query that will rename the schema.
 func (sb *SchemaBuilder) Rename(newName string) string {
-	return fmt.Sprintf(`ALTER SCHEMA %v RENAME TO "%v"`, sb.QualifiedName(), newName)
+	return fmt.Sprintf(`ALTER SCHEMA %v RENAME TO %v`, sb.QualifiedName(), Identifier{newName})
 }
 
 // Swap returns the SQL query that Swaps all objects (tables, views, etc.) and
 // metadata, including identifiers, between the two specified schemas.
 func (sb *SchemaBuilder) Swap(targetSchema string) string {
-	return fmt.Sprintf(`ALTER SCHEMA %v SWAP WITH "%v"`, sb.QualifiedName(), targetSchema)
+	return fmt.Sprintf(`ALTER SCHEMA %v SWAP WITH %v`, sb.QualifiedName(), Identifier{targetSchema})
 }
 
 // ChangeComment returns the SQL query that will update the comment on the schema.
@@ -138,6 +134,11 @@ func (sb *SchemaBuilder) RemoveDataRetentionDays() string {
 	return fmt.Sprintf(`ALTER SCHEMA %v UNSET DATA_RETENTION_TIME_IN_DAYS`, sb.QualifiedName())
 }
 
+// ShowParameters returns the SQL query that will show the parameters of the schema.
+func (sb *SchemaBuilder) ShowParameters() string {
+	return fmt.Sprintf(`SHOW PARAMETERS IN SCHEMA %v`, sb.QualifiedName())
+}
+
 // Manage returns the SQL query that will enable managed access for a schema.
 func (sb *SchemaBuilder) Manage() string {
 	return fmt.Sprintf(`ALTER SCHEMA %v ENABLE MANAGED ACCESS`, sb.QualifiedName())
@@ -167,10 +168,10 @@ func (sb *SchemaBuilder) Use() string {
 func (sb *SchemaBuilder) Show() string {
 	q := strings.Builder{}
 
-	q.WriteString(fmt.Sprintf(`SHOW SCHEMAS LIKE '%v'`, sb.name))
+	q.WriteString(fmt.Sprintf(`SHOW SCHEMAS LIKE %v`, LikePattern(sb.name)))
 
 	if sb.db != "" {
-		q.WriteString(fmt.Sprintf(` IN DATABASE "%v"`, sb.db))
+		q.WriteString(fmt.Sprintf(` IN DATABASE %v`, Identifier{sb.db}))
 	}
 
 	return q.String()
@@ -189,3 +190,25 @@ func ScanSchema(row *sqlx.Row) (*schema, error) {
 	err := row.StructScan(r)
 	return r, err
 }
+
+// ShowSchemasInDatabase returns the SQL query that will show all schemas in
+// the database db
+func ShowSchemasInDatabase(db string) string {
+	return fmt.Sprintf(`SHOW SCHEMAS IN DATABASE %v`, Identifier{db})
+}
+
+// ReadSchema returns the schema name of database db. All schemas of the
+// database are read with a single query that is cached for the Reads of the
+// other schemas. It returns sql.ErrNoRows if the schema does not exist.
+func ReadSchema(ctx context.Context, c *Client, db, name string) (*schema, error) {
+	s, err := c.showFind(ctx,
+		ShowSchemasInDatabase(db),
+		fmt.Sprintf(`SHOW SCHEMAS LIKE %v IN DATABASE %v`, LikePattern(name), Identifier{db}),
+		func() interface{} { return &schema{} },
+		func(s interface{}) bool { return s.(*schema).Name.String == name },
+	)
+	if err != nil {
+		return nil, err
+	}
+	return s.(*schema), nil
+}
@@ -1,7 +1,7 @@
 package snowflake
 
 import (
-	"database/sql"
+	"context"
 	"fmt"
 	"strings"
 
@@ -24,11 +24,7 @@ type StageBuilder struct {
 
 // QualifiedName prepends the db and schema and escapes everything nicely
 func (sb *StageBuilder) QualifiedName() string {
-	var n strings.Builder
-
-	n.WriteString(fmt.Sprintf(`"%v"."%v"."%v"`, sb.db, sb.schema, sb.name))
-
-	return n.String()
+	return Identifier{sb.db, sb.schema, sb.name}.String()
 }
 
 // WithURL adds a URL to the StageBuilder
@@ -99,7 +95,7 @@ func (sb *StageBuilder) Create() string {
 	q.WriteString(fmt.Sprintf(` STAGE %v`, sb.QualifiedName()))
 
 	if sb.url != "" {
-		q.WriteString(fmt.Sprintf(` URL = '%v'`, sb.url))
+		q.WriteString(fmt.Sprintf(` URL = '%v'`, EscapeString(sb.url)))
 	}
 
 	if sb.credentials != "" {
@@ -131,12 +127,12 @@ func (sb *StageBuilder) Create() string {
 
 // Rename returns the SQL query that will rename the stage.
 func (sb *StageBuilder) Rename(newName string) string {
-	return fmt.Sprintf(`ALTER STAGE %v RENAME TO "%v"`, sb.QualifiedName(), newName)
+	return fmt.Sprintf(`ALTER STAGE %v RENAME TO %v`, sb.QualifiedName(), Identifier{newName})
 }
 
 // ChangeComment returns the SQL query that will update the comment on the stage.
 func (sb *StageBuilder) ChangeComment(c string) string {
-	return fmt.Sprintf(`ALTER STAGE %v SET COMMENT = '%v'`, sb.QualifiedName(), c)
+	return fmt.Sprintf(`ALTER STAGE %v SET COMMENT = '%v'`, sb.QualifiedName(), EscapeString(c))
 }
 
 // RemoveComment returns the SQL query that will remove the comment on the stage.
@@ -146,7 +142,7 @@ func (sb *StageBuilder) RemoveComment() string {
 
 // ChangeURL returns the SQL query that will update the url on the stage.
 func (sb *StageBuilder) ChangeURL(u string) string {
-	return fmt.Sprintf(`ALTER STAGE %v SET URL = '%v'`, sb.QualifiedName(), u)
+	return fmt.Sprintf(`ALTER STAGE %v SET URL = '%v'`, sb.QualifiedName(), EscapeString(u))
 }
 
 // ChangeCredentials returns the SQL query that will update the credentials on the stage.
@@ -191,7 +187,7 @@ func (sb *StageBuilder) Describe() string {
 
 // Show returns the SQL query that will show a stage.
 func (sb *StageBuilder) Show() string {
-	return fmt.Sprintf(`SHOW STAGES LIKE '%v' IN DATABASE "%v"`, sb.name, sb.db)
+	return fmt.Sprintf(`SHOW STAGES LIKE %v IN DATABASE %v`, LikePattern(sb.name), Identifier{sb.db})
 }
 
 type stage struct {
@@ -208,6 +204,30 @@ func ScanStageShow(row *sqlx.Row) (*stage, error) {
 	return r, err
 }
 
+// ShowStagesInSchema returns the SQL query that will show all stages in the
+// schema of the database db
+func ShowStagesInSchema(db, schema string) string {
+	return fmt.Sprintf(`SHOW STAGES IN SCHEMA %v`, Identifier{db, schema})
+}
+
+// ReadStage returns the stage name in schema of database db. All stages of
+// the schema are read with a single query that is cached for the Reads of the
+// other stages. It returns sql.ErrNoRows if the stage does not exist.
+func ReadStage(ctx context.Context, c *Client, db, schema, name string) (*stage, error) {
+	s, err := c.showFind(ctx,
+		ShowStagesInSchema(db, schema),
+		fmt.Sprintf(`SHOW STAGES LIKE %v IN SCHEMA %v`, LikePattern(name), Identifier{db, schema}),
+		func() interface{} { return &stage{} },
+		func(s interface{}) bool {
+			return s.(*stage).Name != nil && *s.(*stage).Name == name
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return s.(*stage), nil
+}
+
 type descStageResult struct {
 	Url              string
 	AwsExternalID    string
@@ -223,11 +243,11 @@ type descStageRow struct {
 	PropertyDefault string `db:"property_default"`
 }
 
-func DescStage(db *sql.DB, query string) (*descStageResult, error) {
+func DescStage(ctx context.Context, c *Client, query string) (*descStageResult, error) {
 	r := &descStageResult{}
 	var ff []string
 	var co []string
-	rows, err := Query(db, query)
+	rows, err := c.QueryContext(ctx, query)
 	if err != nil {
 		return r, err
 	}
@@ -0,0 +1,34 @@
+package snowflake
+
+import (
+	"strings"
+)
+
+// NormalizeStatement returns a canonical form of the statement sql: its
+// canonical tokens, without comments or trailing semicolons, separated by
+// single spaces. Two statements with the same normal form mean the same,
+// even if Snowflake reformatted one of them, while the case of string
+// literals and quoted identifiers is still significant.
+func NormalizeStatement(sql string) (string, error) {
+	tokens, err := CanonicalTokens(sql)
+	if err != nil {
+		return "", err
+	}
+	for len(tokens) > 0 && tokens[len(tokens)-1] == ";" {
+		tokens = tokens[:len(tokens)-1]
+	}
+	return strings.Join(tokens, " "), nil
+}
+
+// EqualStatements reports whether the statements a and b have the same
+// normal form. Statements that cannot be tokenized, e.g. with an unterminated
+// literal, are only equal if their text is, apart from surrounding
+// whitespace.
+func EqualStatements(a, b string) bool {
+	normalA, errA := NormalizeStatement(a)
+	normalB, errB := NormalizeStatement(b)
+	if errA != nil || errB != nil {
+		return strings.TrimSpace(a) == strings.TrimSpace(b)
+	}
+	return normalA == normalB
+}
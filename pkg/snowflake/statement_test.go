@@ -0,0 +1,47 @@
+package snowflake_test
+
+import (
+	"testing"
+
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeStatement(t *testing.T) {
+	r := require.New(t)
+
+	n, err := snowflake.NormalizeStatement("select a,\n\tb -- columns\nfrom \"T\" where c = 'It''s';;\n")
+	r.NoError(err)
+	r.Equal(`SELECT A , B FROM T WHERE C = 'It\'s'`, n)
+
+	_, err = snowflake.NormalizeStatement("select 'a")
+	r.Error(err)
+}
+
+func TestEqualStatements(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     string
+		b     string
+		equal bool
+	}{
+		{"identical", "select * from foo;", "select * from foo;", true},
+		{"whitespace", "select *\r\n  from foo", "select * from foo", true},
+		{"keyword case", "SELECT * FROM foo", "select * from FOO", true},
+		{"semicolon", "select * from foo;\n", "select * from foo", true},
+		{"comments", "-- all of foo\nselect * /* all */ from foo", "select * from foo", true},
+		{"literal case", "select * from foo where a = 'X'", "select * from foo where a = 'x'", false},
+		{"literal spaces", "select 'a  b'", "select 'a b'", false},
+		{"quoted identifier case", `select * from "foo"`, `select * from "FOO"`, false},
+		{"quoted uppercase identifier", `select * from "FOO"`, `select * from foo`, true},
+		{"different table", "select * from foo", "select * from bar", false},
+		{"semicolon in literal", "select ';'", "select ''", false},
+		{"unterminated", "select 'a", "select 'a", true},
+		{"unterminated differing", "select 'a", "SELECT 'a", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.equal, snowflake.EqualStatements(tt.a, tt.b))
+		})
+	}
+}
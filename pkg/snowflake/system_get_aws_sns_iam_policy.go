@@ -20,7 +20,7 @@ func SystemGetAWSSNSIAMPolicy(awsSnsTopicArn string) *SystemGetAWSSNSIAMPolicyBu
 
 // Select generates the select statement for obtaining the aws sns iam policy
 func (pb *SystemGetAWSSNSIAMPolicyBuilder) Select() string {
-	return fmt.Sprintf(`SELECT SYSTEM$GET_AWS_SNS_IAM_POLICY('%v') AS "policy"`, pb.awsSnsTopicArn)
+	return fmt.Sprintf(`SELECT SYSTEM$GET_AWS_SNS_IAM_POLICY('%v') AS "policy"`, EscapeString(pb.awsSnsTopicArn))
 }
 
 type awsSNSIAMPolicy struct {
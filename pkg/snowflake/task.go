@@ -1,6 +1,7 @@
 package snowflake
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strconv"
@@ -27,11 +28,7 @@ type TaskBuilder struct {
 
 // GetFullName prepends db and schema to in parameter
 func (tb *TaskBuilder) GetFullName(in string) string {
-	var n strings.Builder
-
-	n.WriteString(fmt.Sprintf(`"%v"."%v"."%v"`, tb.db, tb.schema, in))
-
-	return n.String()
+	return Identifier{tb.db, tb.schema, in}.String()
 }
 
 // QualifiedName prepends the db and schema and escapes everything nicely
@@ -116,7 +113,7 @@ func (tb *TaskBuilder) Create() string {
 	q.WriteString(`CREATE`)
 
 	q.WriteString(fmt.Sprintf(` TASK %v`, tb.QualifiedName()))
-	q.WriteString(fmt.Sprintf(` WAREHOUSE = "%v"`, EscapeString(tb.warehouse)))
+	q.WriteString(fmt.Sprintf(` WAREHOUSE = %v`, Identifier{tb.warehouse}))
 
 	if tb.schedule != "" {
 		q.WriteString(fmt.Sprintf(` SCHEDULE = '%v'`, EscapeString(tb.schedule)))
@@ -161,7 +158,7 @@ func (tb *TaskBuilder) Create() string {
 
 // ChangeWarehouse returns the sql that will change the warehouse for the task.
 func (tb *TaskBuilder) ChangeWarehouse(newWh string) string {
-	return fmt.Sprintf(`ALTER TASK %v SET WAREHOUSE = "%v"`, tb.QualifiedName(), EscapeString(newWh))
+	return fmt.Sprintf(`ALTER TASK %v SET WAREHOUSE = %v`, tb.QualifiedName(), Identifier{newWh})
 }
 
 // ChangeSchedule returns the sql that will change the schedule for the task.
@@ -263,12 +260,12 @@ func (tb *TaskBuilder) Describe() string {
 
 // Show returns the sql that will show a task.
 func (tb *TaskBuilder) Show() string {
-	return fmt.Sprintf(`SHOW TASKS LIKE '%v' IN DATABASE "%v"`, EscapeString(tb.name), EscapeString(tb.db))
+	return fmt.Sprintf(`SHOW TASKS LIKE %v IN DATABASE %v`, LikePattern(tb.name), Identifier{tb.db})
 }
 
 // ShowParameters returns the query to show the session parameters for the task
 func (tb *TaskBuilder) ShowParameters() string {
-	return fmt.Sprintf(`SHOW PARAMETERS IN TASK %v`, tb.QualifiedName())
+	return ShowParameters(TaskType, Identifier{tb.db, tb.schema, tb.name})
 }
 
 // SetDisabled disables the task builder
@@ -322,27 +319,24 @@ func ScanTask(row *sqlx.Row) (*task, error) {
 	return t, e
 }
 
-// taskParams struct to represent a row of parameters
-type taskParams struct {
-	Key          string `db:"key"`
-	Value        string `db:"value"`
-	DefaultValue string `db:"default"`
-	Level        string `db:"level"`
-	Description  string `db:"description"`
+// ShowTasksInSchema returns the SQL query that will show all tasks in the
+// schema of the database db
+func ShowTasksInSchema(db, schema string) string {
+	return fmt.Sprintf(`SHOW TASKS IN SCHEMA %v`, Identifier{db, schema})
 }
 
-// ScanTaskParameters takes a database row and converts it to a task parameter pointer
-func ScanTaskParameters(rows *sqlx.Rows) ([]*taskParams, error) {
-	t := []*taskParams{}
-
-	for rows.Next() {
-		r := &taskParams{}
-		err := rows.StructScan(r)
-		if err != nil {
-			return nil, err
-		}
-		t = append(t, r)
-
+// ReadTask returns the task name in schema of database db. All tasks of the
+// schema are read with a single query that is cached for the Reads of the
+// other tasks. It returns sql.ErrNoRows if the task does not exist.
+func ReadTask(ctx context.Context, c *Client, db, schema, name string) (*task, error) {
+	t, err := c.showFind(ctx,
+		ShowTasksInSchema(db, schema),
+		fmt.Sprintf(`SHOW TASKS LIKE %v IN SCHEMA %v`, LikePattern(name), Identifier{db, schema}),
+		func() interface{} { return &task{} },
+		func(t interface{}) bool { return t.(*task).Name == name },
+	)
+	if err != nil {
+		return nil, err
 	}
-	return t, nil
+	return t.(*task), nil
 }
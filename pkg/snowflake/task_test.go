@@ -149,5 +149,5 @@ func TestDescribe(t *testing.T) {
 func TestShow(t *testing.T) {
 	r := require.New(t)
 	st := Task("test_task", "test_db", "test_schema")
-	r.Equal(st.Show(), `SHOW TASKS LIKE 'test_task' IN DATABASE "test_db"`)
+	r.Equal(st.Show(), `SHOW TASKS LIKE 'test\\_task' IN DATABASE "test_db"`)
 }
@@ -33,3 +33,29 @@ func ScanUser(row *sqlx.Row) (*user, error) {
 	err := row.StructScan(r)
 	return r, err
 }
+
+// userProperty is a row of DESCRIBE USER
+type userProperty struct {
+	Property string         `db:"property"`
+	Value    sql.NullString `db:"value"`
+}
+
+// ScanUserDescription scans the rows of DESCRIBE USER into the values of the
+// user's properties by name, e.g. RSA_PUBLIC_KEY_FP. Unset values, which
+// Snowflake shows as null, are empty.
+func ScanUserDescription(rows *sqlx.Rows) (map[string]string, error) {
+	defer rows.Close()
+
+	values := map[string]string{}
+	for rows.Next() {
+		p := &userProperty{}
+		err := rows.StructScan(p)
+		if err != nil {
+			return nil, err
+		}
+		if p.Value.Valid && p.Value.String != "null" {
+			values[p.Property] = p.Value.String
+		}
+	}
+	return values, rows.Err()
+}
@@ -3,7 +3,9 @@ package snowflake_test
 import (
 	"testing"
 
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
+	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/require"
 )
 
@@ -40,3 +42,25 @@ func TestUser(t *testing.T) {
 	q = c.Statement()
 	r.Equal(`CREATE USER "user1" FOO='bar' BAM=false`, q)
 }
+
+func TestScanUserDescription(t *testing.T) {
+	r := require.New(t)
+
+	db, mock, err := sqlmock.New()
+	r.NoError(err)
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"property", "value", "default", "description"}).
+		AddRow("NAME", "USER1", "null", "Name").
+		AddRow("RSA_PUBLIC_KEY_FP", "SHA256:abc=", "null", "Fingerprint of user's RSA public key.").
+		AddRow("RSA_PUBLIC_KEY_2_FP", "null", "null", "Fingerprint of user's second RSA public key.").
+		AddRow("COMMENT", nil, "null", "user comment associated to an object in the dictionary")
+	mock.ExpectQuery(`^DESCRIBE USER "user1"$`).WillReturnRows(rows)
+
+	sdb := sqlx.NewDb(db, "snowflake").Unsafe()
+	result, err := sdb.Queryx(snowflake.User("user1").Describe())
+	r.NoError(err)
+	desc, err := snowflake.ScanUserDescription(result)
+	r.NoError(err)
+	r.Equal(map[string]string{"NAME": "USER1", "RSA_PUBLIC_KEY_FP": "SHA256:abc="}, desc)
+}
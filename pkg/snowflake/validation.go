@@ -4,6 +4,9 @@ import "github.com/pkg/errors"
 
 // ValidateIdentifier implements a strict definition of valid identifiers from
 // https://docs.snowflake.net/manuals/sql-reference/identifiers-syntax.html
+// It does not depend on the IdentifierPolicy, as Terraform validates
+// configurations before the provider is configured; see
+// IdentifierPolicy.Validate.
 func ValidateIdentifier(val interface{}) (warns []string, errs []error) {
 	name, ok := val.(string)
 	if !ok {
@@ -1,6 +1,7 @@
 package snowflake
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
@@ -21,23 +22,17 @@ type ViewBuilder struct {
 
 // QualifiedName prepends the db and schema if set and escapes everything nicely
 func (vb *ViewBuilder) QualifiedName() string {
-	var n strings.Builder
-
-	if vb.db != "" && vb.schema != "" {
-		n.WriteString(fmt.Sprintf(`"%v"."%v".`, vb.db, vb.schema))
-	}
-
-	if vb.db != "" && vb.schema == "" {
-		n.WriteString(fmt.Sprintf(`"%v"..`, vb.db))
-	}
-
-	if vb.db == "" && vb.schema != "" {
-		n.WriteString(fmt.Sprintf(`"%v".`, vb.schema))
+	switch {
+	case vb.db != "" && vb.schema != "":
+		return Identifier{vb.db, vb.schema, vb.name}.String()
+	case vb.db != "":
+		// db..name is the object in the PUBLIC schema of db
+		return Identifier{vb.db, "", vb.name}.String()
+	case vb.schema != "":
+		return Identifier{vb.schema, vb.name}.String()
+	default:
+		return Identifier{vb.name}.String()
 	}
-
-	n.WriteString(fmt.Sprintf(`"%v"`, vb.name))
-
-	return n.String()
 }
 
 // WithComment adds a comment to the ViewBuilder
@@ -152,9 +147,9 @@ func (vb *ViewBuilder) RemoveComment() string {
 // Show returns the SQL query that will show the row representing this view.
 func (vb *ViewBuilder) Show() string {
 	if vb.db == "" {
-		return fmt.Sprintf(`SHOW VIEWS LIKE '%v'`, vb.name)
+		return fmt.Sprintf(`SHOW VIEWS LIKE %v`, LikePattern(vb.name))
 	}
-	return fmt.Sprintf(`SHOW VIEWS LIKE '%v' IN DATABASE "%v"`, vb.name, vb.db)
+	return fmt.Sprintf(`SHOW VIEWS LIKE %v IN DATABASE %v`, LikePattern(vb.name), Identifier{vb.db})
 }
 
 // Drop returns the SQL query that will drop the row representing this view.
@@ -176,3 +171,25 @@ func ScanView(row *sqlx.Row) (*view, error) {
 	err := row.StructScan(r)
 	return r, err
 }
+
+// ShowViewsInSchema returns the SQL query that will show all views in the
+// schema of the database db
+func ShowViewsInSchema(db, schema string) string {
+	return fmt.Sprintf(`SHOW VIEWS IN SCHEMA %v`, Identifier{db, schema})
+}
+
+// ReadView returns the view name in schema of database db. All views of the
+// schema are read with a single query that is cached for the Reads of the
+// other views. It returns sql.ErrNoRows if the view does not exist.
+func ReadView(ctx context.Context, c *Client, database, schema, name string) (*view, error) {
+	v, err := c.showFind(ctx,
+		ShowViewsInSchema(database, schema),
+		fmt.Sprintf(`SHOW VIEWS LIKE %v IN SCHEMA %v`, LikePattern(name), Identifier{database, schema}),
+		func() interface{} { return &view{} },
+		func(v interface{}) bool { return v.(*view).Name.String == name },
+	)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*view), nil
+}
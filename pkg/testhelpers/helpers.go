@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/chanzuckerberg/terraform-provider-snowflake/pkg/snowflake"
 	"github.com/stretchr/testify/require"
 )
 
@@ -23,3 +24,10 @@ func WithMockDb(t *testing.T, f func(*sql.DB, sqlmock.Sqlmock)) {
 	}
 
 }
+
+// WithMockClient is WithMockDb for functions that take the provider's meta
+func WithMockClient(t *testing.T, f func(*snowflake.Client, sqlmock.Sqlmock)) {
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		f(snowflake.NewClient(db), mock)
+	})
+}